@@ -43,3 +43,13 @@ ifneq ($(HAS_SERVER),)
 	rm -rf ./calendar/utils/bot/mock_bot
 	rm -rf ./calendar/store/mock_store
 endif
+
+# Builds the plugin with the fake calendar provider, for development only. Never release this build.
+.PHONY: dist-fake
+dist-fake: GO_BUILD_FLAGS += -tags fake
+dist-fake: dist
+
+# Builds and installs the plugin with the fake calendar provider, for development only.
+.PHONY: deploy-fake
+deploy-fake: GO_BUILD_FLAGS += -tags fake
+deploy-fake: deploy
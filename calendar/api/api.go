@@ -35,6 +35,20 @@ func Init(h *httputils.Handler, env engine.Env, notificationProcessor engine.Not
 	postActionRouter.HandleFunc(config.PathTentative, api.postActionTentative).Methods(http.MethodPost)
 	postActionRouter.HandleFunc(config.PathRespond, api.postActionRespond).Methods(http.MethodPost)
 	postActionRouter.HandleFunc(config.PathConfirmStatusChange, api.postActionConfirmStatusChange).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathProposeNewTime, api.postActionProposeNewTime).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathAcceptProposedTime, api.postActionAcceptProposedTime).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathBookMeetingTime, api.postActionBookMeetingTime).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathPreviewEventImport, api.postActionPreviewEventImport).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathImportEvent, api.postActionImportEvent).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathSnoozeReminder, api.postActionSnoozeReminder).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathJoinMeeting, api.postActionJoinMeeting).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathRunningLate, api.postActionRunningLate).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathLinkEvent, api.postActionLinkEvent).Methods(http.MethodPost)
+
+	dialogsRouter := h.Router.PathPrefix(config.PathDialogs).Subrouter()
+	dialogsRouter.HandleFunc(config.PathProposeNewTime, api.dialogProposeNewTime).Methods(http.MethodPost)
+
+	h.Router.PathPrefix(config.PathRemote).HandlerFunc(api.remote)
 
 	dialogRouter := h.Router.PathPrefix(config.PathAutocomplete).Subrouter()
 	dialogRouter.HandleFunc(config.PathUsers, api.autocompleteConnectedUsers)
@@ -42,6 +56,10 @@ func Init(h *httputils.Handler, env engine.Env, notificationProcessor engine.Not
 	apiRoutes := h.Router.PathPrefix(config.InternalAPIPath).Subrouter()
 	eventsRouter := apiRoutes.PathPrefix(config.PathEvents).Subrouter()
 	eventsRouter.HandleFunc(config.PathCreate, api.createEvent).Methods(http.MethodPost)
+	eventsRouter.HandleFunc(config.PathExport, api.exportEvents).Methods(http.MethodGet)
+	eventsRouter.HandleFunc(config.PathEventID, api.updateEvent).Methods(http.MethodPatch)
+	eventsRouter.HandleFunc(config.PathEventID, api.deleteEvent).Methods(http.MethodDelete)
+	apiRoutes.HandleFunc(config.PathAvailability+config.PathUserID, api.getUserAvailability).Methods(http.MethodGet)
 	apiRoutes.HandleFunc(config.PathConnectedUser, api.connectedUserHandler)
 
 	// Returns provider information for the plugin to use
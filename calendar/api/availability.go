@@ -0,0 +1,31 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/httputils"
+)
+
+// getUserAvailability returns the free/busy schedule of another user during the working hours of the
+// requesting user, for today or for the date in the "date" query parameter.
+func (api *api) getUserAvailability(w http.ResponseWriter, r *http.Request) {
+	user := api.loadEventsUser(w, r, "getUserAvailability")
+	if user == nil {
+		return
+	}
+
+	mscal := engine.New(api.Env, user.MattermostUserID)
+	schedule, err := mscal.GetSchedule(&engine.User{User: user, MattermostUserID: user.MattermostUserID}, []string{mux.Vars(r)["userID"]}, r.URL.Query().Get("date"))
+	if err != nil {
+		api.writeEventError(w, err, "getUserAvailability", user.MattermostUserID)
+		return
+	}
+
+	httputils.WriteJSONResponse(w, schedule, http.StatusOK)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const exportDefaultDays = 14
+
+// exportEvents returns the events of the user as an .ics file. The optional "from" and "to" query parameters
+// are days, formatted as YYYY-MM-DD in the time zone of the user, both included. By default, the events of
+// the upcoming 14 days, including today, are exported.
+func (api *api) exportEvents(w http.ResponseWriter, r *http.Request) {
+	user := api.loadEventsUser(w, r, "exportEvents")
+	if user == nil {
+		return
+	}
+
+	mscal := engine.New(api.Env, user.MattermostUserID)
+	engineUser := &engine.User{User: user, MattermostUserID: user.MattermostUserID}
+	timezone, err := mscal.GetTimezone(engineUser)
+	if err != nil {
+		api.writeEventError(w, err, "exportEvents", user.MattermostUserID)
+		return
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		api.writeEventError(w, errors.Wrapf(err, "error loading timezone %s", timezone), "exportEvents", user.MattermostUserID)
+		return
+	}
+
+	from, to, err := parseExportRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"), time.Now().In(loc))
+	if err != nil {
+		api.writeEventError(w, err, "exportEvents", user.MattermostUserID)
+		return
+	}
+
+	ics, err := mscal.ExportCalendar(engineUser, from, to)
+	if err != nil {
+		api.writeEventError(w, err, "exportEvents", user.MattermostUserID)
+		return
+	}
+
+	w.Header().Set("Content-Type", views.ICalendarContentType+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"calendar-%s%s\"", from.Format(engine.CreateEventDateFormat), views.ICalendarExtension))
+	if _, err = w.Write([]byte(ics)); err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Warnf("exportEvents, error occurred while writing the response")
+	}
+}
+
+// parseExportRange returns the range of the export, the end day being excluded.
+func parseExportRange(fromValue, toValue string, now time.Time) (time.Time, time.Time, error) {
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if fromValue != "" {
+		day, err := time.ParseInLocation(engine.CreateEventDateFormat, fromValue, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid from date %q, please use the format YYYY-MM-DD", engine.ErrInvalidEventPayload, fromValue)
+		}
+		from = day
+	}
+
+	to := from.AddDate(0, 0, exportDefaultDays)
+	if toValue != "" {
+		day, err := time.ParseInLocation(engine.CreateEventDateFormat, toValue, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid to date %q, please use the format YYYY-MM-DD", engine.ErrInvalidEventPayload, toValue)
+		}
+		to = day.AddDate(0, 0, 1)
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func (api *api) postActionBookMeetingTime(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	var request model.PostActionIntegrationRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+
+	rawPayload, _ := request.Context[config.EventPayloadKey].(string)
+	var payload engine.CreateEventPayload
+	if err := json.Unmarshal([]byte(rawPayload), &payload); err != nil {
+		utils.SlackAttachmentError(w, "Error: invalid meeting time")
+		return
+	}
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	_, err := mscal.CreateEventFromPayload(engine.NewUser(mattermostUserID), payload)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			utils.SlackAttachmentError(w, "Error: "+err.Error())
+			return
+		}
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Warnf("Failed to book meeting time.")
+		utils.SlackAttachmentError(w, "Error: Failed to book the meeting time: "+err.Error())
+		return
+	}
+
+	// Only one of the suggestions can be booked, the buttons of the other ones are removed as well.
+	isBookedSuggestion := func(sa *model.SlackAttachment) bool {
+		booked := false
+		for _, action := range sa.Actions {
+			if action.Integration != nil && action.Integration.Context[config.EventPayloadKey] == rawPayload {
+				booked = true
+			}
+		}
+		if !booked {
+			sa.Actions = []*model.PostAction{}
+		}
+		return booked
+	}
+	if err = api.markPostResponded(request.PostId, "You have booked this time", isBookedSuggestion); err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "postID": request.PostId}).Warnf("Failed to update the meeting time suggestions post.")
+	}
+
+	writePostActionResponse(w, model.PostActionIntegrationResponse{})
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func (api *api) postActionPreviewEventImport(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	var request model.PostActionIntegrationRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+
+	fileID, _ := request.Context[config.FileIDKey].(string)
+	channelID, _ := request.Context[config.ChannelIDKey].(string)
+	if fileID == "" {
+		utils.SlackAttachmentError(w, "Error: missing file ID")
+		return
+	}
+
+	// Anyone in the channel can click the button, so the response is only visible to the user.
+	_, err := api.Store.LoadUser(mattermostUserID)
+	if errors.Is(err, store.ErrNotFound) {
+		writePostActionResponse(w, model.PostActionIntegrationResponse{
+			EphemeralText: fmt.Sprintf("Please connect your %s account using `/%s connect` to add events to your calendar.", config.Provider.DisplayName, config.Provider.CommandTrigger),
+		})
+		return
+	}
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: failed to load user: "+err.Error())
+		return
+	}
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	err = mscal.PreviewEventImport(engine.NewUser(mattermostUserID), fileID, channelID)
+	if err != nil {
+		if !errors.Is(err, engine.ErrInvalidEventPayload) {
+			api.Logger.With(bot.LogContext{"err": err.Error(), "fileID": fileID}).Warnf("Failed to preview the events of a calendar file.")
+		}
+		writePostActionResponse(w, model.PostActionIntegrationResponse{
+			EphemeralText: "Failed to read the calendar file: " + err.Error(),
+		})
+		return
+	}
+
+	writePostActionResponse(w, model.PostActionIntegrationResponse{
+		EphemeralText: "The events of the calendar file were sent to you in a direct message.",
+	})
+}
+
+func (api *api) postActionImportEvent(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	var request model.PostActionIntegrationRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+
+	fileID, _ := request.Context[config.FileIDKey].(string)
+	channelID, _ := request.Context[config.ChannelIDKey].(string)
+	// Numbers of the context are decoded as float64
+	eventIndex, ok := request.Context[config.EventIndexKey].(float64)
+	if fileID == "" || !ok {
+		utils.SlackAttachmentError(w, "Error: invalid event")
+		return
+	}
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	_, err := mscal.ImportEvent(engine.NewUser(mattermostUserID), fileID, int(eventIndex), channelID)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			utils.SlackAttachmentError(w, "Error: "+err.Error())
+			return
+		}
+		api.Logger.With(bot.LogContext{"err": err.Error(), "fileID": fileID}).Warnf("Failed to import event.")
+		utils.SlackAttachmentError(w, "Error: Failed to add the event to your calendar: "+err.Error())
+		return
+	}
+
+	response := "You have added this event to your calendar"
+	if channelID != "" {
+		response = "You have added this event to your calendar and linked it to the channel"
+	}
+	isImportedEvent := func(sa *model.SlackAttachment) bool {
+		for _, action := range sa.Actions {
+			if action.Integration != nil && fmt.Sprint(action.Integration.Context[config.EventIndexKey]) == fmt.Sprint(int(eventIndex)) {
+				return true
+			}
+		}
+		return false
+	}
+	if err = api.markPostResponded(request.PostId, response, isImportedEvent); err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "postID": request.PostId}).Warnf("Failed to update the imported event post.")
+	}
+
+	writePostActionResponse(w, model.PostActionIntegrationResponse{})
+}
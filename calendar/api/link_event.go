@@ -0,0 +1,43 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func (api *api) postActionLinkEvent(w http.ResponseWriter, req *http.Request) {
+	mscal, user, eventID, channelID, _ := api.preprocessAction(w, req)
+	if eventID == "" {
+		return
+	}
+	if channelID == "" {
+		utils.SlackAttachmentError(w, "Error: missing channel")
+		return
+	}
+
+	event, err := mscal.LinkEventToChannel(user, eventID, channelID)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			utils.SlackAttachmentError(w, "Error: "+err.Error())
+			return
+		}
+		api.Logger.With(bot.LogContext{"err": err.Error(), "eventID": eventID, "channelID": channelID}).Warnf("Failed to link event to channel.")
+		utils.SlackAttachmentError(w, "Error: Failed to link the event to the channel: "+err.Error())
+		return
+	}
+
+	writePostActionResponse(w, model.PostActionIntegrationResponse{
+		EphemeralText: fmt.Sprintf("The event **%s** was linked to the channel.", views.EnsureSubject(event.Subject)),
+	})
+}
@@ -108,7 +108,9 @@ func (api *api) postActionRespond(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	sa := sas[0]
+	// The daily summary has an attachment per event, the one of the event is updated in place
+	index := eventAttachmentIndex(sas, eventID)
+	sa := sas[index]
 
 	if err == nil || isAcceptedError(err) {
 		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
@@ -118,9 +120,15 @@ func (api *api) postActionRespond(w http.ResponseWriter, req *http.Request) {
 		})
 	}
 
-	sa.Actions = []*model.PostAction{}
+	sa.Actions = actionsKeptAfterResponse(sa.Actions)
+	updated := []*model.SlackAttachment{}
+	for i, a := range sas {
+		if i == index || !hasEventAction(a, eventID) {
+			updated = append(updated, a)
+		}
+	}
 	postResponse := model.PostActionIntegrationResponse{}
-	model.ParseSlackAttachment(p, []*model.SlackAttachment{sa})
+	model.ParseSlackAttachment(p, updated)
 
 	postResponse.Update = p
 
@@ -133,6 +141,43 @@ func (api *api) postActionRespond(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// eventAttachmentIndex returns the index of the first attachment with an action for the event, or 0.
+func eventAttachmentIndex(sas []*model.SlackAttachment, eventID string) int {
+	for i, sa := range sas {
+		if hasEventAction(sa, eventID) {
+			return i
+		}
+	}
+	return 0
+}
+
+func hasEventAction(sa *model.SlackAttachment, eventID string) bool {
+	for _, action := range sa.Actions {
+		if action.Integration == nil {
+			continue
+		}
+		if id, _ := action.Integration.Context[config.EventIDKey].(string); id == eventID {
+			return true
+		}
+	}
+	return false
+}
+
+// actionsKeptAfterResponse returns the actions to join the meeting and to link the event to a channel, which
+// are kept once the user responded.
+func actionsKeptAfterResponse(actions []*model.PostAction) []*model.PostAction {
+	result := []*model.PostAction{}
+	for _, action := range actions {
+		if action.Integration == nil {
+			continue
+		}
+		if strings.HasSuffix(action.Integration.URL, config.PathJoinMeeting) || strings.HasSuffix(action.Integration.URL, config.PathLinkEvent) {
+			result = append(result, action)
+		}
+	}
+	return result
+}
+
 func prettyOption(option string) string {
 	switch option {
 	case engine.OptionYes:
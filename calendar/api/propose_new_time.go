@@ -0,0 +1,267 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const (
+	proposeNewTimeResponseField  = "response"
+	proposeNewTimeDateField      = "date"
+	proposeNewTimeStartTimeField = "start_time"
+	proposeNewTimeEndTimeField   = "end_time"
+	proposeNewTimeCommentField   = "comment"
+)
+
+type proposeNewTimeDialogState struct {
+	EventID string `json:"event_id"`
+	PostID  string `json:"post_id"`
+}
+
+func (api *api) postActionProposeNewTime(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	var request model.PostActionIntegrationRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+
+	eventID, ok := request.Context[config.EventIDKey].(string)
+	if !ok {
+		utils.SlackAttachmentError(w, "Error: missing event ID")
+		return
+	}
+
+	state, err := json.Marshal(proposeNewTimeDialogState{
+		EventID: eventID,
+		PostID:  request.PostId,
+	})
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: failed to open the dialog: "+err.Error())
+		return
+	}
+
+	err = api.PluginAPI.OpenInteractiveDialog(model.OpenDialogRequest{
+		TriggerId: request.TriggerId,
+		URL:       api.Config.PluginURLPath + config.PathDialogs + config.PathProposeNewTime,
+		Dialog: model.Dialog{
+			Title:            "Propose new time",
+			IntroductionText: "The new time will be sent to the organizer along with your response. Times are in the time zone of your calendar.",
+			SubmitLabel:      "Propose",
+			State:            string(state),
+			Elements: []model.DialogElement{
+				{
+					DisplayName: "Response",
+					Name:        proposeNewTimeResponseField,
+					Type:        "radio",
+					Default:     engine.OptionNo,
+					Options: []*model.PostActionOptions{
+						{Text: "Decline", Value: engine.OptionNo},
+						{Text: "Tentative", Value: engine.OptionMaybe},
+					},
+				},
+				{
+					DisplayName: "Date",
+					Name:        proposeNewTimeDateField,
+					Type:        "text",
+					Placeholder: "YYYY-MM-DD",
+				},
+				{
+					DisplayName: "Start time",
+					Name:        proposeNewTimeStartTimeField,
+					Type:        "text",
+					Placeholder: "HH:MM",
+				},
+				{
+					DisplayName: "End time",
+					Name:        proposeNewTimeEndTimeField,
+					Type:        "text",
+					Placeholder: "HH:MM",
+				},
+				{
+					DisplayName: "Message",
+					Name:        proposeNewTimeCommentField,
+					Type:        "textarea",
+					Optional:    true,
+				},
+			},
+		},
+	})
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Warnf("Failed to open propose new time dialog.")
+		utils.SlackAttachmentError(w, "Error: failed to open the dialog: "+err.Error())
+		return
+	}
+
+	writePostActionResponse(w, model.PostActionIntegrationResponse{})
+}
+
+func (api *api) dialogProposeNewTime(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		http.Error(w, "not authorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request model.SubmitDialogRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if request.Cancelled {
+		return
+	}
+
+	var state proposeNewTimeDialogState
+	if err := json.Unmarshal([]byte(request.State), &state); err != nil || state.EventID == "" {
+		writeDialogResponse(w, &model.SubmitDialogResponse{Error: "Missing event ID."})
+		return
+	}
+
+	submission := func(field string) string {
+		value, _ := request.Submission[field].(string)
+		return value
+	}
+	payload := engine.ProposeNewTimePayload{
+		Response:  submission(proposeNewTimeResponseField),
+		Date:      submission(proposeNewTimeDateField),
+		StartTime: submission(proposeNewTimeStartTimeField),
+		EndTime:   submission(proposeNewTimeEndTimeField),
+		Comment:   submission(proposeNewTimeCommentField),
+	}
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	err := mscal.ProposeNewTime(engine.NewUser(mattermostUserID), state.EventID, payload)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			writeDialogResponse(w, &model.SubmitDialogResponse{Error: err.Error()})
+			return
+		}
+		api.Logger.With(bot.LogContext{"err": err.Error(), "eventID": state.EventID}).Warnf("Failed to propose new time.")
+		writeDialogResponse(w, &model.SubmitDialogResponse{Error: "Failed to propose new time: " + err.Error()})
+		return
+	}
+
+	if state.PostID != "" {
+		response := "You have declined this event and proposed a new time"
+		if payload.Response == engine.OptionMaybe {
+			response = "You have tentatively accepted this event and proposed a new time"
+		}
+		hasEventActions := func(sa *model.SlackAttachment) bool {
+			for _, action := range sa.Actions {
+				if action.Integration != nil && action.Integration.Context[config.EventIDKey] == state.EventID {
+					return true
+				}
+			}
+			return false
+		}
+		if err = api.markPostResponded(state.PostID, response, hasEventActions); err != nil {
+			api.Logger.With(bot.LogContext{"err": err.Error(), "postID": state.PostID}).Warnf("Failed to update the event post after proposing a new time.")
+		}
+	}
+
+	writeDialogResponse(w, &model.SubmitDialogResponse{})
+}
+
+func (api *api) postActionAcceptProposedTime(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	var request model.PostActionIntegrationRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+
+	eventID, _ := request.Context[config.EventIDKey].(string)
+	proposedStart, _ := request.Context[config.ProposedStartKey].(string)
+	proposedEnd, _ := request.Context[config.ProposedEndKey].(string)
+	start, errStart := time.Parse(time.RFC3339, proposedStart)
+	end, errEnd := time.Parse(time.RFC3339, proposedEnd)
+	if eventID == "" || errStart != nil || errEnd != nil {
+		utils.SlackAttachmentError(w, "Error: invalid proposed time")
+		return
+	}
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	err := mscal.AcceptProposedTime(engine.NewUser(mattermostUserID), eventID, &remote.TimeSlot{
+		Start: remote.NewDateTime(start.UTC(), "UTC"),
+		End:   remote.NewDateTime(end.UTC(), "UTC"),
+	})
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "eventID": eventID}).Warnf("Failed to accept proposed time.")
+		utils.SlackAttachmentError(w, "Error: Failed to accept the proposed time: "+err.Error())
+		return
+	}
+
+	isAcceptedProposal := func(sa *model.SlackAttachment) bool {
+		for _, action := range sa.Actions {
+			if action.Integration != nil && action.Integration.Context[config.ProposedStartKey] == proposedStart {
+				return true
+			}
+		}
+		return false
+	}
+	if err = api.markPostResponded(request.PostId, "You have moved the event to the proposed time", isAcceptedProposal); err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "postID": request.PostId}).Warnf("Failed to update the proposal post.")
+	}
+
+	writePostActionResponse(w, model.PostActionIntegrationResponse{})
+}
+
+// markPostResponded replaces the actions of the matching attachments of the post with a response field.
+func (api *api) markPostResponded(postID, response string, matches func(*model.SlackAttachment) bool) error {
+	p, err := api.PluginAPI.GetPost(postID)
+	if err != nil {
+		return err
+	}
+
+	sas := p.Attachments()
+	for _, sa := range sas {
+		if !matches(sa) {
+			continue
+		}
+		sa.Actions = []*model.PostAction{}
+		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+			Title: "Response",
+			Value: response,
+			Short: false,
+		})
+	}
+	model.ParseSlackAttachment(p, sas)
+
+	return api.Poster.UpdatePost(p)
+}
+
+func writePostActionResponse(w http.ResponseWriter, response model.PostActionIntegrationResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		utils.SlackAttachmentError(w, "Error: unable to write response, "+err.Error())
+	}
+}
+
+func writeDialogResponse(w http.ResponseWriter, response *model.SubmitDialogResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func (api *api) postActionSnoozeReminder(w http.ResponseWriter, req *http.Request) {
+	mscal, user, eventID, _, _ := api.preprocessAction(w, req)
+	if eventID == "" {
+		return
+	}
+
+	remindAt, err := mscal.SnoozeReminder(user, eventID, engine.ReminderSnoozeDuration)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			utils.SlackAttachmentError(w, "Error: "+err.Error())
+			return
+		}
+		api.Logger.With(bot.LogContext{"err": err.Error(), "eventID": eventID}).Warnf("Failed to snooze reminder.")
+		utils.SlackAttachmentError(w, "Error: Failed to snooze the reminder: "+err.Error())
+		return
+	}
+
+	at := fmt.Sprintf("in %d minutes", int(engine.ReminderSnoozeDuration/time.Minute))
+	if timezone, errTimezone := mscal.GetTimezone(user); errTimezone == nil {
+		at = "at " + remote.NewDateTime(remindAt, "UTC").In(timezone).Time().Format(time.Kitchen)
+	}
+
+	writePostActionResponse(w, model.PostActionIntegrationResponse{
+		EphemeralText: fmt.Sprintf("I'll remind you again about this event %s.", at),
+	})
+}
+
+func (api *api) postActionJoinMeeting(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	var request model.PostActionIntegrationRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+
+	joinURL, _ := request.Context[config.JoinURLKey].(string)
+	if joinURL == "" {
+		utils.SlackAttachmentError(w, "Error: missing meeting URL")
+		return
+	}
+
+	writePostActionResponse(w, model.PostActionIntegrationResponse{
+		EphemeralText: fmt.Sprintf("[Click here to join the meeting](%s)", joinURL),
+	})
+}
+
+func (api *api) postActionRunningLate(w http.ResponseWriter, req *http.Request) {
+	mscal, user, eventID, option, _ := api.preprocessAction(w, req)
+	if eventID == "" {
+		return
+	}
+
+	notified, emailed, err := mscal.NotifyRunningLate(user, eventID, option)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			utils.SlackAttachmentError(w, "Error: "+err.Error())
+			return
+		}
+		api.Logger.With(bot.LogContext{"err": err.Error(), "eventID": eventID}).Warnf("Failed to notify that the user is running late.")
+		if notified == 0 {
+			utils.SlackAttachmentError(w, "Error: Failed to tell that you are running late: "+err.Error())
+			return
+		}
+	}
+
+	writePostActionResponse(w, model.PostActionIntegrationResponse{
+		EphemeralText: runningLateResponse(notified, emailed, err),
+	})
+}
+
+func runningLateResponse(notified int, emailed bool, err error) string {
+	var response string
+	switch notified {
+	case 0:
+		response = "No channel was told that you are running late."
+	case 1:
+		response = "1 channel was told that you are running late."
+	default:
+		response = fmt.Sprintf("%d channels were told that you are running late.", notified)
+	}
+
+	switch {
+	case emailed:
+		response += " The organizer was emailed."
+	case err != nil:
+		response += " Failed to email the organizer: " + err.Error()
+	}
+
+	return response
+}
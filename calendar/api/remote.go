@@ -0,0 +1,27 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+)
+
+// remote passes the requests under config.PathRemote to the remote, for the providers that serve pages of their
+// own, like the login of the fake calendar. The fake calendar is only built in the development builds, so the
+// requests are not found with the calendar providers of the release builds.
+func (api *api) remote(w http.ResponseWriter, req *http.Request) {
+	handler, ok := api.Env.Remote.(http.Handler)
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	req = req.Clone(req.Context())
+	req.URL.Path = strings.TrimPrefix(req.URL.Path, config.PathRemote)
+	req.URL.RawPath = ""
+	handler.ServeHTTP(w, req)
+}
@@ -3,6 +3,96 @@
 
 package command
 
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+func getAvailabilityHelp() string {
+	return "### Availability command:\n" +
+		fmt.Sprintf("`/%s availability @user [@user ...] [date]` - Show when other users are free or busy during your working hours\n", config.Provider.CommandTrigger) +
+		"The date can be `today`, `tomorrow`, a weekday like `monday`, or `YYYY-MM-DD`, and defaults to today.\n" +
+		fmt.Sprintf("For example: `/%s availability @alice @bob tomorrow`", config.Provider.CommandTrigger)
+}
+
+func (c *Command) availability(parameters ...string) (string, bool, error) {
+	if len(parameters) == 0 {
+		return getAvailabilityHelp(), false, nil
+	}
+
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return "", false, err
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		return "", false, errors.Wrapf(err, "error loading timezone %s", timezone)
+	}
+
+	usernames, mattermostUserIDs, date, err := parseAvailabilityArgs(parameters, time.Now().In(loc), c.Args.UserMentions)
+	if err != nil {
+		return err.Error() + "\n" + getAvailabilityHelp(), false, nil
+	}
+
+	schedule, err := c.Engine.GetSchedule(c.user(), mattermostUserIDs, date)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			return err.Error() + "\n" + getAvailabilityHelp(), false, nil
+		}
+		return "", false, err
+	}
+
+	interval := time.Duration(schedule.Interval) * time.Minute
+	rows := make([]views.ScheduleRow, 0, len(schedule.Users))
+	for i, availability := range schedule.Users {
+		rows = append(rows, views.ScheduleRow{
+			Name:             "@" + usernames[i],
+			AvailabilityView: availability.AvailabilityView,
+			Error:            availability.Error,
+		})
+	}
+
+	return views.RenderScheduleGrid(rows, schedule.From, int(schedule.To.Sub(schedule.From)/interval), interval), false, nil
+}
+
+func parseAvailabilityArgs(parameters []string, now time.Time, userMentions model.UserMentionMap) (usernames, mattermostUserIDs []string, date string, err error) {
+	for _, param := range parameters {
+		if !strings.HasPrefix(param, "@") {
+			if date != "" {
+				return nil, nil, "", fmt.Errorf("unexpected argument %q", param)
+			}
+			date, err = parseEventDate(param, now)
+			if err != nil {
+				return nil, nil, "", err
+			}
+			continue
+		}
+
+		username := strings.TrimPrefix(param, "@")
+		mattermostUserID, ok := userMentions[username]
+		if !ok {
+			return nil, nil, "", fmt.Errorf("could not find user %s", param)
+		}
+		usernames = append(usernames, username)
+		mattermostUserIDs = append(mattermostUserIDs, mattermostUserID)
+	}
+
+	if len(mattermostUserIDs) == 0 {
+		return nil, nil, "", errors.New("please mention at least one user")
+	}
+
+	return usernames, mattermostUserIDs, date, nil
+}
+
 func (c *Command) debugAvailability(parameters ...string) (string, bool, error) {
 	switch {
 	case len(parameters) == 0:
@@ -0,0 +1,102 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+)
+
+func getChannelHelp() string {
+	return "### Channel commands:\n" +
+		fmt.Sprintf("`/%s channel subscribe <calendar email>` - Post the new, changed and cancelled events of a shared calendar or group mailbox in this channel\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s channel unsubscribe` - Stop posting the events of the calendar in this channel\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s channel agenda <time|off>` - Post the agenda of the day in this channel at the given time, for example `8:00AM`\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s channel info` - Show the calendar this channel is subscribed to", config.Provider.CommandTrigger)
+}
+
+func (c *Command) channel(parameters ...string) (string, bool, error) {
+	if len(parameters) == 0 {
+		return getChannelHelp(), false, nil
+	}
+
+	var out string
+	var err error
+	switch {
+	case parameters[0] == "subscribe" && len(parameters) == 2:
+		out, err = c.subscribeChannel(parameters[1])
+	case parameters[0] == "unsubscribe" && len(parameters) == 1:
+		out, err = c.unsubscribeChannel()
+	case parameters[0] == "agenda" && len(parameters) == 2:
+		out, err = c.setChannelAgenda(parameters[1])
+	case parameters[0] == "info" && len(parameters) == 1:
+		out, err = c.channelInfo()
+	default:
+		return getChannelHelp(), false, nil
+	}
+
+	if errors.Is(err, engine.ErrChannelAlreadySubscribed) ||
+		errors.Is(err, engine.ErrChannelNotSubscribed) ||
+		errors.Is(err, engine.ErrChannelCalendarPermission) ||
+		errors.Is(err, engine.ErrInvalidCalendarAddress) ||
+		errors.Is(err, engine.ErrInvalidAgendaTime) {
+		return err.Error() + "\n" + getChannelHelp(), false, nil
+	}
+	return out, false, err
+}
+
+func (c *Command) subscribeChannel(calendarAddress string) (string, error) {
+	channelCalendar, err := c.Engine.SubscribeChannelCalendar(c.user(), c.ChannelID, calendarAddress)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("This channel is now subscribed to the calendar **%s**.", channelCalendar.CalendarAddress), nil
+}
+
+func (c *Command) unsubscribeChannel() (string, error) {
+	channelCalendar, err := c.Engine.UnsubscribeChannelCalendar(c.user(), c.ChannelID)
+	if err != nil {
+		return "", err
+	}
+	if channelCalendar == nil {
+		return "", engine.ErrChannelNotSubscribed
+	}
+	return fmt.Sprintf("This channel is no longer subscribed to the calendar **%s**.", channelCalendar.CalendarAddress), nil
+}
+
+func (c *Command) setChannelAgenda(timeStr string) (string, error) {
+	if strings.EqualFold(timeStr, "off") {
+		timeStr = ""
+	}
+
+	channelCalendar, err := c.Engine.SetChannelAgendaTime(c.user(), c.ChannelID, strings.ToUpper(timeStr))
+	if err != nil {
+		return "", err
+	}
+	if channelCalendar.AgendaTime == "" {
+		return "The agenda of the day will no longer be posted in this channel.", nil
+	}
+	return fmt.Sprintf("The agenda of the day will be posted in this channel at %s %s.", channelCalendar.AgendaTime, channelCalendar.Timezone), nil
+}
+
+func (c *Command) channelInfo() (string, error) {
+	channelCalendar, err := c.Engine.GetChannelCalendar(c.ChannelID)
+	if err != nil {
+		return "", err
+	}
+	if channelCalendar == nil {
+		return "", engine.ErrChannelNotSubscribed
+	}
+
+	agenda := "The agenda of the day is not posted."
+	if channelCalendar.AgendaTime != "" {
+		agenda = fmt.Sprintf("The agenda of the day is posted at %s %s.", channelCalendar.AgendaTime, channelCalendar.Timezone)
+	}
+	return fmt.Sprintf("This channel is subscribed to the calendar **%s**. %s", channelCalendar.CalendarAddress, agenda), nil
+}
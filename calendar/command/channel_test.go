@@ -0,0 +1,100 @@
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func TestChannel(t *testing.T) {
+	tcs := []struct {
+		name           string
+		command        string
+		setup          func(*mock_engine.MockEngine)
+		expectedOutput string
+	}{
+		{
+			name:    "subscribe",
+			command: "channel subscribe team@example.com",
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().SubscribeChannelCalendar(gomock.Any(), "channel_id", "team@example.com").Return(&store.ChannelCalendar{CalendarAddress: "team@example.com"}, nil)
+			},
+			expectedOutput: "This channel is now subscribed to the calendar **team@example.com**.",
+		},
+		{
+			name:    "subscribe twice",
+			command: "channel subscribe team@example.com",
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().SubscribeChannelCalendar(gomock.Any(), "channel_id", "team@example.com").Return(nil, engine.ErrChannelAlreadySubscribed)
+			},
+			expectedOutput: engine.ErrChannelAlreadySubscribed.Error() + "\n" + getChannelHelp(),
+		},
+		{
+			name:    "agenda off",
+			command: "channel agenda off",
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().SetChannelAgendaTime(gomock.Any(), "channel_id", "").Return(&store.ChannelCalendar{}, nil)
+			},
+			expectedOutput: "The agenda of the day will no longer be posted in this channel.",
+		},
+		{
+			name:    "agenda time",
+			command: "channel agenda 9:00am",
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().SetChannelAgendaTime(gomock.Any(), "channel_id", "9:00AM").Return(&store.ChannelCalendar{AgendaTime: "9:00AM", Timezone: "Europe/Paris"}, nil)
+			},
+			expectedOutput: "The agenda of the day will be posted in this channel at 9:00AM Europe/Paris.",
+		},
+		{
+			name:    "info without calendar",
+			command: "channel info",
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().GetChannelCalendar("channel_id").Return(nil, nil)
+			},
+			expectedOutput: engine.ErrChannelNotSubscribed.Error() + "\n" + getChannelHelp(),
+		},
+		{
+			name:           "unknown subcommand",
+			command:        "channel rename",
+			expectedOutput: getChannelHelp(),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mscal := mock_engine.NewMockEngine(ctrl)
+			mscal.EXPECT().GetRemoteUser("user_id").Return(&remote.User{}, nil)
+			if tc.setup != nil {
+				tc.setup(mscal)
+			}
+
+			command := Command{
+				Context: &plugin.Context{},
+				Args: &model.CommandArgs{
+					Command: fmt.Sprintf("/%s %s", config.Provider.CommandTrigger, tc.command),
+					UserId:  "user_id",
+				},
+				ChannelID: "channel_id",
+				Config:    &config.Config{},
+				Engine:    mscal,
+			}
+
+			out, _, err := command.Handle()
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, out)
+		})
+	}
+}
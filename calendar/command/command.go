@@ -54,14 +54,45 @@ var cmds = []*model.AutocompleteData{
 			model.NewAutocompleteData("disable", "", "Disable your daily summary."),
 		},
 	},
-	model.NewAutocompleteData("viewcal", "", "View your events for the upcoming 14 days, including today."),
+	model.NewAutocompleteData("viewcal", "[today|tomorrow|week|next week|YYYY-MM-DD..YYYY-MM-DD] [--compact|--table|--agenda]", "View your events for the upcoming 14 days, including today, or for the given range."),
 	{ // Create
-		Trigger:  "event",
+		Trigger:  "events",
 		HelpText: "Manage events.",
 		SubCommands: []*model.AutocompleteData{
-			model.NewAutocompleteData("create", "", "Creates a new event (desktop only)."),
+			model.NewAutocompleteData("create", "\"<subject>\" <date> <start>-<end> [@user|email ...] [~channel] [--location \"<location>\"]", "Creates a new event."),
+			model.NewAutocompleteData("list", "", "List your upcoming events with their number."),
+			model.NewAutocompleteData("update", "<number> [--date <date>] [--time <start>-<end>] [--subject \"<subject>\"]", "Update an event you organize."),
+			model.NewAutocompleteData("move", "<number> <+30m|-1h>", "Move an event you organize."),
+			model.NewAutocompleteData("cancel", "<number> [message]", "Cancel an event you organize."),
+			model.NewAutocompleteData("delete", "<number>", "Delete an event from your calendar."),
+			model.NewAutocompleteData("link", "<number> [~channel]", "Link an event to a channel."),
+			model.NewAutocompleteData("unlink", "<number> [~channel]", "Unlink an event from a channel."),
+			model.NewAutocompleteData("linked", "", "List your upcoming events linked to channels."),
 		},
 	},
+	{ // Custom status
+		Trigger:  "customstatus",
+		HelpText: "Manage the custom status set during your events.",
+		SubCommands: []*model.AutocompleteData{
+			model.NewAutocompleteData("list", "", "List your custom status rules with their number."),
+			model.NewAutocompleteData("add", "[--category <category>] [--subject <regex>] [--showas <showas>] [--teams true] --emoji <emoji> --text \"<text>\" [--expiry <end|none|30m>]", "Add a custom status rule."),
+			model.NewAutocompleteData("remove", "<number>", "Remove a custom status rule."),
+		},
+	},
+	{ // Channel
+		Trigger:  "channel",
+		HelpText: "Post the events of a shared calendar in this channel.",
+		SubCommands: []*model.AutocompleteData{
+			model.NewAutocompleteData("subscribe", "<calendar email>", "Post the new, changed and cancelled events of a shared calendar or group mailbox in this channel."),
+			model.NewAutocompleteData("unsubscribe", "", "Stop posting the events of the calendar in this channel."),
+			model.NewAutocompleteData("agenda", "<time|off>", "Set the time the agenda of the day is posted in this channel."),
+			model.NewAutocompleteData("info", "", "Show the calendar this channel is subscribed to."),
+		},
+	},
+	model.NewAutocompleteData("focus", "<duration|stop> [title]", "Block your calendar and hold back notifications, for example for 90m."),
+	model.NewAutocompleteData("export", "[today|tomorrow|week|next week|YYYY-MM-DD..YYYY-MM-DD]", "Export your events for the upcoming 14 days, or for the given range, as an .ics file."),
+	model.NewAutocompleteData("availability", "@user [@user ...] [date]", "Show when other users are free or busy."),
+	model.NewAutocompleteData("findtime", "@user [@user ...] <duration> [today|tomorrow|this week|next week]", "Find a time to meet with other users."),
 	model.NewAutocompleteData("today", "", "Display today's events."),
 	model.NewAutocompleteData("tomorrow", "", "Display tomorrow's events."),
 	model.NewAutocompleteData("settings", "", "Edit your user personal settings."),
@@ -121,6 +152,18 @@ func (c *Command) Handle() (string, bool, error) {
 		handler = c.requireConnectedUser(c.settings)
 	case "events":
 		handler = c.requireConnectedUser(c.event)
+	case "findtime":
+		handler = c.requireConnectedUser(c.findTime)
+	case "availability":
+		handler = c.requireConnectedUser(c.availability)
+	case "export":
+		handler = c.requireConnectedUser(c.export)
+	case "focus":
+		handler = c.requireConnectedUser(c.focus)
+	case "customstatus":
+		handler = c.requireConnectedUser(c.customStatus)
+	case "channel":
+		handler = c.requireConnectedUser(c.channel)
 	// Admin only
 	case "showcals":
 		handler = c.requireConnectedUser(c.requireAdminUser(c.showCalendars))
@@ -0,0 +1,163 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func getCustomStatusHelp() string {
+	return "### Custom status commands:\n" +
+		fmt.Sprintf("`/%s customstatus list` - List your custom status rules with their number\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s customstatus add [--category <category>] [--subject <regex>] [--showas <free|tentative|busy|oof|workingElsewhere>] [--teams true] --emoji <emoji> --text \"<text>\" [--expiry <end|none|30m>]` - Add a rule\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s customstatus remove <number>` - Remove a rule\n", config.Provider.CommandTrigger) +
+		"During an event, the custom status is set from the first rule matching all its conditions, or to \"In a meeting\" for the other meetings. " +
+		"The text can contain `{subject}` and `{end}`, the subject of private events being never shown. The custom status expires at the end of the event unless set otherwise.\n" +
+		fmt.Sprintf("For example: `/%s customstatus add --category \"Focus time\" --emoji headphones --text \"Focus time until {end}\"`", config.Provider.CommandTrigger)
+}
+
+func (c *Command) customStatus(parameters ...string) (string, bool, error) {
+	if len(parameters) == 0 {
+		return getCustomStatusHelp(), false, nil
+	}
+
+	switch parameters[0] {
+	case "list":
+		return c.listCustomStatusRules()
+	case "add":
+		return c.addCustomStatusRule(parameters[1:]...)
+	case "remove":
+		return c.removeCustomStatusRule(parameters[1:]...)
+	}
+
+	return getCustomStatusHelp(), false, nil
+}
+
+func (c *Command) listCustomStatusRules() (string, bool, error) {
+	rules, err := c.Engine.GetCustomStatusRules(c.user())
+	if err != nil {
+		return "", false, err
+	}
+	if len(rules) == 0 {
+		return fmt.Sprintf("You have no custom status rules. Use `/%s customstatus add` to add one.", config.Provider.CommandTrigger), false, nil
+	}
+
+	out := "Your custom status rules:\n"
+	for i, rule := range rules {
+		out += fmt.Sprintf("%d. %s\n", i+1, formatCustomStatusRule(rule))
+	}
+	return out, false, nil
+}
+
+func (c *Command) addCustomStatusRule(parameters ...string) (string, bool, error) {
+	rule, err := parseCustomStatusRuleArgs(parameters)
+	if err != nil {
+		return err.Error() + "\n" + getCustomStatusHelp(), false, nil
+	}
+
+	err = c.Engine.AddCustomStatusRule(c.user(), rule)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidCustomStatusRule) {
+			return err.Error() + "\n" + getCustomStatusHelp(), false, nil
+		}
+		return "", false, err
+	}
+
+	return "Custom status rule added: " + formatCustomStatusRule(rule), false, nil
+}
+
+func (c *Command) removeCustomStatusRule(parameters ...string) (string, bool, error) {
+	if len(parameters) != 1 {
+		return getCustomStatusHelp(), false, nil
+	}
+
+	number, err := strconv.Atoi(parameters[0])
+	if err != nil {
+		return fmt.Sprintf("Invalid rule number %q.\n", parameters[0]) + getCustomStatusHelp(), false, nil
+	}
+
+	removed, err := c.Engine.RemoveCustomStatusRule(c.user(), number-1)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidCustomStatusRule) {
+			return fmt.Sprintf("Rule %d not found. Use `/%s customstatus list` to see your rules.", number, config.Provider.CommandTrigger), false, nil
+		}
+		return "", false, err
+	}
+
+	return "Custom status rule removed: " + formatCustomStatusRule(removed), false, nil
+}
+
+// parseCustomStatusRuleArgs builds a custom status rule from the slash command flags.
+func parseCustomStatusRuleArgs(parameters []string) (*store.CustomStatusRule, error) {
+	args, err := splitQuotedArgs(strings.Join(parameters, " "))
+	if err != nil {
+		return nil, err
+	}
+	if len(args)%2 != 0 {
+		return nil, errors.New("every option must have a value")
+	}
+
+	rule := &store.CustomStatusRule{}
+	for i := 0; i < len(args); i += 2 {
+		value := args[i+1]
+		switch args[i] {
+		case "--category":
+			rule.Category = value
+		case "--subject":
+			rule.SubjectPattern = value
+		case "--showas":
+			rule.ShowAs = value
+		case "--teams":
+			rule.HasTeamsLink, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q for --teams, please use true or false", value)
+			}
+		case "--emoji":
+			rule.Emoji = value
+		case "--text":
+			rule.Text = value
+		case "--expiry":
+			rule.Expiry = strings.ToLower(value)
+		default:
+			return nil, fmt.Errorf("unexpected argument %q", args[i])
+		}
+	}
+
+	return rule, nil
+}
+
+func formatCustomStatusRule(rule *store.CustomStatusRule) string {
+	conditions := []string{}
+	if rule.Category != "" {
+		conditions = append(conditions, fmt.Sprintf("category is %q", rule.Category))
+	}
+	if rule.SubjectPattern != "" {
+		conditions = append(conditions, fmt.Sprintf("subject matches `%s`", rule.SubjectPattern))
+	}
+	if rule.ShowAs != "" {
+		conditions = append(conditions, fmt.Sprintf("shown as %s", rule.ShowAs))
+	}
+	if rule.HasTeamsLink {
+		conditions = append(conditions, "has a Teams link")
+	}
+
+	expiry := "expires at the end of the event"
+	switch rule.Expiry {
+	case store.CustomStatusExpiryNone:
+		expiry = "does not expire"
+	case store.CustomStatusExpiryEventEnd, "":
+	default:
+		expiry = "expires " + rule.Expiry + " after the start of the event"
+	}
+
+	return fmt.Sprintf("when %s, :%s: %q, %s", strings.Join(conditions, " and "), rule.Emoji, rule.Text, expiry)
+}
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func TestParseCustomStatusRuleArgs(t *testing.T) {
+	tcs := []struct {
+		name          string
+		command       string
+		expectedRule  *store.CustomStatusRule
+		expectedError string
+	}{
+		{
+			name:    "all options",
+			command: `--category "Focus time" --subject "^Focus" --showas busy --teams false --emoji headphones --text "Focus time until {end}" --expiry END`,
+			expectedRule: &store.CustomStatusRule{
+				Category:       "Focus time",
+				SubjectPattern: "^Focus",
+				ShowAs:         "busy",
+				Emoji:          "headphones",
+				Text:           "Focus time until {end}",
+				Expiry:         "end",
+			},
+		},
+		{
+			name:    "Teams link",
+			command: `--teams true --emoji :video_camera: --text "In a call"`,
+			expectedRule: &store.CustomStatusRule{
+				HasTeamsLink: true,
+				Emoji:        ":video_camera:",
+				Text:         "In a call",
+			},
+		},
+		{
+			name:          "missing value",
+			command:       `--category Travel --emoji`,
+			expectedError: "every option must have a value",
+		},
+		{
+			name:          "invalid Teams value",
+			command:       `--teams maybe`,
+			expectedError: `invalid value "maybe" for --teams, please use true or false`,
+		},
+		{
+			name:          "unknown option",
+			command:       `--color red`,
+			expectedError: `unexpected argument "--color"`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := parseCustomStatusRuleArgs(strings.Fields(tc.command))
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRule, rule)
+		})
+	}
+}
@@ -3,14 +3,474 @@
 
 package command
 
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	allDayEventKeyword   = "all-day"
+	upcomingEventsWindow = 14 * 24 * time.Hour
+)
+
+var createEventTimeFormats = []string{"15:04", "3:04pm", "3pm"}
+
+func getEventsHelp() string {
+	return "### Events commands:\n" +
+		fmt.Sprintf("`/%s events create \"<subject>\" <date> <start>-<end> [@user|email ...] [~channel] [--location \"<location>\"] [--description \"<description>\"]` - Create an event\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s events list` - List your upcoming events with their number\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s events update <number> [--date <date>] [--time <start>-<end>] [--subject \"<subject>\"] [--location \"<location>\"] [--description \"<description>\"]` - Update an event you organize\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s events move <number> <+30m|-1h>` - Move an event you organize\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s events cancel <number> [message]` - Cancel an event you organize, sending the message to the attendees\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s events delete <number>` - Delete an event from your calendar\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s events link <number> [~channel]` - Link an event to a channel, the current one by default\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s events unlink <number> [~channel]` - Unlink an event from a channel, the current one by default\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s events linked` - List your upcoming events linked to channels\n", config.Provider.CommandTrigger) +
+		"Dates can be `today`, `tomorrow`, a weekday like `monday`, or `YYYY-MM-DD`. Use `all-day` instead of the time range for all day events.\n" +
+		"To create a recurring event, add `--repeat <daily|weekdays|weekly|monthly|yearly>`, optionally with `--every <n>`, `--on <mon,wed>` for weekly events, and `--until <date>` or `--count <n>` to end the series.\n" +
+		fmt.Sprintf("For example: `/%s events create \"Design review\" tomorrow 10:00am-11:00am @alice bob@example.com ~town-square --location \"Room 4\"`", config.Provider.CommandTrigger)
+}
+
 func (c *Command) event(parameters ...string) (string, bool, error) {
 	if len(parameters) == 0 {
-		return getDailySummaryHelp(), false, nil
+		return getEventsHelp(), false, nil
+	}
+
+	switch parameters[0] {
+	case "create":
+		return c.createEvent(parameters[1:]...)
+	case "list":
+		return c.listEvents()
+	case "update":
+		return c.updateEvent(parameters[1:]...)
+	case "move":
+		return c.moveEvent(parameters[1:]...)
+	case "cancel":
+		return c.cancelEvent(parameters[1:]...)
+	case "delete":
+		return c.deleteEvent(parameters[1:]...)
+	case "link":
+		return c.linkEvent(parameters[1:]...)
+	case "unlink":
+		return c.unlinkEvent(parameters[1:]...)
+	case "linked":
+		return c.listLinkedEvents()
+	}
+
+	return getEventsHelp(), false, nil
+}
+
+func (c *Command) createEvent(parameters ...string) (string, bool, error) {
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return "", false, err
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		return "", false, errors.Wrapf(err, "error loading timezone %s", timezone)
+	}
+
+	payload, err := parseCreateEventArgs(parameters, time.Now().In(loc), c.Args.UserMentions, c.Args.ChannelMentions)
+	if err != nil {
+		return err.Error() + "\n" + getEventsHelp(), false, nil
+	}
+
+	event, err := c.Engine.CreateEventFromPayload(c.user(), *payload)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			return err.Error() + "\n" + getEventsHelp(), false, nil
+		}
+		return "", false, err
+	}
+
+	return fmt.Sprintf("The event **%s** was created.", event.Subject), false, nil
+}
+
+// upcomingEvents returns the events of the next two weeks, in the order used by `events list`.
+func (c *Command) upcomingEvents() ([]*remote.Event, error) {
+	now := time.Now()
+	events, err := c.Engine.ViewCalendar(c.user(), now, now.Add(upcomingEventsWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Time().Before(events[j].Start.Time())
+	})
+
+	return events, nil
+}
+
+// findEvent resolves an event reference, either its number in `events list` or its ID.
+func (c *Command) findEvent(ref string) (*remote.Event, error) {
+	n, err := strconv.Atoi(ref)
+	if err != nil {
+		return &remote.Event{ID: ref}, nil
+	}
+
+	events, err := c.upcomingEvents()
+	if err != nil {
+		return nil, err
+	}
+	if n < 1 || n > len(events) {
+		return nil, nil
+	}
+
+	return events[n-1], nil
+}
+
+func (c *Command) listEvents() (string, bool, error) {
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return "Error: No timezone found", false, err
+	}
+
+	events, err := c.upcomingEvents()
+	if err != nil {
+		return "", false, err
+	}
+
+	out, err := views.RenderEventList(events, timezone)
+	return out, false, err
+}
+
+func (c *Command) updateEvent(parameters ...string) (string, bool, error) {
+	if len(parameters) < 2 {
+		return getEventsHelp(), false, nil
+	}
+
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return "", false, err
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		return "", false, errors.Wrapf(err, "error loading timezone %s", timezone)
+	}
+
+	payload, err := parseUpdateEventArgs(parameters[1:], time.Now().In(loc))
+	if err != nil {
+		return err.Error() + "\n" + getEventsHelp(), false, nil
+	}
+
+	return c.applyEventUpdate(parameters[0], *payload)
+}
+
+func (c *Command) moveEvent(parameters ...string) (string, bool, error) {
+	if len(parameters) != 2 {
+		return getEventsHelp(), false, nil
+	}
+
+	shift := strings.TrimPrefix(parameters[1], "+")
+	if _, err := time.ParseDuration(shift); err != nil {
+		return fmt.Sprintf("Invalid duration %q, please use a value like `+30m` or `-1h`.", parameters[1]), false, nil
+	}
+
+	return c.applyEventUpdate(parameters[0], engine.UpdateEventPayload{Shift: shift})
+}
+
+func (c *Command) applyEventUpdate(ref string, payload engine.UpdateEventPayload) (string, bool, error) {
+	event, err := c.findEvent(ref)
+	if err != nil {
+		return "", false, err
+	}
+	if event == nil {
+		return fmt.Sprintf("Event %s not found. Use `/%s events list` to see your upcoming events.", ref, config.Provider.CommandTrigger), false, nil
+	}
+
+	updated, err := c.Engine.UpdateEvent(c.user(), event.ID, payload)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			return err.Error(), false, nil
+		}
+		return "", false, err
+	}
+
+	return fmt.Sprintf("The event **%s** was updated.", views.EnsureSubject(updated.Subject)), false, nil
+}
+
+func (c *Command) cancelEvent(parameters ...string) (string, bool, error) {
+	if len(parameters) == 0 {
+		return getEventsHelp(), false, nil
+	}
+
+	comment := strings.Trim(strings.Join(parameters[1:], " "), "\"")
+
+	event, err := c.findEvent(parameters[0])
+	if err != nil {
+		return "", false, err
 	}
+	if event == nil {
+		return fmt.Sprintf("Event %s not found. Use `/%s events list` to see your upcoming events.", parameters[0], config.Provider.CommandTrigger), false, nil
+	}
+
+	err = c.Engine.CancelEvent(c.user(), event.ID, comment)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			return err.Error(), false, nil
+		}
+		return "", false, err
+	}
+
+	return fmt.Sprintf("The event **%s** was cancelled.", views.EnsureSubject(event.Subject)), false, nil
+}
+
+func (c *Command) deleteEvent(parameters ...string) (string, bool, error) {
+	if len(parameters) != 1 {
+		return getEventsHelp(), false, nil
+	}
+
+	event, err := c.findEvent(parameters[0])
+	if err != nil {
+		return "", false, err
+	}
+	if event == nil {
+		return fmt.Sprintf("Event %s not found. Use `/%s events list` to see your upcoming events.", parameters[0], config.Provider.CommandTrigger), false, nil
+	}
+
+	err = c.Engine.DeleteEvent(c.user(), event.ID)
+	if err != nil {
+		return "", false, err
+	}
+
+	return fmt.Sprintf("The event **%s** was deleted.", views.EnsureSubject(event.Subject)), false, nil
+}
+
+// parseUpdateEventArgs builds the update event payload from the slash command flags.
+func parseUpdateEventArgs(parameters []string, now time.Time) (*engine.UpdateEventPayload, error) {
+	args, err := splitQuotedArgs(strings.Join(parameters, " "))
+	if err != nil {
+		return nil, err
+	}
+	if len(args)%2 != 0 {
+		return nil, errors.New("every option must have a value")
+	}
+
+	payload := &engine.UpdateEventPayload{}
+	for i := 0; i < len(args); i += 2 {
+		value := args[i+1]
+		switch args[i] {
+		case "--subject":
+			payload.Subject = value
+		case "--location":
+			payload.Location = value
+		case "--description":
+			payload.Description = value
+		case "--date":
+			payload.Date, err = parseEventDate(value, now)
+		case "--time":
+			payload.StartTime, payload.EndTime, err = parseEventTimeRange(value)
+		default:
+			return nil, fmt.Errorf("unexpected argument %q", args[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return payload, nil
+}
+
+// parseCreateEventArgs builds the create event payload from the slash command arguments. Relative dates
+// are resolved against now, which is expected to be in the user mailbox timezone.
+func parseCreateEventArgs(parameters []string, now time.Time, userMentions model.UserMentionMap, channelMentions model.ChannelMentionMap) (*engine.CreateEventPayload, error) {
+	args, err := splitQuotedArgs(strings.Join(parameters, " "))
+	if err != nil {
+		return nil, err
+	}
+	if len(args) < 3 {
+		return nil, errors.New("please provide the subject, the date and the time of the event")
+	}
+
+	payload := &engine.CreateEventPayload{
+		Subject: args[0],
+	}
+
+	payload.Date, err = parseEventDate(args[1], now)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(args[2], allDayEventKeyword) {
+		payload.AllDay = true
+	} else {
+		payload.StartTime, payload.EndTime, err = parseEventTimeRange(args[2])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := 3; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case strings.HasPrefix(arg, "--"):
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("missing value for %s", arg)
+			}
+			i++
+			if err = setCreateEventOption(payload, arg, args[i], now); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(arg, "~"):
+			if payload.ChannelID != "" {
+				return nil, errors.New("events can only be linked to one channel")
+			}
+			channelID, ok := channelMentions[strings.TrimPrefix(arg, "~")]
+			if !ok {
+				return nil, fmt.Errorf("could not find channel %s", arg)
+			}
+			payload.ChannelID = channelID
+		case strings.HasPrefix(arg, "@"):
+			userID, ok := userMentions[strings.TrimPrefix(arg, "@")]
+			if !ok {
+				return nil, fmt.Errorf("could not find user %s", arg)
+			}
+			payload.Attendees = append(payload.Attendees, userID)
+		case strings.Contains(arg, "@"):
+			payload.Attendees = append(payload.Attendees, arg)
+		default:
+			return nil, fmt.Errorf("unexpected argument %q", arg)
+		}
+	}
+
+	if payload.Recurrence != nil && payload.Recurrence.Type == "" {
+		return nil, errors.New("please set how the event repeats with `--repeat`")
+	}
+
+	return payload, nil
+}
+
+func setCreateEventOption(payload *engine.CreateEventPayload, option, value string, now time.Time) error {
+	recurrence := func() *engine.CreateEventRecurrence {
+		if payload.Recurrence == nil {
+			payload.Recurrence = &engine.CreateEventRecurrence{}
+		}
+		return payload.Recurrence
+	}
+
+	var err error
+	switch option {
+	case "--location":
+		payload.Location = value
+	case "--description":
+		payload.Description = value
+	case "--repeat":
+		recurrence().Type = strings.ToLower(value)
+	case "--every":
+		recurrence().Interval, err = strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid interval %q", value)
+		}
+	case "--on":
+		recurrence().DaysOfWeek = strings.Split(value, ",")
+	case "--until":
+		recurrence().EndDate, err = parseEventDate(value, now)
+	case "--count":
+		recurrence().Occurrences, err = strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid number of occurrences %q", value)
+		}
+	default:
+		return fmt.Errorf("unexpected argument %q", option)
+	}
+
+	return err
+}
+
+func parseEventDate(value string, now time.Time) (string, error) {
+	value = strings.ToLower(value)
+	switch value {
+	case "today":
+		return now.Format(engine.CreateEventDateFormat), nil
+	case "tomorrow":
+		return now.AddDate(0, 0, 1).Format(engine.CreateEventDateFormat), nil
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if value == strings.ToLower(weekday.String()) {
+			days := (int(weekday) - int(now.Weekday()) + 7) % 7
+			return now.AddDate(0, 0, days).Format(engine.CreateEventDateFormat), nil
+		}
+	}
+
+	if _, err := time.Parse(engine.CreateEventDateFormat, value); err != nil {
+		return "", fmt.Errorf("invalid date %q", value)
+	}
+
+	return value, nil
+}
 
-	if parameters[0] == "create" {
-		return "Creating events is only supported on desktop.", false, nil
+func parseEventTimeRange(value string) (string, string, error) {
+	parts := strings.Split(value, "-")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid time range %q, please use the format `<start>-<end>`", value)
+	}
+
+	start, err := parseEventTime(parts[0])
+	if err != nil {
+		return "", "", err
+	}
+
+	end, err := parseEventTime(parts[1])
+	if err != nil {
+		return "", "", err
+	}
+
+	return start, end, nil
+}
+
+func parseEventTime(value string) (string, error) {
+	for _, layout := range createEventTimeFormats {
+		t, err := time.Parse(layout, strings.ToLower(value))
+		if err == nil {
+			return t.Format(engine.CreateEventTimeFormat), nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid time %q", value)
+}
+
+// splitQuotedArgs splits the input on whitespace, keeping double quoted strings as a single argument.
+func splitQuotedArgs(input string) ([]string, error) {
+	args := []string{}
+	var current strings.Builder
+	inQuotes, hasArg := false, false
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasArg = true
+		case r == ' ' && !inQuotes:
+			if hasArg {
+				args = append(args, current.String())
+				current.Reset()
+				hasArg = false
+			}
+		default:
+			current.WriteRune(r)
+			hasArg = true
+		}
+	}
+
+	if inQuotes {
+		return nil, errors.New("unterminated quoted string")
+	}
+	if hasArg {
+		args = append(args, current.String())
 	}
 
-	return "", false, nil
+	return args, nil
 }
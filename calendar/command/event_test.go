@@ -0,0 +1,170 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+)
+
+func TestParseCreateEventArgs(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, time.May, 15, 9, 30, 0, 0, time.UTC)
+	userMentions := model.UserMentionMap{"alice": "alice_id"}
+	channelMentions := model.ChannelMentionMap{"town-square": "channel_id"}
+
+	tcs := []struct {
+		name            string
+		command         string
+		expectedPayload *engine.CreateEventPayload
+		expectedError   string
+	}{
+		{
+			name:    "full event",
+			command: `"Design review" tomorrow 10:00am-11:30am @alice bob@example.com ~town-square --location "Room 4" --description "Bring notes"`,
+			expectedPayload: &engine.CreateEventPayload{
+				Subject:     "Design review",
+				Date:        "2024-05-16",
+				StartTime:   "10:00",
+				EndTime:     "11:30",
+				Attendees:   []string{"alice_id", "bob@example.com"},
+				ChannelID:   "channel_id",
+				Location:    "Room 4",
+				Description: "Bring notes",
+			},
+		},
+		{
+			name:    "all day event on a weekday",
+			command: `Offsite monday all-day`,
+			expectedPayload: &engine.CreateEventPayload{
+				Subject: "Offsite",
+				Date:    "2024-05-20",
+				AllDay:  true,
+			},
+		},
+		{
+			name:    "24h times and explicit date",
+			command: `"1:1" 2024-06-01 15:00-15:30`,
+			expectedPayload: &engine.CreateEventPayload{
+				Subject:   "1:1",
+				Date:      "2024-06-01",
+				StartTime: "15:00",
+				EndTime:   "15:30",
+			},
+		},
+		{
+			name:    "recurring event",
+			command: `Standup today 9:45-10:00 --repeat weekly --on mon,wed,fri --until 2024-07-01`,
+			expectedPayload: &engine.CreateEventPayload{
+				Subject:   "Standup",
+				Date:      "2024-05-15",
+				StartTime: "09:45",
+				EndTime:   "10:00",
+				Recurrence: &engine.CreateEventRecurrence{
+					Type:       engine.RecurrenceWeekly,
+					DaysOfWeek: []string{"mon", "wed", "fri"},
+					EndDate:    "2024-07-01",
+				},
+			},
+		},
+		{
+			name:          "recurrence without pattern",
+			command:       `Standup today 9:45-10:00 --count 10`,
+			expectedError: "please set how the event repeats with `--repeat`",
+		},
+		{
+			name:          "missing time",
+			command:       `"Design review" today`,
+			expectedError: "please provide the subject, the date and the time of the event",
+		},
+		{
+			name:          "invalid date",
+			command:       `"Design review" someday 10am-11am`,
+			expectedError: `invalid date "someday"`,
+		},
+		{
+			name:          "invalid time range",
+			command:       `"Design review" today 10am`,
+			expectedError: "invalid time range \"10am\", please use the format `<start>-<end>`",
+		},
+		{
+			name:          "unknown user",
+			command:       `"Design review" today 10am-11am @carol`,
+			expectedError: "could not find user @carol",
+		},
+		{
+			name:          "unterminated quote",
+			command:       `"Design review today 10am-11am`,
+			expectedError: "unterminated quoted string",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := parseCreateEventArgs(strings.Fields(tc.command), now, userMentions, channelMentions)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPayload, payload)
+		})
+	}
+}
+
+func TestParseUpdateEventArgs(t *testing.T) {
+	now := time.Date(2024, time.May, 15, 9, 30, 0, 0, time.UTC)
+
+	tcs := []struct {
+		name            string
+		command         string
+		expectedPayload *engine.UpdateEventPayload
+		expectedError   string
+	}{
+		{
+			name:    "time and subject",
+			command: `--time 2pm-3pm --subject "New subject"`,
+			expectedPayload: &engine.UpdateEventPayload{
+				StartTime: "14:00",
+				EndTime:   "15:00",
+				Subject:   "New subject",
+			},
+		},
+		{
+			name:    "date and location",
+			command: `--date friday --location "Room 2"`,
+			expectedPayload: &engine.UpdateEventPayload{
+				Date:     "2024-05-17",
+				Location: "Room 2",
+			},
+		},
+		{
+			name:          "missing value",
+			command:       `--subject`,
+			expectedError: "every option must have a value",
+		},
+		{
+			name:          "unknown option",
+			command:       `--room 2`,
+			expectedError: `unexpected argument "--room"`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := parseUpdateEventArgs(strings.Fields(tc.command), now)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPayload, payload)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+func getExportHelp() string {
+	return "### Export command:\n" +
+		fmt.Sprintf("`/%s export [today|tomorrow|week|next week|YYYY-MM-DD|YYYY-MM-DD..YYYY-MM-DD]` - Export your events as an .ics file\n", config.Provider.CommandTrigger) +
+		"Without a range, the events of the upcoming 14 days, including today, are exported."
+}
+
+func (c *Command) export(parameters ...string) (string, bool, error) {
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return "Error: No timezone found", false, err
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		return "", false, errors.Wrapf(err, "error loading timezone %s", timezone)
+	}
+
+	from, to, err := parseExportArgs(parameters, time.Now().In(loc))
+	if err != nil {
+		return err.Error() + "\n" + getExportHelp(), false, nil
+	}
+
+	err = c.Engine.SendCalendarExport(c.user(), from, to)
+	if err != nil {
+		return "", false, err
+	}
+
+	return "", true, nil
+}
+
+func parseExportArgs(parameters []string, now time.Time) (time.Time, time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if len(parameters) == 0 {
+		return today, today.AddDate(0, 0, viewCalendarDefaultDays), nil
+	}
+
+	for _, param := range parameters {
+		if strings.HasPrefix(param, "--") {
+			return time.Time{}, time.Time{}, fmt.Errorf("unexpected argument %q", param)
+		}
+	}
+
+	return parseViewCalendarRange(strings.ToLower(strings.Join(parameters, " ")), today)
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExportArgs(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// Wednesday
+	now := time.Date(2024, time.May, 15, 21, 30, 0, 0, loc)
+	day := func(d int) time.Time {
+		return time.Date(2024, time.May, d, 0, 0, 0, 0, loc)
+	}
+
+	tcs := []struct {
+		name          string
+		parameters    []string
+		expectedFrom  time.Time
+		expectedTo    time.Time
+		expectedError string
+	}{
+		{
+			name:         "default",
+			expectedFrom: day(15),
+			expectedTo:   day(29),
+		},
+		{
+			name:         "next week",
+			parameters:   []string{"Next", "week"},
+			expectedFrom: day(20),
+			expectedTo:   day(27),
+		},
+		{
+			name:         "date range",
+			parameters:   []string{"2024-05-16..2024-05-18"},
+			expectedFrom: day(16),
+			expectedTo:   day(19),
+		},
+		{
+			name:          "unknown option",
+			parameters:    []string{"--compact"},
+			expectedError: `unexpected argument "--compact"`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to, err := parseExportArgs(tc.parameters, now)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFrom, from)
+			require.Equal(t, tc.expectedTo, to)
+		})
+	}
+}
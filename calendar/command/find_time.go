@@ -0,0 +1,136 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const findTimeDefaultRange = 7 * 24 * time.Hour
+
+func getFindTimeHelp() string {
+	return "### Find time command:\n" +
+		fmt.Sprintf("`/%s findtime @user [@user ...] <duration> [today|tomorrow|this week|next week] [--subject \"<subject>\"]` - Find a time to meet with other users\n", config.Provider.CommandTrigger) +
+		"The duration can be like `30m`, `1h` or `1h30m`. Without a range, the next 7 days are searched.\n" +
+		fmt.Sprintf("For example: `/%s findtime @alice @bob 45m this week`", config.Provider.CommandTrigger)
+}
+
+func (c *Command) findTime(parameters ...string) (string, bool, error) {
+	if len(parameters) == 0 {
+		return getFindTimeHelp(), false, nil
+	}
+
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return "", false, err
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		return "", false, errors.Wrapf(err, "error loading timezone %s", timezone)
+	}
+
+	payload, err := parseFindTimeArgs(parameters, time.Now().In(loc), c.Args.UserMentions)
+	if err != nil {
+		return err.Error() + "\n" + getFindTimeHelp(), false, nil
+	}
+
+	results, err := c.Engine.FindTime(c.user(), *payload)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			return err.Error() + "\n" + getFindTimeHelp(), false, nil
+		}
+		return "", false, err
+	}
+
+	if len(results.MeetingTimeSuggestions) == 0 {
+		if results.EmptySuggestionReason != "" {
+			return fmt.Sprintf("No meeting time could be found (%s). Try a shorter meeting or a wider range.", results.EmptySuggestionReason), false, nil
+		}
+		return "No meeting time could be found. Try a shorter meeting or a wider range.", false, nil
+	}
+
+	return "", true, nil
+}
+
+func parseFindTimeArgs(parameters []string, now time.Time, userMentions model.UserMentionMap) (*engine.FindTimePayload, error) {
+	args, err := splitQuotedArgs(strings.Join(parameters, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &engine.FindTimePayload{
+		From: now,
+		To:   now.Add(findTimeDefaultRange),
+	}
+	var rangeWords []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "" {
+			continue
+		}
+		switch {
+		case arg == "--subject":
+			if i+1 >= len(args) {
+				return nil, errors.New("every option must have a value")
+			}
+			i++
+			payload.Subject = args[i]
+		case strings.HasPrefix(arg, "@"):
+			mattermostUserID, ok := userMentions[strings.TrimPrefix(arg, "@")]
+			if !ok {
+				return nil, fmt.Errorf("could not find user %s", arg)
+			}
+			payload.Attendees = append(payload.Attendees, mattermostUserID)
+		case payload.Duration == 0 && arg[0] >= '0' && arg[0] <= '9':
+			duration, errParse := time.ParseDuration(arg)
+			if errParse != nil {
+				return nil, fmt.Errorf("invalid duration %q", arg)
+			}
+			payload.Duration = duration
+		default:
+			rangeWords = append(rangeWords, strings.ToLower(arg))
+		}
+	}
+
+	if len(payload.Attendees) == 0 || payload.Duration == 0 {
+		return nil, errors.New("please provide the users to meet with and the duration of the meeting")
+	}
+
+	if len(rangeWords) > 0 {
+		payload.From, payload.To, err = parseFindTimeRange(strings.Join(rangeWords, " "), now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return payload, nil
+}
+
+// parseFindTimeRange returns the time range to search in. Weeks start on Monday.
+func parseFindTimeRange(value string, now time.Time) (time.Time, time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	nextMonday := today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7)
+
+	switch value {
+	case "today":
+		return now, today.AddDate(0, 0, 1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2), nil
+	case "this week":
+		return now, nextMonday, nil
+	case "next week":
+		return nextMonday, nextMonday.AddDate(0, 0, 7), nil
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid range %q", value)
+}
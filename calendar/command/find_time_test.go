@@ -0,0 +1,110 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+)
+
+func TestParseFindTimeArgs(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, time.May, 15, 9, 30, 0, 0, time.UTC)
+	userMentions := model.UserMentionMap{"alice": "alice_id", "bob": "bob_id"}
+
+	tcs := []struct {
+		name            string
+		command         string
+		expectedPayload *engine.FindTimePayload
+		expectedError   string
+	}{
+		{
+			name:    "default range",
+			command: `@alice @bob 45m`,
+			expectedPayload: &engine.FindTimePayload{
+				Attendees: []string{"alice_id", "bob_id"},
+				Duration:  45 * time.Minute,
+				From:      now,
+				To:        now.Add(7 * 24 * time.Hour),
+			},
+		},
+		{
+			name:    "this week",
+			command: `@alice 1h30m this week`,
+			expectedPayload: &engine.FindTimePayload{
+				Attendees: []string{"alice_id"},
+				Duration:  90 * time.Minute,
+				From:      now,
+				To:        time.Date(2024, time.May, 20, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "next week with subject",
+			command: `@bob 30m next week --subject "Planning"`,
+			expectedPayload: &engine.FindTimePayload{
+				Attendees: []string{"bob_id"},
+				Duration:  30 * time.Minute,
+				From:      time.Date(2024, time.May, 20, 0, 0, 0, 0, time.UTC),
+				To:        time.Date(2024, time.May, 27, 0, 0, 0, 0, time.UTC),
+				Subject:   "Planning",
+			},
+		},
+		{
+			name:    "tomorrow",
+			command: `@alice 1h tomorrow`,
+			expectedPayload: &engine.FindTimePayload{
+				Attendees: []string{"alice_id"},
+				Duration:  time.Hour,
+				From:      time.Date(2024, time.May, 16, 0, 0, 0, 0, time.UTC),
+				To:        time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "empty argument",
+			command: `@alice "" 1h tomorrow`,
+			expectedPayload: &engine.FindTimePayload{
+				Attendees: []string{"alice_id"},
+				Duration:  time.Hour,
+				From:      time.Date(2024, time.May, 16, 0, 0, 0, 0, time.UTC),
+				To:        time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:          "missing duration",
+			command:       `@alice this week`,
+			expectedError: "please provide the users to meet with and the duration of the meeting",
+		},
+		{
+			name:          "invalid duration",
+			command:       `@alice 45 minutes`,
+			expectedError: `invalid duration "45"`,
+		},
+		{
+			name:          "invalid range",
+			command:       `@alice 45m someday`,
+			expectedError: `invalid range "someday"`,
+		},
+		{
+			name:          "unknown user",
+			command:       `@carol 45m`,
+			expectedError: "could not find user @carol",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := parseFindTimeArgs(strings.Fields(tc.command), now, userMentions)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPayload, payload)
+		})
+	}
+}
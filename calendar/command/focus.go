@@ -0,0 +1,79 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+func getFocusHelp() string {
+	return "### Focus commands:\n" +
+		fmt.Sprintf("`/%s focus <duration> [title]` - Block your calendar and set your status to Do Not Disturb, for example for `90m` or `2h`\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s focus stop` - End your focus block now\n", config.Provider.CommandTrigger) +
+		"Event notifications and reminders are held back during the focus block, and sent to you as one message at its end."
+}
+
+func (c *Command) focus(parameters ...string) (string, bool, error) {
+	if len(parameters) == 0 {
+		return getFocusHelp(), false, nil
+	}
+
+	if parameters[0] == "stop" && len(parameters) == 1 {
+		block, err := c.Engine.StopFocusBlock(c.user())
+		if err != nil {
+			return "", false, err
+		}
+		if block == nil {
+			return "You are not in a focus block.", false, nil
+		}
+		return fmt.Sprintf("Your focus block **%s** was ended.", block.Subject), false, nil
+	}
+
+	d, subject, err := parseFocusArgs(parameters)
+	if err != nil {
+		return err.Error() + "\n" + getFocusHelp(), false, nil
+	}
+
+	block, err := c.Engine.StartFocusBlock(c.user(), d, subject)
+	if err != nil {
+		if errors.Is(err, engine.ErrFocusBlockInProgress) || errors.Is(err, engine.ErrInvalidEventPayload) {
+			return err.Error() + "\n" + getFocusHelp(), false, nil
+		}
+		return "", false, err
+	}
+
+	until := block.End.UTC().Format(time.Kitchen) + " UTC"
+	if timezone, errTimezone := c.Engine.GetTimezone(c.user()); errTimezone == nil {
+		if loc, errLoc := time.LoadLocation(tz.Go(timezone)); errLoc == nil {
+			until = block.End.In(loc).Format(time.Kitchen)
+		}
+	}
+
+	return fmt.Sprintf("Focus block **%s** started until %s. Your status is set to Do Not Disturb, and your event notifications will be sent to you at the end of the block.", block.Subject, until), false, nil
+}
+
+func parseFocusArgs(parameters []string) (time.Duration, string, error) {
+	d, err := time.ParseDuration(strings.ToLower(parameters[0]))
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid duration %q", parameters[0])
+	}
+	if d < time.Minute {
+		return 0, "", errors.New("the focus block must last at least one minute")
+	}
+
+	args, err := splitQuotedArgs(strings.Join(parameters[1:], " "))
+	if err != nil {
+		return 0, "", err
+	}
+
+	return d, strings.Join(args, " "), nil
+}
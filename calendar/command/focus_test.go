@@ -0,0 +1,60 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFocusArgs(t *testing.T) {
+	tcs := []struct {
+		name             string
+		command          string
+		expectedDuration time.Duration
+		expectedSubject  string
+		expectedError    string
+	}{
+		{
+			name:             "duration only",
+			command:          "90m",
+			expectedDuration: 90 * time.Minute,
+		},
+		{
+			name:             "duration and title",
+			command:          `1h30m "Write the RFC"`,
+			expectedDuration: 90 * time.Minute,
+			expectedSubject:  "Write the RFC",
+		},
+		{
+			name:             "unquoted title",
+			command:          "2H Quarterly planning",
+			expectedDuration: 2 * time.Hour,
+			expectedSubject:  "Quarterly planning",
+		},
+		{
+			name:          "invalid duration",
+			command:       "soon",
+			expectedError: `invalid duration "soon"`,
+		},
+		{
+			name:          "too short",
+			command:       "30s",
+			expectedError: "the focus block must last at least one minute",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			d, subject, err := parseFocusArgs(strings.Fields(tc.command))
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDuration, d)
+			require.Equal(t, tc.expectedSubject, subject)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func (c *Command) linkEvent(parameters ...string) (string, bool, error) {
+	ref, channelID, err := parseLinkEventArgs(parameters, c.ChannelID, c.Args.ChannelMentions)
+	if err != nil {
+		return err.Error() + "\n" + getEventsHelp(), false, nil
+	}
+
+	event, err := c.findEvent(ref)
+	if err != nil {
+		return "", false, err
+	}
+	if event == nil {
+		return fmt.Sprintf("Event %s not found. Use `/%s events list` to see your upcoming events.", ref, config.Provider.CommandTrigger), false, nil
+	}
+
+	linked, err := c.Engine.LinkEventToChannel(c.user(), event.ID, channelID)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			return err.Error(), false, nil
+		}
+		return "", false, err
+	}
+
+	return fmt.Sprintf("The event **%s** was linked to the channel.", views.EnsureSubject(linked.Subject)), false, nil
+}
+
+func (c *Command) unlinkEvent(parameters ...string) (string, bool, error) {
+	ref, channelID, err := parseLinkEventArgs(parameters, c.ChannelID, c.Args.ChannelMentions)
+	if err != nil {
+		return err.Error() + "\n" + getEventsHelp(), false, nil
+	}
+
+	event, err := c.findEvent(ref)
+	if err != nil {
+		return "", false, err
+	}
+	if event == nil {
+		return fmt.Sprintf("Event %s not found. Use `/%s events list` to see your upcoming events.", ref, config.Provider.CommandTrigger), false, nil
+	}
+
+	unlinked, err := c.Engine.UnlinkEventFromChannel(c.user(), event.ID, channelID)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidEventPayload) {
+			return err.Error(), false, nil
+		}
+		return "", false, err
+	}
+
+	return fmt.Sprintf("The event **%s** was unlinked from the channel.", views.EnsureSubject(unlinked.Subject)), false, nil
+}
+
+func (c *Command) listLinkedEvents() (string, bool, error) {
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return "", false, err
+	}
+
+	events, err := c.upcomingEvents()
+	if err != nil {
+		return "", false, err
+	}
+
+	return renderLinkedEvents(events, c.Engine.LinkedChannelNames(events), timezone), false, nil
+}
+
+// renderLinkedEvents lists the events linked to channels, with their number in `events list`.
+func renderLinkedEvents(events []*remote.Event, channelNames map[string][]string, timezone string) string {
+	lines := []string{}
+	for i, e := range events {
+		names := channelNames[e.ID]
+		if len(names) == 0 {
+			continue
+		}
+
+		start := e.Start.In(timezone).Time().Format("Mon Jan 02 3:04PM")
+		channels := "~" + strings.Join(names, ", ~")
+		lines = append(lines, fmt.Sprintf("%d. %s **%s** - %s", i+1, start, views.EnsureSubject(e.Subject), channels))
+	}
+
+	if len(lines) == 0 {
+		return "None of your upcoming events is linked to a channel."
+	}
+	return "Your upcoming events linked to channels:\n" + strings.Join(lines, "\n")
+}
+
+// parseLinkEventArgs returns the event reference and the channel of `events link` and `events unlink`. The
+// channel defaults to the current one.
+func parseLinkEventArgs(parameters []string, currentChannelID string, channelMentions model.ChannelMentionMap) (string, string, error) {
+	switch len(parameters) {
+	case 1:
+		return parameters[0], currentChannelID, nil
+	case 2:
+		if !strings.HasPrefix(parameters[1], "~") {
+			return "", "", fmt.Errorf("unexpected argument %q", parameters[1])
+		}
+		channelID, ok := channelMentions[strings.TrimPrefix(parameters[1], "~")]
+		if !ok {
+			return "", "", fmt.Errorf("could not find channel %s", parameters[1])
+		}
+		return parameters[0], channelID, nil
+	default:
+		return "", "", errors.New("please provide the number of the event, and optionally the channel")
+	}
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestParseLinkEventArgs(t *testing.T) {
+	tcs := []struct {
+		name              string
+		command           string
+		expectedRef       string
+		expectedChannelID string
+		expectedError     string
+	}{
+		{
+			name:              "current channel",
+			command:           "2",
+			expectedRef:       "2",
+			expectedChannelID: "current_channel_id",
+		},
+		{
+			name:              "mentioned channel",
+			command:           "2 ~town-square",
+			expectedRef:       "2",
+			expectedChannelID: "town_square_id",
+		},
+		{
+			name:          "unknown channel",
+			command:       "2 ~off-topic",
+			expectedError: "could not find channel ~off-topic",
+		},
+		{
+			name:          "not a channel",
+			command:       "2 town-square",
+			expectedError: `unexpected argument "town-square"`,
+		},
+		{
+			name:          "missing event",
+			command:       "",
+			expectedError: "please provide the number of the event, and optionally the channel",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, channelID, err := parseLinkEventArgs(strings.Fields(tc.command), "current_channel_id", model.ChannelMentionMap{"town-square": "town_square_id"})
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRef, ref)
+			require.Equal(t, tc.expectedChannelID, channelID)
+		})
+	}
+}
+
+func TestRenderLinkedEvents(t *testing.T) {
+	start := time.Date(2024, time.May, 14, 10, 0, 0, 0, time.UTC)
+	events := []*remote.Event{
+		{ID: "standup", Subject: "Standup", Start: remote.NewDateTime(start, "UTC")},
+		{ID: "review", Subject: "Review", Start: remote.NewDateTime(start.Add(24*time.Hour), "UTC")},
+	}
+
+	require.Equal(t, "None of your upcoming events is linked to a channel.", renderLinkedEvents(events, map[string][]string{}, "UTC"))
+	require.Equal(t,
+		"Your upcoming events linked to channels:\n2. Wed May 15 10:00AM **Review** - ~design, ~town-square",
+		renderLinkedEvents(events, map[string][]string{"review": {"design", "town-square"}}, "UTC"),
+	)
+}
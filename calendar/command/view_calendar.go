@@ -4,23 +4,144 @@
 package command
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	viewCalendarDefaultDays = 14
+	viewCalendarRangeSep    = ".."
 )
 
-func (c *Command) viewCalendar(_ ...string) (string, bool, error) {
-	tz, err := c.Engine.GetTimezone(c.user())
+func getViewCalendarHelp() string {
+	return "### View calendar command:\n" +
+		fmt.Sprintf("`/%s viewcal [today|tomorrow|week|next week|YYYY-MM-DD|YYYY-MM-DD..YYYY-MM-DD] [--compact|--table|--agenda]` - View your events\n", config.Provider.CommandTrigger) +
+		"Without a range, the events of the upcoming 14 days, including today, are shown."
+}
+
+type viewCalendarRequest struct {
+	from   time.Time
+	to     time.Time
+	format string
+}
+
+func (c *Command) viewCalendar(parameters ...string) (string, bool, error) {
+	timezone, err := c.Engine.GetTimezone(c.user())
 	if err != nil {
 		return "Error: No timezone found", false, err
 	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		return "", false, errors.Wrapf(err, "error loading timezone %s", timezone)
+	}
+
+	req, err := parseViewCalendarArgs(parameters, time.Now().In(loc))
+	if err != nil {
+		return err.Error() + "\n" + getViewCalendarHelp(), false, nil
+	}
 
-	startOfCurrentDay := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, time.Now().Location())
-	events, err := c.Engine.ViewCalendar(c.user(), startOfCurrentDay, time.Now().Add(14*24*time.Hour))
+	events, err := c.Engine.ViewCalendar(c.user(), req.from, req.to)
 	if err != nil {
 		return "", false, err
 	}
 
-	out, err := views.RenderCalendarView(events, tz)
+	var out string
+	switch req.format {
+	case views.CalendarViewCompact:
+		out, err = views.RenderCalendarCompact(events, timezone)
+	case views.CalendarViewAgenda:
+		out, err = views.RenderCalendarAgenda(events, timezone)
+	default:
+		out, err = views.RenderCalendarView(events, timezone)
+	}
 	return out, false, err
 }
+
+func parseViewCalendarArgs(parameters []string, now time.Time) (*viewCalendarRequest, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	req := &viewCalendarRequest{
+		from:   today,
+		to:     today.AddDate(0, 0, viewCalendarDefaultDays),
+		format: views.CalendarViewTable,
+	}
+
+	rangeWords := []string{}
+	for _, param := range parameters {
+		switch strings.ToLower(param) {
+		case "--compact":
+			req.format = views.CalendarViewCompact
+		case "--table":
+			req.format = views.CalendarViewTable
+		case "--agenda":
+			req.format = views.CalendarViewAgenda
+		default:
+			if strings.HasPrefix(param, "--") {
+				return nil, fmt.Errorf("unexpected argument %q", param)
+			}
+			rangeWords = append(rangeWords, strings.ToLower(param))
+		}
+	}
+
+	if len(rangeWords) == 0 {
+		return req, nil
+	}
+
+	var err error
+	req.from, req.to, err = parseViewCalendarRange(strings.Join(rangeWords, " "), today)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// parseViewCalendarRange returns the days of the range, the end day being excluded. Weeks start on Monday.
+func parseViewCalendarRange(value string, today time.Time) (time.Time, time.Time, error) {
+	nextMonday := today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7)
+
+	switch value {
+	case "today":
+		return today, today.AddDate(0, 0, 1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2), nil
+	case "week", "this week":
+		return today, nextMonday, nil
+	case "next week":
+		return nextMonday, nextMonday.AddDate(0, 0, 7), nil
+	}
+
+	parseDate := func(date string) (time.Time, error) {
+		day, err := time.ParseInLocation(engine.CreateEventDateFormat, date, today.Location())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q, please use the format YYYY-MM-DD", date)
+		}
+		return day, nil
+	}
+
+	start, end, isRange := strings.Cut(value, viewCalendarRangeSep)
+	from, err := parseDate(start)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !isRange {
+		return from, from.AddDate(0, 0, 1), nil
+	}
+
+	to, err := parseDate(end)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("the end of the range must not be before its start")
+	}
+
+	return from, to.AddDate(0, 0, 1), nil
+}
@@ -0,0 +1,85 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+)
+
+func TestParseViewCalendarArgs(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// Wednesday
+	now := time.Date(2024, time.May, 15, 21, 30, 0, 0, loc)
+	day := func(d int) time.Time {
+		return time.Date(2024, time.May, d, 0, 0, 0, 0, loc)
+	}
+
+	tcs := []struct {
+		name          string
+		parameters    []string
+		expected      *viewCalendarRequest
+		expectedError string
+	}{
+		{
+			name:     "default",
+			expected: &viewCalendarRequest{from: day(15), to: day(29), format: views.CalendarViewTable},
+		},
+		{
+			name:       "today compact",
+			parameters: []string{"today", "--compact"},
+			expected:   &viewCalendarRequest{from: day(15), to: day(16), format: views.CalendarViewCompact},
+		},
+		{
+			name:       "week",
+			parameters: []string{"week"},
+			expected:   &viewCalendarRequest{from: day(15), to: day(20), format: views.CalendarViewTable},
+		},
+		{
+			name:       "next week agenda",
+			parameters: []string{"--agenda", "next", "week"},
+			expected:   &viewCalendarRequest{from: day(20), to: day(27), format: views.CalendarViewAgenda},
+		},
+		{
+			name:       "single date",
+			parameters: []string{"2024-05-18"},
+			expected:   &viewCalendarRequest{from: day(18), to: day(19), format: views.CalendarViewTable},
+		},
+		{
+			name:       "date range",
+			parameters: []string{"2024-05-16..2024-05-22", "--table"},
+			expected:   &viewCalendarRequest{from: day(16), to: day(23), format: views.CalendarViewTable},
+		},
+		{
+			name:          "reversed range",
+			parameters:    []string{"2024-05-22..2024-05-16"},
+			expectedError: "the end of the range must not be before its start",
+		},
+		{
+			name:          "invalid date",
+			parameters:    []string{"someday"},
+			expectedError: `invalid date "someday", please use the format YYYY-MM-DD`,
+		},
+		{
+			name:          "unknown option",
+			parameters:    []string{"--list"},
+			expectedError: `unexpected argument "--list"`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := parseViewCalendarArgs(tc.parameters, now)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, req)
+		})
+	}
+}
@@ -10,6 +10,14 @@ type StoredConfig struct {
 	OAuth2Authority    string
 	OAuth2ClientID     string
 	OAuth2ClientSecret string
+	// GraphBaseURL and OAuth2AuthorityHost point the plugin to a national cloud, or to a Graph stand-in. The
+	// global cloud is used when they are empty.
+	GraphBaseURL        string
+	OAuth2AuthorityHost string
+	// RemoteKind selects the calendar provider, among the registered remotes. The provider of the build is used
+	// when it is empty. It is not in the System Console, as the other remotes, like the fake calendar, are only
+	// built for development.
+	RemoteKind string
 	bot.Config
 	EnableStatusSync   bool
 	EnableDailySummary bool
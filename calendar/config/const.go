@@ -17,9 +17,19 @@ const (
 	PathDecline               = "/decline"
 	PathTentative             = "/tentative"
 	PathConfirmStatusChange   = "/confirm"
+	PathProposeNewTime        = "/propose"
+	PathAcceptProposedTime    = "/accept-proposal"
+	PathBookMeetingTime       = "/book-meeting-time"
+	PathPreviewEventImport    = "/preview-event-import"
+	PathImportEvent           = "/import-event"
+	PathSnoozeReminder        = "/snooze-reminder"
+	PathJoinMeeting           = "/join-meeting"
+	PathRunningLate           = "/running-late"
+	PathLinkEvent             = "/link-event"
 	PathNotification          = "/notification/v1"
 	PathEvent                 = "/event"
 	PathVerifyDomain          = "/verify"
+	PathRemote                = "/remote"
 
 	PathAutocomplete = "/autocomplete"
 	PathUsers        = "/users"
@@ -28,11 +38,22 @@ const (
 	InternalAPIPath   = "/api/v1"
 	PathEvents        = "/events"
 	PathCreate        = "/create"
+	PathExport        = "/export"
+	PathEventID       = "/{eventID}"
+	PathAvailability  = "/availability"
+	PathUserID        = "/{userID}"
 	PathProvider      = "/provider"
 	PathConnectedUser = "/me"
 
 	FullPathEventNotification = PathNotification + PathEvent
 	FullPathOAuth2Redirect    = PathOAuth2 + PathComplete
 
-	EventIDKey = "EventID"
+	EventIDKey       = "EventID"
+	ProposedStartKey = "ProposedStart"
+	ProposedEndKey   = "ProposedEnd"
+	EventPayloadKey  = "EventPayload"
+	FileIDKey        = "FileID"
+	EventIndexKey    = "EventIndex"
+	ChannelIDKey     = "ChannelID"
+	JoinURLKey       = "JoinURL"
 )
@@ -4,8 +4,11 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -20,13 +23,24 @@ import (
 )
 
 const (
-	calendarViewTimeWindowSize    = 10 * time.Minute
-	StatusSyncJobInterval         = 5 * time.Minute
-	upcomingEventNotificationTime = 10 * time.Minute
-
-	upcomingEventNotificationWindow = (StatusSyncJobInterval * 11) / 10 // 110% of the interval
-	logTruncateMsg                  = "We've truncated the logs due to too many messages"
-	logTruncateLimit                = 5
+	calendarViewTimeWindowSize = 10 * time.Minute
+	StatusSyncJobInterval      = 5 * time.Minute
+
+	// A reminder is delivered by the sync job closest to its time. As the jobs are dithered, a reminder that is
+	// late by up to 110% of the interval is still delivered, like before the lead times were configurable.
+	reminderEarlyTolerance = StatusSyncJobInterval / 2
+	reminderLateTolerance  = (StatusSyncJobInterval * 11) / 10
+	maxReminderLeadTime    = 2 * time.Hour
+	reminderClaimTTL       = maxReminderLeadTime + reminderLateTolerance
+
+	// The status sync job syncs the users in pages, with a few pages in flight at once. It stops dispatching
+	// pages when its budget is exhausted, leaving time to the pages in flight before the next job.
+	statusSyncPageSize  = 100
+	statusSyncWorkers   = 4
+	statusSyncJobBudget = StatusSyncJobInterval * 4 / 5
+
+	logTruncateMsg   = "We've truncated the logs due to too many messages"
+	logTruncateLimit = 5
 )
 
 var (
@@ -37,10 +51,32 @@ type StatusSyncJobSummary struct {
 	NumberOfUsersFailedStatusChanged int
 	NumberOfUsersStatusChanged       int
 	NumberOfUsersProcessed           int
+	NumberOfUsersSkipped             int
+
+	// Time spent in each phase, added up over the pages. As the pages are synced in parallel, the phases can add
+	// up to more than the duration of the job.
+	LoadUsersDuration      time.Duration
+	FetchCalendarsDuration time.Duration
+	UpdateUsersDuration    time.Duration
+	Duration               time.Duration
+}
+
+func (s *StatusSyncJobSummary) add(other *StatusSyncJobSummary) {
+	if other == nil {
+		return
+	}
+	s.NumberOfUsersFailedStatusChanged += other.NumberOfUsersFailedStatusChanged
+	s.NumberOfUsersStatusChanged += other.NumberOfUsersStatusChanged
+	s.NumberOfUsersProcessed += other.NumberOfUsersProcessed
+	s.NumberOfUsersSkipped += other.NumberOfUsersSkipped
+	s.LoadUsersDuration += other.LoadUsersDuration
+	s.FetchCalendarsDuration += other.FetchCalendarsDuration
+	s.UpdateUsersDuration += other.UpdateUsersDuration
 }
 
 type Availability interface {
 	GetCalendarViews(users []*store.User) ([]*remote.ViewCalendarResponse, error)
+	GetSchedule(user *User, mattermostUserIDs []string, date string) (*Schedule, error)
 	Sync(mattermostUserID string) (string, *StatusSyncJobSummary, error)
 	SyncAll() (string, *StatusSyncJobSummary, error)
 }
@@ -61,26 +97,137 @@ func (m *mscalendar) Sync(mattermostUserID string) (string, *StatusSyncJobSummar
 	return m.syncUsers(userIndex, errors.Is(err, remote.ErrSuperUserClientNotSupported))
 }
 
+// SyncAll syncs the connected users in pages of the user index, dispatched to a pool of workers so that the
+// users of a page are loaded while the calendars of another page are fetched. The job stops dispatching pages
+// when its budget is exhausted, and the users it didn't get to are synced first by the next job.
 func (m *mscalendar) SyncAll() (string, *StatusSyncJobSummary, error) {
-	userIndex, err := m.Store.LoadUserIndex()
+	return m.syncAllUntil(time.Now().Add(statusSyncJobBudget), statusSyncWorkers)
+}
+
+func (m *mscalendar) syncAllUntil(deadline time.Time, workers int) (string, *StatusSyncJobSummary, error) {
+	start := time.Now()
+	jobSummary := &StatusSyncJobSummary{}
+	defer func() {
+		jobSummary.Duration = time.Since(start)
+	}()
+
+	err := m.Filter(withSuperuserClient)
+	if err != nil && !errors.Is(err, remote.ErrSuperUserClientNotSupported) {
+		return "", jobSummary, errors.Wrap(err, "not able to filter the super user client")
+	}
+	fetchIndividually := errors.Is(err, remote.ErrSuperUserClientNotSupported)
+
+	skippedLastTime, err := m.Store.LoadSkippedStatusSyncUsers()
 	if err != nil {
-		if err.Error() == "not found" {
-			return "No users found in user index", &StatusSyncJobSummary{}, nil
+		m.Logger.Warnf("Not able to load the users skipped by the last status sync. err=%v", err)
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	var lock sync.Mutex
+	results := []string{}
+	var syncErr error
+
+	pages := make(chan store.UserIndex)
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker := m.copy()
+			for page := range pages {
+				result, pageSummary, err := worker.syncUsers(page, fetchIndividually)
+
+				lock.Lock()
+				jobSummary.add(pageSummary)
+				switch {
+				case errors.Is(err, errNoUsersNeedToBeSynced):
+				case err != nil && result == "":
+					m.Logger.Warnf("Not able to sync a page of the user index. err=%v", err)
+					if syncErr == nil {
+						syncErr = err
+					}
+				case result != "":
+					results = append(results, result)
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	numberOfUsers := 0
+	skipped := []string{}
+	dispatch := func(userIndex store.UserIndex) {
+		for len(userIndex) > 0 {
+			page := userIndex[:min(statusSyncPageSize, len(userIndex))]
+			userIndex = userIndex[len(page):]
+			numberOfUsers += len(page)
+
+			if ctx.Err() == nil {
+				select {
+				case pages <- page:
+					continue
+				case <-ctx.Done():
+				}
+			}
+			for _, u := range page {
+				skipped = append(skipped, u.MattermostUserID)
+			}
 		}
-		return "", &StatusSyncJobSummary{}, errors.Wrap(err, "not able to load the users from user index")
 	}
 
-	err = m.Filter(withSuperuserClient)
-	if err != nil && !errors.Is(err, remote.ErrSuperUserClientNotSupported) {
-		return "", &StatusSyncJobSummary{}, errors.Wrap(err, "not able to filter the super user client")
+	first := map[string]bool{}
+	userIndex := store.UserIndex{}
+	for _, mattermostUserID := range skippedLastTime {
+		first[mattermostUserID] = true
+		userIndex = append(userIndex, &store.UserShort{MattermostUserID: mattermostUserID})
 	}
+	dispatch(userIndex)
 
-	result, jobSummary, err := m.syncUsers(userIndex, errors.Is(err, remote.ErrSuperUserClientNotSupported))
-	if result != "" && err != nil {
-		return result, jobSummary, nil
+	err = m.Store.ForEachUserIndexPage(func(page store.UserIndex) error {
+		userIndex := store.UserIndex{}
+		for _, u := range page {
+			if !first[u.MattermostUserID] {
+				userIndex = append(userIndex, u)
+			}
+		}
+		dispatch(userIndex)
+		return nil
+	})
+	close(pages)
+	wg.Wait()
+	indexErr := err
+	if indexErr != nil {
+		// The users of the shards failing to load are synced by the next job, if their shards load by then
+		m.Logger.Warnf("Not able to load some users from the user index, continuing with the other ones. err=%v", indexErr)
+	}
+
+	if len(skipped) > 0 || len(skippedLastTime) > 0 {
+		err = m.Store.StoreSkippedStatusSyncUsers(skipped)
+		if err != nil {
+			m.Logger.Warnf("Not able to store the users skipped by the status sync. err=%v", err)
+		}
+	}
+	jobSummary.NumberOfUsersSkipped = len(skipped)
+	if len(skipped) > 0 {
+		m.Logger.Warnf("The status sync ran out of time, %d users will be synced first by the next status sync.", len(skipped))
+		results = append(results, fmt.Sprintf("%d users skipped, they will be synced first next time", len(skipped)))
 	}
 
-	return result, jobSummary, err
+	if numberOfUsers == 0 {
+		if indexErr != nil {
+			return "", jobSummary, errors.Wrap(indexErr, "not able to load the users from user index")
+		}
+		return "No users found in user index", jobSummary, nil
+	}
+	if len(results) == 0 && syncErr != nil {
+		return "", jobSummary, syncErr
+	}
+	if len(results) == 0 {
+		return errNoUsersNeedToBeSynced.Error(), jobSummary, nil
+	}
+	return strings.Join(results, "\n"), jobSummary, nil
 }
 
 // retrieveUsersToSync retrieves the users and their calendar data to sync up and send notifications
@@ -88,14 +235,20 @@ func (m *mscalendar) SyncAll() (string, *StatusSyncJobSummary, error) {
 // users (using individual credentials) or on a batch after the loop.
 func (m *mscalendar) retrieveUsersToSync(userIndex store.UserIndex, syncJobSummary *StatusSyncJobSummary, fetchIndividually bool) ([]*store.User, []*remote.ViewCalendarResponse, error) {
 	start := time.Now().UTC()
-	end := time.Now().UTC().Add(calendarViewTimeWindowSize)
 
 	numberOfLogs := 0
 	users := []*store.User{}
 	calendarViews := []*remote.ViewCalendarResponse{}
 	for _, u := range userIndex {
-		// TODO fetch users from kvstore in batches, and process in batches instead of all at once
+		loadStart := time.Now()
 		user, err := m.Store.LoadUser(u.MattermostUserID)
+		syncJobSummary.LoadUsersDuration += time.Since(loadStart)
+		if errors.Is(err, store.ErrNotFound) {
+			// The user disconnected since the page was read. They are not skipped either, so they are dropped
+			// from the users to sync first next time.
+			m.Logger.Debugf("User %s disconnected, not syncing them.", u.MattermostUserID)
+			continue
+		}
 		if err != nil {
 			syncJobSummary.NumberOfUsersFailedStatusChanged++
 			if numberOfLogs < logTruncateLimit {
@@ -110,7 +263,7 @@ func (m *mscalendar) retrieveUsersToSync(userIndex store.UserIndex, syncJobSumma
 		}
 
 		// If user does not have the proper features enabled, just go to the next one
-		if !(user.IsConfiguredForStatusUpdates() || user.IsConfiguredForCustomStatusUpdates() || user.Settings.ReceiveReminders) {
+		if !(user.IsConfiguredForStatusUpdates() || user.IsConfiguredForCustomStatusUpdates() || user.IsConfiguredForPresenceUpdates() || user.Settings.ReceiveReminders) {
 			continue
 		}
 
@@ -122,7 +275,10 @@ func (m *mscalendar) retrieveUsersToSync(userIndex store.UserIndex, syncJobSumma
 			}
 
 			calendarUser := newUserFromStoredUser(user)
+			end := start.Add(calendarViewTimeWindow([]*store.User{user}))
+			fetchStart := time.Now()
 			calendarEvents, err := engine.GetCalendarEvents(calendarUser, start, end, true)
+			syncJobSummary.FetchCalendarsDuration += time.Since(fetchStart)
 			if err != nil {
 				syncJobSummary.NumberOfUsersFailedStatusChanged++
 				m.Logger.With(bot.LogContext{
@@ -144,7 +300,9 @@ func (m *mscalendar) retrieveUsersToSync(userIndex store.UserIndex, syncJobSumma
 
 	if !fetchIndividually {
 		var err error
+		fetchStart := time.Now()
 		calendarViews, err = m.GetCalendarViews(users)
+		syncJobSummary.FetchCalendarsDuration += time.Since(fetchStart)
 		if err != nil {
 			return users, calendarViews, errors.Wrap(err, "not able to get calendar views for connected users")
 		}
@@ -177,8 +335,10 @@ func (m *mscalendar) syncUsers(userIndex store.UserIndex, fetchIndividually bool
 		return err.Error(), syncJobSummary, errors.Wrapf(err, "error retrieving users to sync (individually=%v)", fetchIndividually)
 	}
 
+	updateStart := time.Now()
 	m.deliverReminders(users, calendarViews, fetchIndividually)
-	out, numberOfUsersStatusChanged, numberOfUsersFailedStatusChanged, err := m.setUserStatuses(users, calendarViews)
+	out, numberOfUsersStatusChanged, numberOfUsersFailedStatusChanged, err := m.setUserStatuses(users, calendarViews, fetchIndividually)
+	syncJobSummary.UpdateUsersDuration = time.Since(updateStart)
 	if err != nil {
 		return "", syncJobSummary, errors.Wrap(err, "error setting the user statuses")
 	}
@@ -221,25 +381,24 @@ func (m *mscalendar) deliverReminders(users []*store.User, calendarViews []*remo
 			continue
 		}
 
-		mattermostUserID := usersByRemoteID[view.RemoteUserID].MattermostUserID
 		if fetchIndividually {
 			engine, err := m.FilterCopy(withActingUser(user.MattermostUserID))
 			if err != nil {
 				m.Logger.With(bot.LogContext{"err": err}).Errorf("error getting engine for user")
 				continue
 			}
-			engine.notifyUpcomingEvents(mattermostUserID, view.Events)
+			engine.notifyUpcomingEvents(user, view.Events)
 		} else {
-			m.notifyUpcomingEvents(mattermostUserID, view.Events)
+			m.notifyUpcomingEvents(user, view.Events)
 		}
 	}
 }
 
-func (m *mscalendar) setUserStatuses(users []*store.User, calendarViews []*remote.ViewCalendarResponse) (string, int, int, error) {
+func (m *mscalendar) setUserStatuses(users []*store.User, calendarViews []*remote.ViewCalendarResponse, fetchIndividually bool) (string, int, int, error) {
 	numberOfLogs, numberOfUserStatusChange, numberOfUserErrorInStatusChange := 0, 0, 0
 	toUpdate := []*store.User{}
 	for _, u := range users {
-		if u.IsConfiguredForStatusUpdates() || u.IsConfiguredForCustomStatusUpdates() {
+		if u.IsConfiguredForStatusUpdates() || u.IsConfiguredForCustomStatusUpdates() || u.IsConfiguredForPresenceUpdates() {
 			toUpdate = append(toUpdate, u)
 		}
 	}
@@ -263,6 +422,15 @@ func (m *mscalendar) setUserStatuses(users []*store.User, calendarViews []*remot
 		statusMap[s.UserId] = s
 	}
 
+	focusUserIDs, err := m.Store.LoadFocusBlockUsers()
+	if err != nil {
+		m.Logger.Warnf("Error loading the users in a focus block. err=%v", err)
+	}
+	focusing := map[string]bool{}
+	for _, id := range focusUserIDs {
+		focusing[id] = true
+	}
+
 	var res string
 	for _, view := range calendarViews {
 		isStatusChanged := false
@@ -287,12 +455,34 @@ func (m *mscalendar) setUserStatuses(users []*store.User, calendarViews []*remot
 			continue
 		}
 
-		events := filterBusyAndAttendeeEvents(view.Events)
-		events = getMergedEvents(events)
+		// The view goes further ahead for the reminders, only the events starting soon make the user busy
+		upcomingEvents := eventsStartingBefore(view.Events, time.Now().Add(calendarViewTimeWindowSize))
+
+		// The events matching a custom status rule take precedence over the meetings for the custom status
+		customStatusEvents := filterCustomStatusRuleEvents(upcomingEvents, user.Settings.CustomStatusRules)
+
+		events := getMergedEvents(filterBusyAndAttendeeEvents(upcomingEvents))
+		if len(customStatusEvents) == 0 {
+			customStatusEvents = events
+		}
 
 		var err error
-		if user.IsConfiguredForStatusUpdates() {
-			res, isStatusChanged, err = m.setStatusFromCalendarView(user, status, events)
+		var p *presence
+		if user.IsConfiguredForPresenceUpdates() {
+			p, err = m.getPresence(user, fetchIndividually, time.Now())
+			if err != nil {
+				if numberOfLogs < logTruncateLimit {
+					m.Logger.Warnf("Error getting user %s mailbox settings. err=%v", user.MattermostUserID, err)
+				} else if numberOfLogs == logTruncateLimit {
+					m.Logger.Warnf(logTruncateMsg)
+				}
+				numberOfLogs++
+				numberOfUserErrorInStatusChange++
+			}
+		}
+
+		if user.IsConfiguredForStatusUpdates() || (p != nil && user.IsConfiguredForPresenceUpdates()) {
+			res, isStatusChanged, err = m.setStatusFromCalendarView(user, status, events, p, focusing[mattermostUserID])
 			if err != nil {
 				if numberOfLogs < logTruncateLimit {
 					m.Logger.Warnf("Error setting user %s status. err=%v", user.MattermostUserID, err)
@@ -307,8 +497,21 @@ func (m *mscalendar) setUserStatuses(users []*store.User, calendarViews []*remot
 			}
 		}
 
+		if p != nil && user.Settings.SetOutOfOfficeCustomStatus {
+			res, isStatusChanged, err = m.setOutOfOfficeCustomStatus(user, p, time.Now())
+			if err != nil {
+				if numberOfLogs < logTruncateLimit {
+					m.Logger.Warnf("Error setting user %s out of office custom status. err=%v", user.MattermostUserID, err)
+				} else if numberOfLogs == logTruncateLimit {
+					m.Logger.Warnf(logTruncateMsg)
+				}
+				numberOfLogs++
+				numberOfUserErrorInStatusChange++
+			}
+		}
+
 		if user.IsConfiguredForCustomStatusUpdates() {
-			res, isStatusChanged, err = m.setCustomStatusFromCalendarView(user, events)
+			res, isStatusChanged, err = m.setCustomStatusFromCalendarView(user, customStatusEvents)
 			if err != nil {
 				if numberOfLogs < logTruncateLimit {
 					m.Logger.Warnf("Error setting user %s custom status. err=%v", user.MattermostUserID, err)
@@ -339,6 +542,10 @@ func (m *mscalendar) setCustomStatusFromCalendarView(user *store.User, events []
 		return "User doesn't want to set custom status", isStatusChanged, nil
 	}
 
+	if user.IsOutOfOfficeCustomStatusSet {
+		return "Out of office custom status set, ignoring custom status change", isStatusChanged, nil
+	}
+
 	if len(events) == 0 {
 		if user.IsCustomStatusSet {
 			if err := m.PluginAPI.RemoveMattermostUserCustomStatus(user.MattermostUserID); err != nil {
@@ -363,12 +570,13 @@ func (m *mscalendar) setCustomStatusFromCalendarView(user *store.User, events []
 		return "User already has a custom status set, ignoring custom status change", isStatusChanged, nil
 	}
 
-	if appErr := m.PluginAPI.UpdateMattermostUserCustomStatus(user.MattermostUserID, &model.CustomStatus{
-		Emoji:     "calendar",
-		Text:      "In a meeting",
-		ExpiresAt: events[0].End.Time(),
-		Duration:  "date_and_time",
-	}); appErr != nil {
+	loc, err := time.LoadLocation(currentUser.GetPreferredTimezone())
+	if err != nil {
+		loc = time.UTC
+	}
+
+	customStatus := newCustomStatus(user.Settings.CustomStatusRules, events[0], loc)
+	if appErr := m.PluginAPI.UpdateMattermostUserCustomStatus(user.MattermostUserID, customStatus); appErr != nil {
 		return "", isStatusChanged, appErr
 	}
 
@@ -380,17 +588,35 @@ func (m *mscalendar) setCustomStatusFromCalendarView(user *store.User, events []
 	return "", isStatusChanged, nil
 }
 
-func (m *mscalendar) setStatusFromCalendarView(user *store.User, status *model.Status, events []*remote.Event) (string, bool, error) {
+// setStatusFromCalendarView sets the status of the user from the events starting soon, and from their presence
+// according to their mailbox settings, when it is known. The status is left alone during a focus block.
+func (m *mscalendar) setStatusFromCalendarView(user *store.User, status *model.Status, events []*remote.Event, p *presence, focusing bool) (string, bool, error) {
 	isStatusChanged := false
-	currentStatus := status.Status
-	if !user.IsConfiguredForStatusUpdates() {
+	if !user.IsConfiguredForStatusUpdates() && !user.IsConfiguredForPresenceUpdates() {
 		return "No value set from options to update status", isStatusChanged, nil
 	}
 
-	if currentStatus == model.StatusOffline && !user.Settings.GetConfirmation {
+	if status.Status == model.StatusOffline && !user.Settings.GetConfirmation {
 		return "User offline and does not want status change confirmations. No status change", isStatusChanged, nil
 	}
 
+	// The DND status set for the focus block is kept until its end
+	if focusing {
+		return "User is in a focus block. No status change.", isStatusChanged, nil
+	}
+
+	if p != nil {
+		message, handled, changed, err := m.setStatusFromPresence(user, status, events, p)
+		if err != nil || handled {
+			return message, changed, err
+		}
+	}
+
+	if !user.IsConfiguredForStatusUpdates() {
+		return "No value set from options to update status", isStatusChanged, nil
+	}
+	currentStatus := status.Status
+
 	busyStatus := model.StatusDnd
 	if user.Settings.UpdateStatusFromOptions == store.AwayStatusOption {
 		busyStatus = model.StatusAway
@@ -545,7 +771,12 @@ func (m *mscalendar) GetCalendarEvents(user *User, start, end time.Time, exclude
 		return nil, errors.Wrap(err, "error withClient in GetCalendarEvents")
 	}
 
-	events, err := m.client.GetEventsBetweenDates(user.Remote.ID, start, end)
+	var events []*remote.Event
+	if user.User == nil || len(user.Settings.CalendarIDs) == 0 {
+		events, err = m.client.GetEventsBetweenDates(user.Remote.ID, start, end)
+	} else {
+		events, err = m.getSelectedCalendarsView(user.User, start, end)
+	}
 	if err != nil {
 		return nil, errors.Wrapf(err, "error getting events for user %s", user.MattermostUserID)
 	}
@@ -567,83 +798,172 @@ func (m *mscalendar) GetCalendarViews(users []*store.User) ([]*remote.ViewCalend
 	}
 
 	start := time.Now().UTC()
-	end := time.Now().UTC().Add(calendarViewTimeWindowSize)
+	end := start.Add(calendarViewTimeWindow(users))
 
 	params := []*remote.ViewCalendarParams{}
 	for _, u := range users {
-		params = append(params, &remote.ViewCalendarParams{
-			RemoteUserID: u.Remote.ID,
-			StartTime:    start,
-			EndTime:      end,
-		})
+		params = append(params, calendarViewParams(u, start, end)...)
+	}
+
+	calendarViews, err := m.client.DoBatchViewCalendarRequests(params)
+	if err != nil {
+		return nil, err
 	}
 
-	return m.client.DoBatchViewCalendarRequests(params)
+	return m.mergeCalendarViews(calendarViews), nil
 }
 
-func (m *mscalendar) notifyUpcomingEvents(mattermostUserID string, events []*remote.Event) {
+func (m *mscalendar) notifyUpcomingEvents(user *store.User, events []*remote.Event) {
+	mattermostUserID := user.MattermostUserID
+	now := time.Now()
 	var timezone string
 	for _, event := range events {
 		if event.IsCancelled {
 			continue
 		}
-		upcomingTime := time.Now().Add(upcomingEventNotificationTime)
-		start := event.Start.Time()
-		diff := start.Sub(upcomingTime)
-
-		if (diff < upcomingEventNotificationWindow) && (diff > -upcomingEventNotificationWindow) {
-			var err error
-			if timezone == "" {
-				timezone, err = m.GetTimezoneByID(mattermostUserID)
-				if err != nil {
-					m.Logger.Warnf("notifyUpcomingEvents error getting timezone. err=%v", err)
-					return
-				}
-			}
 
-			_, attachment, err := views.RenderUpcomingEventAsAttachment(event, timezone)
+		reminderIDs := m.claimDueReminders(user, event, now)
+		if len(reminderIDs) == 0 {
+			continue
+		}
+
+		var err error
+		if timezone == "" {
+			timezone, err = m.GetTimezoneByID(mattermostUserID)
 			if err != nil {
-				m.Logger.Warnf("notifyUpcomingEvent error rendering schedule item. err=%v", err)
-				continue
+				m.Logger.Warnf("notifyUpcomingEvents error getting timezone. err=%v", err)
+				m.releaseReminders(mattermostUserID, reminderIDs)
+				return
 			}
+		}
 
-			_, err = m.Poster.DMWithAttachments(mattermostUserID, attachment)
-			if err != nil {
-				m.Logger.Warnf("notifyUpcomingEvents error creating DM. err=%v", err)
+		linkedChannelIDs := m.linkedChannelIDs(event)
+		err = m.sendEventReminder(user, event, timezone, linkedChannelIDs)
+		if err != nil {
+			m.Logger.Warnf("notifyUpcomingEvents error sending reminder. err=%v", err)
+			// The next sync job delivers the reminder if it is not too late
+			m.releaseReminders(mattermostUserID, reminderIDs)
+			continue
+		}
+
+		// Process channel reminders
+		for _, channelID := range linkedChannelIDs {
+			post := &model.Post{
+				ChannelId: channelID,
+				Message:   "Upcoming event",
+			}
+			attachment, errRender := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
+			if errRender != nil {
+				m.Logger.With(bot.LogContext{"err": errRender}).Errorf("notifyUpcomingEvents error rendering channel post")
+				continue
+			}
+			model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+			errPoster := m.Poster.CreatePost(post)
+			if errPoster != nil {
+				m.Logger.With(bot.LogContext{"err": errPoster}).Warnf("notifyUpcomingEvents error creating post in channel")
 				continue
 			}
+		}
+	}
+}
 
-			// Process channel reminders
-			eventMetadata, errMetadata := m.Store.LoadEventMetadata(event.ICalUID)
-			if errMetadata != nil && !errors.Is(errMetadata, store.ErrNotFound) {
-				m.Logger.With(bot.LogContext{
-					"eventID": event.ID,
-					"err":     errMetadata.Error(),
-				}).Warnf("notifyUpcomingEvents error checking store for channel notifications")
+// claimDueReminders claims the reminders of the event that are due, and returns the IDs of the ones that were
+// not delivered yet. Reminders that are due at the same time are delivered as one.
+func (m *mscalendar) claimDueReminders(user *store.User, event *remote.Event, now time.Time) []string {
+	claimed := []string{}
+	for _, leadTime := range dueReminderLeadTimes(user.Settings.ReminderLeadTimeMinutes(), event, now) {
+		id := reminderID(event, leadTime)
+		ok, err := m.Store.ClaimReminder(user.MattermostUserID, id, reminderClaimTTL)
+		if err != nil {
+			m.Logger.With(bot.LogContext{"err": err.Error(), "reminderID": id}).Warnf("notifyUpcomingEvents error claiming reminder")
+			continue
+		}
+		if ok {
+			claimed = append(claimed, id)
+		}
+	}
+	return claimed
+}
+
+func (m *mscalendar) releaseReminders(mattermostUserID string, reminderIDs []string) {
+	for _, id := range reminderIDs {
+		if err := m.Store.ReleaseReminder(mattermostUserID, id); err != nil {
+			m.Logger.With(bot.LogContext{"err": err.Error(), "reminderID": id}).Warnf("notifyUpcomingEvents error releasing reminder")
+		}
+	}
+}
+
+// dueReminderLeadTimes returns the lead times of the reminders of the event that are due at the time of the
+// sync job. Lead times are capped to maxReminderLeadTime.
+func dueReminderLeadTimes(leadTimes []int, event *remote.Event, now time.Time) []time.Duration {
+	start := event.Start.Time()
+	if !start.After(now) {
+		return nil
+	}
+
+	var due []time.Duration
+	for _, minutes := range leadTimes {
+		leadTime := time.Duration(minutes) * time.Minute
+		if minutes == store.OutlookReminderLeadTime {
+			if !event.IsReminderOn {
 				continue
 			}
+			leadTime = time.Duration(event.ReminderMinutesBeforeStart) * time.Minute
+		}
+		if leadTime > maxReminderLeadTime {
+			leadTime = maxReminderLeadTime
+		}
 
-			if eventMetadata != nil {
-				for channelID := range eventMetadata.LinkedChannelIDs {
-					post := &model.Post{
-						ChannelId: channelID,
-						Message:   "Upcoming event",
-					}
-					attachment, errRender := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
-					if errRender != nil {
-						m.Logger.With(bot.LogContext{"err": errRender}).Errorf("notifyUpcomingEvents error rendering channel post")
-						continue
-					}
-					model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
-					errPoster := m.Poster.CreatePost(post)
-					if errPoster != nil {
-						m.Logger.With(bot.LogContext{"err": errPoster}).Warnf("notifyUpcomingEvents error creating post in channel")
-						continue
-					}
-				}
+		remindAt := start.Add(-leadTime)
+		if remindAt.Sub(now) > reminderEarlyTolerance || now.Sub(remindAt) > reminderLateTolerance {
+			continue
+		}
+		due = append(due, leadTime)
+	}
+
+	return due
+}
+
+// reminderID identifies the reminder of an occurrence of an event. The start time is part of it, so that moved
+// events are reminded again.
+func reminderID(event *remote.Event, leadTime time.Duration) string {
+	eventID := event.ID
+	if eventID == "" {
+		eventID = event.ICalUID
+	}
+	return fmt.Sprintf("%s_%s_%d", eventID, event.Start.Time().UTC().Format(time.RFC3339), int(leadTime.Minutes()))
+}
+
+// calendarViewTimeWindow returns how far ahead the events of the users are fetched, so that the events making
+// them busy soon and the events they need to be reminded of are found.
+func calendarViewTimeWindow(users []*store.User) time.Duration {
+	window := calendarViewTimeWindowSize
+	for _, u := range users {
+		if !u.Settings.ReceiveReminders {
+			continue
+		}
+		for _, minutes := range u.Settings.ReminderLeadTimeMinutes() {
+			leadTime := time.Duration(minutes) * time.Minute
+			if minutes == store.OutlookReminderLeadTime || leadTime > maxReminderLeadTime {
+				leadTime = maxReminderLeadTime
+			}
+			if leadTime+reminderEarlyTolerance > window {
+				window = leadTime + reminderEarlyTolerance
 			}
 		}
 	}
+	return window
+}
+
+// eventsStartingBefore returns the events starting before the given time.
+func eventsStartingBefore(events []*remote.Event, t time.Time) []*remote.Event {
+	result := []*remote.Event{}
+	for _, e := range events {
+		if e.Start.Time().Before(t) {
+			result = append(result, e)
+		}
+	}
+	return result
 }
 
 func filterBusyAndAttendeeEvents(events []*remote.Event) []*remote.Event {
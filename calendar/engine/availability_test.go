@@ -5,6 +5,7 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -123,13 +124,16 @@ func TestSyncStatusAll(t *testing.T) {
 			deps := env.Dependencies
 
 			c, r, papi, s, logger := client.(*mock_remote.MockClient), env.Remote.(*mock_remote.MockRemote), deps.PluginAPI.(*mock_plugin_api.MockPluginAPI), deps.Store.(*mock_store.MockStore), deps.Logger.(*mock_bot.MockLogger)
-			s.EXPECT().LoadUserIndex().Return(store.UserIndex{
-				&store.UserShort{
-					MattermostUserID: "user_mm_id",
-					RemoteID:         "user_remote_id",
-					Email:            "user_email@example.com",
-				},
-			}, nil).Times(1)
+			s.EXPECT().LoadSkippedStatusSyncUsers().Return(nil, nil)
+			s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(func(f func(store.UserIndex) error) error {
+				return f(store.UserIndex{
+					&store.UserShort{
+						MattermostUserID: "user_mm_id",
+						RemoteID:         "user_remote_id",
+						Email:            "user_email@example.com",
+					},
+				})
+			}).Times(1)
 			r.EXPECT().MakeSuperuserClient(context.Background()).Return(client, nil)
 
 			mockUser := &store.User{
@@ -152,6 +156,7 @@ func TestSyncStatusAll(t *testing.T) {
 			}, nil)
 
 			papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: tc.currentStatus, Manual: tc.currentStatusManual, UserId: "user_mm_id"}}, nil)
+			s.EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 
 			if tc.newStatus == "" {
 				papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", gomock.Any()).Times(0)
@@ -216,6 +221,7 @@ func TestSyncStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{busyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				s.EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 
 				s.EXPECT().StoreUser(gomock.Any()).Return(nil).Times(1)
 				s.EXPECT().StoreUserActiveEvents("user_mm_id", []string{"event_id " + moment.Format(time.RFC3339)})
@@ -237,6 +243,7 @@ func TestSyncStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{busyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				deps.Store.(*mock_store.MockStore).EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 
 				papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", gomock.Any()).Times(0)
 
@@ -258,6 +265,7 @@ func TestSyncStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{firstBusyEvent, secondBusyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				deps.Store.(*mock_store.MockStore).EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 
 				papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", gomock.Any()).Times(0)
 
@@ -280,6 +288,7 @@ func TestSyncStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{firstBusyEvent, secondBusyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				deps.Store.(*mock_store.MockStore).EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 
 				papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", gomock.Any()).Times(0)
 
@@ -302,6 +311,7 @@ func TestSyncStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{firstBusyEvent, secondBusyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				deps.Store.(*mock_store.MockStore).EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 
 				papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", gomock.Any()).Times(0)
 
@@ -316,13 +326,16 @@ func TestSyncStatusUserConfig(t *testing.T) {
 			env, client := makeStatusSyncTestEnv(ctrl)
 
 			s := env.Dependencies.Store.(*mock_store.MockStore)
-			s.EXPECT().LoadUserIndex().Return(store.UserIndex{
-				&store.UserShort{
-					MattermostUserID: "user_mm_id",
-					RemoteID:         "user_remote_id",
-					Email:            "user_email@example.com",
-				},
-			}, nil).Times(1)
+			s.EXPECT().LoadSkippedStatusSyncUsers().Return(nil, nil)
+			s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(func(f func(store.UserIndex) error) error {
+				return f(store.UserIndex{
+					&store.UserShort{
+						MattermostUserID: "user_mm_id",
+						RemoteID:         "user_remote_id",
+						Email:            "user_email@example.com",
+					},
+				})
+			}).Times(1)
 			s.EXPECT().LoadUser("user_mm_id").Return(&store.User{
 				MattermostUserID: "user_mm_id",
 				Remote: &remote.User{
@@ -367,6 +380,7 @@ func TestSyncCustomStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				s.EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 				papi.EXPECT().RemoveMattermostUserCustomStatus("user_mm_id").Return(nil)
 				s.EXPECT().StoreUserCustomStatusUpdates("user_mm_id", false).Return(nil)
 
@@ -387,6 +401,7 @@ func TestSyncCustomStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{firstBusyEvent, secondBusyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				s.EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 
 				r.EXPECT().MakeSuperuserClient(context.Background()).Return(client, nil)
 
@@ -417,6 +432,7 @@ func TestSyncCustomStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{firstBusyEvent, secondBusyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				s.EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 
 				r.EXPECT().MakeSuperuserClient(context.Background()).Return(client, nil)
 
@@ -447,6 +463,7 @@ func TestSyncCustomStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{firstBusyEvent, secondBusyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				s.EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 
 				r.EXPECT().MakeSuperuserClient(context.Background()).Return(client, nil)
 
@@ -476,6 +493,7 @@ func TestSyncCustomStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{busyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				s.EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 
 				papi.EXPECT().RemoveMattermostUserCustomStatus("user_mm_id").Return(nil)
 				s.EXPECT().StoreUserCustomStatusUpdates("user_mm_id", false).Return(nil)
@@ -496,6 +514,7 @@ func TestSyncCustomStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{busyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				s.EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 				papi.EXPECT().RemoveMattermostUserCustomStatus("user_mm_id").Return(nil)
 
 				r.EXPECT().MakeSuperuserClient(context.Background()).Return(client, nil)
@@ -516,6 +535,7 @@ func TestSyncCustomStatusUserConfig(t *testing.T) {
 					{Events: []*remote.Event{busyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				s.EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 				papi.EXPECT().GetMattermostUser("user_mm_id").Return(&model.User{
 					Id: "user_mm_id",
 				}, nil)
@@ -539,13 +559,16 @@ func TestSyncCustomStatusUserConfig(t *testing.T) {
 			env, client := makeStatusSyncTestEnv(ctrl)
 
 			s := env.Dependencies.Store.(*mock_store.MockStore)
-			s.EXPECT().LoadUserIndex().Return(store.UserIndex{
-				&store.UserShort{
-					MattermostUserID: "user_mm_id",
-					RemoteID:         "user_remote_id",
-					Email:            "user_email@example.com",
-				},
-			}, nil).Times(1)
+			s.EXPECT().LoadSkippedStatusSyncUsers().Return(nil, nil)
+			s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(func(f func(store.UserIndex) error) error {
+				return f(store.UserIndex{
+					&store.UserShort{
+						MattermostUserID: "user_mm_id",
+						RemoteID:         "user_remote_id",
+						Email:            "user_email@example.com",
+					},
+				})
+			}).Times(1)
 			s.EXPECT().LoadUser("user_mm_id").Return(&store.User{
 				MattermostUserID: "user_mm_id",
 				Remote: &remote.User{
@@ -585,6 +608,7 @@ func TestSyncCustomStatusAndUserStatusConfig(t *testing.T) {
 					{Events: []*remote.Event{busyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				s.EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 				papi.EXPECT().GetMattermostUser("user_mm_id").Return(&model.User{
 					Id: "user_mm_id",
 				}, nil)
@@ -620,6 +644,7 @@ func TestSyncCustomStatusAndUserStatusConfig(t *testing.T) {
 					{Events: []*remote.Event{busyEvent}, RemoteUserID: "user_remote_id"},
 				}, nil)
 				papi.EXPECT().GetMattermostUserStatusesByIds([]string{"user_mm_id"}).Return([]*model.Status{{Status: "online", Manual: true, UserId: "user_mm_id"}}, nil)
+				s.EXPECT().LoadFocusBlockUsers().Return(nil, nil)
 				papi.EXPECT().GetMattermostUser("user_mm_id").Return(&model.User{
 					Id: "user_mm_id",
 				}, nil)
@@ -655,13 +680,16 @@ func TestSyncCustomStatusAndUserStatusConfig(t *testing.T) {
 			env, client := makeStatusSyncTestEnv(ctrl)
 
 			s := env.Dependencies.Store.(*mock_store.MockStore)
-			s.EXPECT().LoadUserIndex().Return(store.UserIndex{
-				&store.UserShort{
-					MattermostUserID: "user_mm_id",
-					RemoteID:         "user_remote_id",
-					Email:            "user_email@example.com",
-				},
-			}, nil).Times(1)
+			s.EXPECT().LoadSkippedStatusSyncUsers().Return(nil, nil)
+			s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(func(f func(store.UserIndex) error) error {
+				return f(store.UserIndex{
+					&store.UserShort{
+						MattermostUserID: "user_mm_id",
+						RemoteID:         "user_remote_id",
+						Email:            "user_email@example.com",
+					},
+				})
+			}).Times(1)
 			s.EXPECT().LoadUser("user_mm_id").Return(&store.User{
 				MattermostUserID: "user_mm_id",
 				Remote: &remote.User{
@@ -683,11 +711,13 @@ func TestSyncCustomStatusAndUserStatusConfig(t *testing.T) {
 
 func TestReminders(t *testing.T) {
 	for name, tc := range map[string]struct {
-		apiError       *remote.APIError
-		remoteEvents   []*remote.Event
-		eventMetadata  map[string]*store.EventMetadata
-		numReminders   int
-		shouldLogError bool
+		apiError         *remote.APIError
+		remoteEvents     []*remote.Event
+		eventMetadata    map[string]*store.EventMetadata
+		leadTimes        []int
+		alreadyDelivered bool
+		numReminders     int
+		shouldLogError   bool
 	}{
 		"Most common case, no remote events. No reminder.": {
 			remoteEvents:   []*remote.Event{},
@@ -715,6 +745,45 @@ func TestReminders(t *testing.T) {
 			numReminders:   0,
 			shouldLogError: false,
 		},
+		"One remote event, in the range for the reminder, already delivered by a previous sync. No reminder.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(7*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			alreadyDelivered: true,
+			numReminders:     0,
+			shouldLogError:   false,
+		},
+		"One remote event, with a reminder missed by the previous sync. Late reminder should occur.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(5*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			numReminders:   1,
+			shouldLogError: false,
+		},
+		"One remote event, with a late reminder already delivered by a previous sync. No reminder.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(5*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			alreadyDelivered: true,
+			numReminders:     0,
+			shouldLogError:   false,
+		},
+		"One remote event, in the range for the 30 minutes reminder. Reminder should occur.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(31*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			leadTimes:      []int{1, 30},
+			numReminders:   1,
+			shouldLogError: false,
+		},
+		"One remote event, in the range for the Outlook reminder. Reminder should occur.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", IsReminderOn: true, ReminderMinutesBeforeStart: 15, Start: remote.NewDateTime(time.Now().Add(16*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			leadTimes:      []int{store.OutlookReminderLeadTime},
+			numReminders:   1,
+			shouldLogError: false,
+		},
 		"One remote event, and is in the range for the reminder. Reminder should occur.": {
 			remoteEvents: []*remote.Event{
 				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(7*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
@@ -769,13 +838,16 @@ func TestReminders(t *testing.T) {
 			deps := env.Dependencies
 
 			c, r, poster, s, logger := client.(*mock_remote.MockClient), env.Remote.(*mock_remote.MockRemote), deps.Poster.(*mock_bot.MockPoster), deps.Store.(*mock_store.MockStore), deps.Logger.(*mock_bot.MockLogger)
-			s.EXPECT().LoadUserIndex().Return(store.UserIndex{
-				&store.UserShort{
-					MattermostUserID: "user_mm_id",
-					RemoteID:         "user_remote_id",
-					Email:            "user_email@example.com",
-				},
-			}, nil).Times(1)
+			s.EXPECT().LoadSkippedStatusSyncUsers().Return(nil, nil)
+			s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(func(f func(store.UserIndex) error) error {
+				return f(store.UserIndex{
+					&store.UserShort{
+						MattermostUserID: "user_mm_id",
+						RemoteID:         "user_remote_id",
+						Email:            "user_email@example.com",
+					},
+				})
+			}).Times(1)
 			r.EXPECT().MakeSuperuserClient(context.Background()).Return(client, nil)
 
 			loadUser := s.EXPECT().LoadUser("user_mm_id").Return(&store.User{
@@ -784,14 +856,16 @@ func TestReminders(t *testing.T) {
 					ID:   "user_remote_id",
 					Mail: "user_email@example.com",
 				},
-				Settings: store.Settings{ReceiveReminders: true, UpdateStatusFromOptions: store.NotSetStatusOption},
+				Settings: store.Settings{ReceiveReminders: true, ReminderLeadTimes: tc.leadTimes, UpdateStatusFromOptions: store.NotSetStatusOption},
 			}, nil)
 			c.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).Return([]*remote.ViewCalendarResponse{
 				{Events: tc.remoteEvents, RemoteUserID: "user_remote_id", Error: tc.apiError},
 			}, nil)
+			s.EXPECT().ClaimReminder("user_mm_id", gomock.Any(), reminderClaimTTL).Return(!tc.alreadyDelivered, nil).AnyTimes()
 
 			if tc.numReminders > 0 {
 				poster.EXPECT().DMWithAttachments("user_mm_id", gomock.Any()).Times(tc.numReminders)
+				s.EXPECT().LoadFocusBlock("user_mm_id").Return(nil, nil).Times(tc.numReminders)
 				loadUser.Times(2)
 				c.EXPECT().GetMailboxSettings("user_remote_id").Times(1).Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
 
@@ -824,6 +898,184 @@ func TestReminders(t *testing.T) {
 	}
 }
 
+func TestSyncAllPages(t *testing.T) {
+	testUser := newTestUser()
+	testUser.Settings.UpdateStatusFromOptions = store.NotSetStatusOption
+	testUser.Settings.ReceiveReminders = false
+
+	forEachPage := func(pages ...store.UserIndex) func(f func(store.UserIndex) error) error {
+		return func(f func(store.UserIndex) error) error {
+			for _, page := range pages {
+				if err := f(page); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	t.Run("no users in the index", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		env, client := makeStatusSyncTestEnv(ctrl)
+		env.Remote.(*mock_remote.MockRemote).EXPECT().MakeSuperuserClient(gomock.Any()).Return(client, nil)
+		s := env.Store.(*mock_store.MockStore)
+		s.EXPECT().LoadSkippedStatusSyncUsers().Return(nil, nil)
+		s.EXPECT().ForEachUserIndexPage(gomock.Any()).Return(nil)
+
+		res, jobSummary, err := New(env, "").SyncAll()
+		require.NoError(t, err)
+		require.Equal(t, "No users found in user index", res)
+		require.Zero(t, jobSummary.NumberOfUsersProcessed)
+		require.Zero(t, jobSummary.NumberOfUsersSkipped)
+	})
+
+	t.Run("summary of all the pages", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		env, client := makeStatusSyncTestEnv(ctrl)
+		env.Remote.(*mock_remote.MockRemote).EXPECT().MakeSuperuserClient(gomock.Any()).Return(client, nil)
+
+		s := env.Store.(*mock_store.MockStore)
+		s.EXPECT().LoadSkippedStatusSyncUsers().Return(nil, nil)
+		s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(forEachPage(
+			store.UserIndex{{MattermostUserID: testUser.MattermostUserID}},
+			store.UserIndex{{MattermostUserID: "other_user_mm_id"}, {MattermostUserID: "missing_user_mm_id"}},
+		))
+		s.EXPECT().LoadUser(testUser.MattermostUserID).Return(testUser, nil)
+		s.EXPECT().LoadUser("other_user_mm_id").Return(testUser, nil)
+		s.EXPECT().LoadUser("missing_user_mm_id").Return(nil, errors.New("store error"))
+		env.Logger.(*mock_bot.MockLogger).EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+		res, jobSummary, err := New(env, "").SyncAll()
+		require.NoError(t, err)
+		require.Equal(t, errNoUsersNeedToBeSynced.Error(), res)
+		require.Equal(t, 3, jobSummary.NumberOfUsersProcessed)
+		require.Equal(t, 1, jobSummary.NumberOfUsersFailedStatusChanged)
+		require.Zero(t, jobSummary.NumberOfUsersSkipped)
+	})
+
+	t.Run("the users of the shards loaded are synced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		env, client := makeStatusSyncTestEnv(ctrl)
+		env.Remote.(*mock_remote.MockRemote).EXPECT().MakeSuperuserClient(gomock.Any()).Return(client, nil)
+
+		s := env.Store.(*mock_store.MockStore)
+		s.EXPECT().LoadSkippedStatusSyncUsers().Return(nil, nil)
+		s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(func(f func(store.UserIndex) error) error {
+			err := forEachPage(store.UserIndex{{MattermostUserID: testUser.MattermostUserID}})(f)
+			if err != nil {
+				return err
+			}
+			return store.UserIndexShardErrors{3: errors.New("shard error")}
+		})
+		s.EXPECT().LoadUser(testUser.MattermostUserID).Return(testUser, nil)
+		env.Logger.(*mock_bot.MockLogger).EXPECT().Warnf("Not able to load some users from the user index, continuing with the other ones. err=%v", gomock.Any())
+
+		res, jobSummary, err := New(env, "").SyncAll()
+		require.NoError(t, err)
+		require.Equal(t, errNoUsersNeedToBeSynced.Error(), res)
+		require.Equal(t, 1, jobSummary.NumberOfUsersProcessed)
+	})
+
+	t.Run("no shard loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		env, client := makeStatusSyncTestEnv(ctrl)
+		env.Remote.(*mock_remote.MockRemote).EXPECT().MakeSuperuserClient(gomock.Any()).Return(client, nil)
+
+		s := env.Store.(*mock_store.MockStore)
+		s.EXPECT().LoadSkippedStatusSyncUsers().Return(nil, nil)
+		s.EXPECT().ForEachUserIndexPage(gomock.Any()).Return(store.UserIndexShardErrors{3: errors.New("shard error")})
+		env.Logger.(*mock_bot.MockLogger).EXPECT().Warnf(gomock.Any(), gomock.Any())
+
+		_, _, err := New(env, "").SyncAll()
+		require.EqualError(t, err, "not able to load the users from user index: error loading user index shard 3: shard error")
+	})
+
+	t.Run("users are skipped when the budget is exhausted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		env, client := makeStatusSyncTestEnv(ctrl)
+		env.Remote.(*mock_remote.MockRemote).EXPECT().MakeSuperuserClient(gomock.Any()).Return(client, nil)
+
+		s := env.Store.(*mock_store.MockStore)
+		s.EXPECT().LoadSkippedStatusSyncUsers().Return(nil, nil)
+		s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(forEachPage(
+			store.UserIndex{{MattermostUserID: testUser.MattermostUserID}, {MattermostUserID: "other_user_mm_id"}},
+		))
+		s.EXPECT().LoadUser(gomock.Any()).Times(0)
+		s.EXPECT().StoreSkippedStatusSyncUsers([]string{testUser.MattermostUserID, "other_user_mm_id"}).Return(nil)
+		env.Logger.(*mock_bot.MockLogger).EXPECT().Warnf(gomock.Any(), 2)
+
+		res, jobSummary, err := New(env, "").(*mscalendar).syncAllUntil(time.Now().Add(-time.Minute), statusSyncWorkers)
+		require.NoError(t, err)
+		require.Equal(t, "2 users skipped, they will be synced first next time", res)
+		require.Zero(t, jobSummary.NumberOfUsersProcessed)
+		require.Equal(t, 2, jobSummary.NumberOfUsersSkipped)
+	})
+
+	t.Run("skipped users are synced first, once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		env, client := makeStatusSyncTestEnv(ctrl)
+		env.Remote.(*mock_remote.MockRemote).EXPECT().MakeSuperuserClient(gomock.Any()).Return(client, nil)
+
+		s := env.Store.(*mock_store.MockStore)
+		s.EXPECT().LoadSkippedStatusSyncUsers().Return([]string{"other_user_mm_id"}, nil)
+		s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(forEachPage(
+			store.UserIndex{{MattermostUserID: testUser.MattermostUserID}, {MattermostUserID: "other_user_mm_id"}},
+			store.UserIndex{{MattermostUserID: "third_user_mm_id"}},
+		))
+		synced := []string{}
+		s.EXPECT().LoadUser(gomock.Any()).Times(3).DoAndReturn(func(mattermostUserID string) (*store.User, error) {
+			synced = append(synced, mattermostUserID)
+			return testUser, nil
+		})
+		s.EXPECT().StoreSkippedStatusSyncUsers([]string{}).Return(nil)
+
+		// A single worker syncs the pages in the order they are dispatched
+		res, jobSummary, err := New(env, "").(*mscalendar).syncAllUntil(time.Now().Add(time.Minute), 1)
+		require.NoError(t, err)
+		require.Equal(t, errNoUsersNeedToBeSynced.Error(), res)
+		require.Equal(t, []string{"other_user_mm_id", testUser.MattermostUserID, "third_user_mm_id"}, synced)
+		require.Equal(t, 3, jobSummary.NumberOfUsersProcessed)
+		require.Zero(t, jobSummary.NumberOfUsersSkipped)
+	})
+
+	t.Run("skipped users who disconnected since are dropped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		env, client := makeStatusSyncTestEnv(ctrl)
+		env.Remote.(*mock_remote.MockRemote).EXPECT().MakeSuperuserClient(gomock.Any()).Return(client, nil)
+
+		s := env.Store.(*mock_store.MockStore)
+		s.EXPECT().LoadSkippedStatusSyncUsers().Return([]string{"disconnected_user_mm_id"}, nil)
+		s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(forEachPage(
+			store.UserIndex{{MattermostUserID: testUser.MattermostUserID}},
+		))
+		s.EXPECT().LoadUser("disconnected_user_mm_id").Return(nil, store.ErrNotFound)
+		s.EXPECT().LoadUser(testUser.MattermostUserID).Return(testUser, nil)
+		s.EXPECT().StoreSkippedStatusSyncUsers([]string{}).Return(nil)
+		env.Logger.(*mock_bot.MockLogger).EXPECT().Debugf(gomock.Any(), "disconnected_user_mm_id")
+
+		res, jobSummary, err := New(env, "").SyncAll()
+		require.NoError(t, err)
+		require.Equal(t, errNoUsersNeedToBeSynced.Error(), res)
+		require.Equal(t, 2, jobSummary.NumberOfUsersProcessed)
+		require.Zero(t, jobSummary.NumberOfUsersFailedStatusChanged)
+		require.Zero(t, jobSummary.NumberOfUsersSkipped)
+	})
+}
+
 func TestRetrieveUsersToSyncIndividually(t *testing.T) {
 	t.Run("no users to sync", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -1183,3 +1435,78 @@ func TestGetMergedEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestDueReminderLeadTimes(t *testing.T) {
+	now := time.Date(2024, time.May, 16, 10, 0, 0, 0, time.UTC)
+	eventAt := func(d time.Duration) *remote.Event {
+		return &remote.Event{Start: remote.NewDateTime(now.Add(d), "UTC"), IsReminderOn: true, ReminderMinutesBeforeStart: 240}
+	}
+
+	for name, tc := range map[string]struct {
+		leadTimes []int
+		event     *remote.Event
+		expected  []time.Duration
+	}{
+		"not due yet": {
+			leadTimes: []int{10},
+			event:     eventAt(13 * time.Minute),
+		},
+		"due in the tolerance before": {
+			leadTimes: []int{10},
+			event:     eventAt(12 * time.Minute),
+			expected:  []time.Duration{10 * time.Minute},
+		},
+		"late in the tolerance after": {
+			leadTimes: []int{10},
+			event:     eventAt(5 * time.Minute),
+			expected:  []time.Duration{10 * time.Minute},
+		},
+		"too late": {
+			leadTimes: []int{10},
+			event:     eventAt(4 * time.Minute),
+		},
+		"several reminders due": {
+			leadTimes: []int{1, 5, 30},
+			event:     eventAt(3 * time.Minute),
+			expected:  []time.Duration{time.Minute, 5 * time.Minute},
+		},
+		"outlook reminder capped": {
+			leadTimes: []int{store.OutlookReminderLeadTime},
+			event:     eventAt(2 * time.Hour),
+			expected:  []time.Duration{maxReminderLeadTime},
+		},
+		"outlook reminder off": {
+			leadTimes: []int{store.OutlookReminderLeadTime},
+			event:     &remote.Event{Start: remote.NewDateTime(now.Add(time.Minute), "UTC")},
+		},
+		"event started": {
+			leadTimes: []int{1},
+			event:     eventAt(0),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			due := dueReminderLeadTimes(tc.leadTimes, tc.event, now)
+			if len(tc.expected) == 0 {
+				require.Empty(t, due)
+				return
+			}
+			require.Equal(t, tc.expected, due)
+		})
+	}
+}
+
+func TestCalendarViewTimeWindow(t *testing.T) {
+	require.Equal(t, calendarViewTimeWindowSize, calendarViewTimeWindow([]*store.User{
+		{Settings: store.Settings{ReminderLeadTimes: []int{30}}},
+	}))
+	require.Equal(t, store.DefaultReminderLeadTime*time.Minute+reminderEarlyTolerance, calendarViewTimeWindow([]*store.User{
+		{Settings: store.Settings{ReceiveReminders: true}},
+		{Settings: store.Settings{ReminderLeadTimes: []int{30}}},
+	}))
+	require.Equal(t, 30*time.Minute+reminderEarlyTolerance, calendarViewTimeWindow([]*store.User{
+		{Settings: store.Settings{ReceiveReminders: true, ReminderLeadTimes: []int{1, 30}}},
+	}))
+	require.Equal(t, maxReminderLeadTime+reminderEarlyTolerance, calendarViewTimeWindow([]*store.User{
+		{Settings: store.Settings{ReceiveReminders: true, ReminderLeadTimes: []int{store.OutlookReminderLeadTime}}},
+	}))
+}
@@ -4,17 +4,29 @@
 package engine
 
 import (
+	"sort"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
 )
 
 type Calendar interface {
 	CreateCalendar(user *User, calendar *remote.Calendar) (*remote.Calendar, error)
 	CreateEvent(user *User, event *remote.Event, mattermostUserIDs []string) (*remote.Event, error)
+	CreateEventFromPayload(user *User, payload CreateEventPayload) (*remote.Event, error)
+	UpdateEvent(user *User, eventID string, payload UpdateEventPayload) (*remote.Event, error)
+	CancelEvent(user *User, eventID, comment string) error
+	DeleteEvent(user *User, eventID string) error
 	DeleteCalendar(user *User, calendarID string) error
+	ExportCalendar(user *User, from, to time.Time) (string, error)
 	FindMeetingTimes(user *User, meetingParams *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error)
+	FindTime(user *User, payload FindTimePayload) (*remote.MeetingTimeSuggestionResults, error)
 	GetCalendars(user *User) ([]*remote.Calendar, error)
+	SendCalendarExport(user *User, from, to time.Time) error
 	ViewCalendar(user *User, from, to time.Time) ([]*remote.Event, error)
 }
 
@@ -26,10 +38,15 @@ func (m *mscalendar) ViewCalendar(user *User, from, to time.Time) ([]*remote.Eve
 	if err != nil {
 		return nil, err
 	}
-	return m.client.GetDefaultCalendarView(user.Remote.ID, from, to)
+	return m.getSelectedCalendarsView(user.User, from, to)
 }
 
 func (m *mscalendar) getTodayCalendarEvents(user *User, now time.Time, timezone string) ([]*remote.Event, error) {
+	from, to := getTodayHoursForTimezone(now, timezone)
+	return m.getCalendarEventsBetween(user, from, to)
+}
+
+func (m *mscalendar) getCalendarEventsBetween(user *User, from, to time.Time) ([]*remote.Event, error) {
 	err := m.Filter(
 		withClient,
 	)
@@ -42,8 +59,104 @@ func (m *mscalendar) getTodayCalendarEvents(user *User, now time.Time, timezone
 		return nil, err
 	}
 
-	from, to := getTodayHoursForTimezone(now, timezone)
-	return m.client.GetDefaultCalendarView(user.Remote.ID, from, to)
+	return m.getSelectedCalendarsView(user.User, from, to)
+}
+
+// getSelectedCalendarsView returns the events of the calendars selected by the user, or of the default
+// calendar if the user did not select any.
+func (m *mscalendar) getSelectedCalendarsView(user *store.User, from, to time.Time) ([]*remote.Event, error) {
+	if len(user.Settings.CalendarIDs) == 0 {
+		return m.client.GetDefaultCalendarView(user.Remote.ID, from, to)
+	}
+
+	calendarViews, err := m.client.DoBatchViewCalendarRequests(calendarViewParams(user, from, to))
+	if err != nil {
+		return nil, err
+	}
+
+	merged := m.mergeCalendarViews(calendarViews)
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	if merged[0].Error != nil {
+		return nil, errors.Errorf("error getting calendar view: %s", merged[0].Error.Message)
+	}
+
+	return merged[0].Events, nil
+}
+
+// calendarViewParams returns the parameters to fetch the view of each calendar selected by the user.
+func calendarViewParams(user *store.User, from, to time.Time) []*remote.ViewCalendarParams {
+	if len(user.Settings.CalendarIDs) == 0 {
+		return []*remote.ViewCalendarParams{{
+			RemoteUserID: user.Remote.ID,
+			StartTime:    from,
+			EndTime:      to,
+		}}
+	}
+
+	params := []*remote.ViewCalendarParams{}
+	for _, calendarID := range user.Settings.CalendarIDs {
+		params = append(params, &remote.ViewCalendarParams{
+			RemoteUserID: user.Remote.ID,
+			CalendarID:   calendarID,
+			StartTime:    from,
+			EndTime:      to,
+		})
+	}
+
+	return params
+}
+
+// mergeCalendarViews merges the views of the calendars of each user into a single view per user, sorted by
+// start time. A user view only has an error if none of the calendars of the user could be fetched.
+func (m *mscalendar) mergeCalendarViews(calendarViews []*remote.ViewCalendarResponse) []*remote.ViewCalendarResponse {
+	merged := []*remote.ViewCalendarResponse{}
+	byRemoteID := map[string]*remote.ViewCalendarResponse{}
+	seenEvents := map[string]bool{}
+	for _, view := range calendarViews {
+		userView, ok := byRemoteID[view.RemoteUserID]
+		if !ok {
+			userView = &remote.ViewCalendarResponse{
+				RemoteUserID: view.RemoteUserID,
+				Error:        view.Error,
+			}
+			byRemoteID[view.RemoteUserID] = userView
+			merged = append(merged, userView)
+		}
+
+		if view.Error != nil {
+			if view.CalendarID == "" {
+				// The error of the default calendar view is reported by the caller
+				continue
+			}
+			m.Logger.With(bot.LogContext{
+				"remote_id":   view.RemoteUserID,
+				"calendar_id": view.CalendarID,
+				"err":         view.Error.Message,
+			}).Warnf("Error getting calendar view.")
+			continue
+		}
+
+		userView.Error = nil
+		for _, event := range view.Events {
+			key := view.RemoteUserID + "/" + event.ID
+			if event.ID != "" && seenEvents[key] {
+				continue
+			}
+			seenEvents[key] = true
+			userView.Events = append(userView.Events, event)
+		}
+	}
+
+	for _, userView := range merged {
+		events := userView.Events
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Start.Time().Before(events[j].Start.Time())
+		})
+	}
+
+	return merged
 }
 
 func (m *mscalendar) excludeDeclinedEvents(events []*remote.Event) (result []*remote.Event) {
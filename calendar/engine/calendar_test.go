@@ -0,0 +1,86 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func TestCalendarViewParams(t *testing.T) {
+	from := time.Date(2024, time.May, 15, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	user := &store.User{Remote: &remote.User{ID: "remote_id"}}
+
+	require.Equal(t, []*remote.ViewCalendarParams{
+		{RemoteUserID: "remote_id", StartTime: from, EndTime: to},
+	}, calendarViewParams(user, from, to))
+
+	user.Settings.CalendarIDs = []string{"work", "team"}
+	require.Equal(t, []*remote.ViewCalendarParams{
+		{RemoteUserID: "remote_id", CalendarID: "work", StartTime: from, EndTime: to},
+		{RemoteUserID: "remote_id", CalendarID: "team", StartTime: from, EndTime: to},
+	}, calendarViewParams(user, from, to))
+}
+
+func TestMergeCalendarViews(t *testing.T) {
+	m := &mscalendar{
+		Env: Env{
+			Dependencies: &Dependencies{
+				Logger: &bot.NilLogger{},
+			},
+		},
+	}
+	event := func(id string, hour int) *remote.Event {
+		return &remote.Event{
+			ID:    id,
+			Start: remote.NewDateTime(time.Date(2024, time.May, 15, hour, 0, 0, 0, time.UTC), "UTC"),
+		}
+	}
+	apiError := &remote.APIError{Message: "Forbidden"}
+
+	for name, tc := range map[string]struct {
+		views    []*remote.ViewCalendarResponse
+		expected []*remote.ViewCalendarResponse
+	}{
+		"events of several calendars are merged and sorted": {
+			views: []*remote.ViewCalendarResponse{
+				{RemoteUserID: "user1", CalendarID: "work", Events: []*remote.Event{event("1", 9), event("2", 14)}},
+				{RemoteUserID: "user2", CalendarID: "work", Events: []*remote.Event{event("3", 10)}},
+				{RemoteUserID: "user1", CalendarID: "team", Events: []*remote.Event{event("4", 11), event("1", 9)}},
+			},
+			expected: []*remote.ViewCalendarResponse{
+				{RemoteUserID: "user1", Events: []*remote.Event{event("1", 9), event("4", 11), event("2", 14)}},
+				{RemoteUserID: "user2", Events: []*remote.Event{event("3", 10)}},
+			},
+		},
+		"a failing calendar is skipped": {
+			views: []*remote.ViewCalendarResponse{
+				{RemoteUserID: "user1", CalendarID: "work", Error: apiError},
+				{RemoteUserID: "user1", CalendarID: "team", Events: []*remote.Event{event("1", 9)}},
+			},
+			expected: []*remote.ViewCalendarResponse{
+				{RemoteUserID: "user1", Events: []*remote.Event{event("1", 9)}},
+			},
+		},
+		"the error is kept when all the calendars fail": {
+			views: []*remote.ViewCalendarResponse{
+				{RemoteUserID: "user1", Error: apiError},
+			},
+			expected: []*remote.ViewCalendarResponse{
+				{RemoteUserID: "user1", Error: apiError},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, m.mergeCalendarViews(tc.views))
+		})
+	}
+}
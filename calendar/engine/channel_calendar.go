@@ -0,0 +1,481 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const (
+	// ChannelAgendaJobInterval is the interval of the job posting the daily agendas of the channels
+	ChannelAgendaJobInterval = DailySummaryJobInterval
+	DefaultChannelAgendaTime = "8:00AM"
+)
+
+var (
+	// ErrChannelAlreadySubscribed is returned when subscribing a channel to a second calendar.
+	ErrChannelAlreadySubscribed = errors.New("this channel is already subscribed to a calendar")
+	// ErrChannelNotSubscribed is returned when changing the calendar of a channel without one.
+	ErrChannelNotSubscribed = errors.New("this channel is not subscribed to a calendar")
+	// ErrChannelCalendarPermission is returned when the user can't post in the channel.
+	ErrChannelCalendarPermission = errors.New("you don't have permission to post in this channel")
+	// ErrInvalidCalendarAddress is returned when the address of the calendar is not an email address.
+	ErrInvalidCalendarAddress = errors.New("invalid calendar address")
+	// ErrInvalidAgendaTime is returned when the agenda time is not a valid post time.
+	ErrInvalidAgendaTime = errors.New("invalid agenda time")
+)
+
+// ChannelCalendars posts the new, changed and cancelled events of shared calendars and group mailboxes in channels,
+// and their daily agenda.
+type ChannelCalendars interface {
+	SubscribeChannelCalendar(user *User, channelID, calendarAddress string) (*store.ChannelCalendar, error)
+	UnsubscribeChannelCalendar(user *User, channelID string) (*store.ChannelCalendar, error)
+	// GetChannelCalendar returns the calendar of the channel, or nil if there is none.
+	GetChannelCalendar(channelID string) (*store.ChannelCalendar, error)
+	// SetChannelAgendaTime sets the time of the daily agenda of the channel, in the timezone of the user. An empty
+	// time turns the agenda off.
+	SetChannelAgendaTime(user *User, channelID, timeStr string) (*store.ChannelCalendar, error)
+	RenewChannelCalendarSubscriptions() error
+	ProcessAllChannelAgendas(now time.Time) error
+}
+
+func (m *mscalendar) SubscribeChannelCalendar(user *User, channelID, calendarAddress string) (*store.ChannelCalendar, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID) {
+		return nil, ErrChannelCalendarPermission
+	}
+	if _, err = mail.ParseAddress(calendarAddress); err != nil {
+		return nil, fmt.Errorf("%w %q: it must be the email address of a shared calendar or group mailbox", ErrInvalidCalendarAddress, calendarAddress)
+	}
+
+	_, err = m.Store.LoadChannelCalendar(channelID)
+	if err == nil {
+		return nil, ErrChannelAlreadySubscribed
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return nil, errors.Wrap(err, "error loading channel calendar")
+	}
+
+	// Reading the calendar checks that the user has access to it
+	now := time.Now()
+	_, err = m.client.GetSharedCalendarView(calendarAddress, now, now.Add(24*time.Hour))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the calendar %s", calendarAddress)
+	}
+
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := m.client.CreateSharedCalendarSubscription(m.Config.GetNotificationURL(), calendarAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "error subscribing to the calendar")
+	}
+
+	channelCalendar := &store.ChannelCalendar{
+		ChannelID:           channelID,
+		CalendarAddress:     calendarAddress,
+		MattermostCreatorID: user.MattermostUserID,
+		AgendaTime:          DefaultChannelAgendaTime,
+		Timezone:            timezone,
+	}
+	err = m.Store.StoreChannelSubscription(channelCalendar, &store.Subscription{
+		Remote:              sub,
+		MattermostCreatorID: user.MattermostUserID,
+		PluginVersion:       m.Config.PluginVersion,
+		ChannelID:           channelID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.postInChannel(channelID, fmt.Sprintf("@%s subscribed this channel to the calendar **%s**. New, changed and cancelled events are posted here, %s.",
+		user.MattermostUsername, calendarAddress, agendaDescription(channelCalendar)))
+
+	return channelCalendar, nil
+}
+
+func (m *mscalendar) UnsubscribeChannelCalendar(user *User, channelID string) (*store.ChannelCalendar, error) {
+	err := m.Filter(withUserExpanded(user))
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID) {
+		return nil, ErrChannelCalendarPermission
+	}
+
+	channelCalendar, err := m.GetChannelCalendar(channelID)
+	if err != nil || channelCalendar == nil {
+		return nil, err
+	}
+
+	sub, err := m.Store.LoadSubscription(channelCalendar.SubscriptionID)
+	if err == nil {
+		// The subscription was created with the credentials of the user who subscribed the channel
+		var creatorEngine *mscalendar
+		creatorEngine, err = m.FilterCopy(withActingUser(channelCalendar.MattermostCreatorID), withClient)
+		if err == nil {
+			err = creatorEngine.client.DeleteSubscription(sub.Remote)
+		}
+		if err != nil {
+			m.Logger.With(bot.LogContext{
+				"channelID":      channelID,
+				"subscriptionID": channelCalendar.SubscriptionID,
+				"err":            err.Error(),
+			}).Warnf("Failed to delete channel subscription.")
+		}
+
+		err = m.Store.DeleteUserSubscription(nil, channelCalendar.SubscriptionID)
+		if err != nil {
+			return nil, errors.Wrap(err, "error deleting channel subscription")
+		}
+	}
+
+	err = m.Store.DeleteChannelCalendar(channelID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error deleting channel calendar")
+	}
+
+	m.postInChannel(channelID, fmt.Sprintf("@%s unsubscribed this channel from the calendar **%s**.", user.MattermostUsername, channelCalendar.CalendarAddress))
+
+	return channelCalendar, nil
+}
+
+func (m *mscalendar) GetChannelCalendar(channelID string) (*store.ChannelCalendar, error) {
+	channelCalendar, err := m.Store.LoadChannelCalendar(channelID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading channel calendar")
+	}
+	return channelCalendar, nil
+}
+
+func (m *mscalendar) SetChannelAgendaTime(user *User, channelID, timeStr string) (*store.ChannelCalendar, error) {
+	if !m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID) {
+		return nil, ErrChannelCalendarPermission
+	}
+
+	channelCalendar, err := m.GetChannelCalendar(channelID)
+	if err != nil {
+		return nil, err
+	}
+	if channelCalendar == nil {
+		return nil, ErrChannelNotSubscribed
+	}
+
+	if timeStr != "" {
+		err = validatePostTime(timeStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidAgendaTime, err.Error())
+		}
+
+		timezone, err := m.GetTimezone(user)
+		if err != nil {
+			return nil, err
+		}
+		channelCalendar.Timezone = timezone
+	}
+	channelCalendar.AgendaTime = timeStr
+
+	err = m.Store.StoreChannelCalendar(channelCalendar)
+	if err != nil {
+		return nil, err
+	}
+	return channelCalendar, nil
+}
+
+// RenewChannelCalendarSubscriptions renews the subscriptions of the channels, with the credentials of the users
+// who subscribed them. Expired subscriptions are created again.
+func (m *mscalendar) RenewChannelCalendarSubscriptions() error {
+	channelIDs, err := m.Store.LoadChannelCalendarIndex()
+	if err != nil {
+		return err
+	}
+
+	for _, channelID := range channelIDs {
+		err = m.renewChannelCalendarSubscription(channelID)
+		if err != nil {
+			m.Logger.With(bot.LogContext{
+				"channelID": channelID,
+				"err":       err.Error(),
+			}).Errorf("Error renewing channel subscription.")
+		}
+	}
+	return nil
+}
+
+func (m *mscalendar) renewChannelCalendarSubscription(channelID string) error {
+	channelCalendar, err := m.Store.LoadChannelCalendar(channelID)
+	if err != nil {
+		return errors.Wrap(err, "error loading channel calendar")
+	}
+
+	creatorEngine, err := m.FilterCopy(withActingUser(channelCalendar.MattermostCreatorID), withClient)
+	if err != nil {
+		return err
+	}
+
+	sub, err := m.Store.LoadSubscription(channelCalendar.SubscriptionID)
+	if err != nil {
+		return errors.Wrap(err, "error loading subscription")
+	}
+
+	renewed, err := creatorEngine.client.RenewSubscription(m.Config.GetNotificationURL(), sub.Remote.CreatorID, sub.Remote)
+	if err != nil {
+		if !strings.Contains(err.Error(), "The object was not found") {
+			return err
+		}
+
+		m.Logger.Infof("Subscription %s for channel %s has expired. Creating a new subscription now.", channelCalendar.SubscriptionID, channelID)
+		err = m.Store.DeleteUserSubscription(nil, channelCalendar.SubscriptionID)
+		if err != nil {
+			return err
+		}
+		renewed, err = creatorEngine.client.CreateSharedCalendarSubscription(m.Config.GetNotificationURL(), channelCalendar.CalendarAddress)
+		if err != nil {
+			return err
+		}
+	}
+
+	sub.Remote = renewed
+	sub.PluginVersion = m.Config.PluginVersion
+	return m.Store.StoreChannelSubscription(channelCalendar, sub)
+}
+
+// ProcessAllChannelAgendas posts the agenda of the day in the channels whose agenda time is now. Days without
+// events are skipped.
+func (m *mscalendar) ProcessAllChannelAgendas(now time.Time) error {
+	channelIDs, err := m.Store.LoadChannelCalendarIndex()
+	if err != nil {
+		return err
+	}
+
+	for _, channelID := range channelIDs {
+		err = m.processChannelAgenda(channelID, now)
+		if err != nil {
+			m.Logger.With(bot.LogContext{
+				"channelID": channelID,
+				"err":       err.Error(),
+			}).Warnf("Error posting channel agenda.")
+		}
+	}
+	return nil
+}
+
+func (m *mscalendar) processChannelAgenda(channelID string, now time.Time) error {
+	channelCalendar, err := m.Store.LoadChannelCalendar(channelID)
+	if err != nil {
+		return errors.Wrap(err, "error loading channel calendar")
+	}
+	if channelCalendar.AgendaTime == "" {
+		return nil
+	}
+
+	shouldPost, err := isScheduledPostTime(channelCalendar.AgendaTime, channelCalendar.Timezone, channelCalendar.LastAgendaPostTime, now, nil)
+	if err != nil || !shouldPost {
+		return err
+	}
+
+	creatorEngine, err := m.FilterCopy(withActingUser(channelCalendar.MattermostCreatorID), withClient)
+	if err != nil {
+		return err
+	}
+
+	start, end := getTodayHoursForTimezone(now, channelCalendar.Timezone)
+	events, err := creatorEngine.client.GetSharedCalendarView(channelCalendar.CalendarAddress, start, end)
+	if err != nil {
+		return errors.Wrap(err, "error getting calendar events")
+	}
+
+	events = scheduledEvents(events)
+	for i, e := range events {
+		events[i] = maskPrivateEvent(e)
+	}
+	if len(events) > 0 {
+		view, renderErr := views.RenderCalendarView(events, channelCalendar.Timezone)
+		if renderErr != nil {
+			return renderErr
+		}
+		m.postInChannel(channelID, fmt.Sprintf("#### Agenda of %s\n%s", channelCalendar.CalendarAddress, view))
+	}
+
+	channelCalendar.LastAgendaPostTime = now.Format(time.RFC3339)
+	return m.Store.StoreChannelCalendar(channelCalendar)
+}
+
+func (m *mscalendar) postInChannel(channelID, message string) {
+	err := m.Poster.CreatePost(&model.Post{
+		ChannelId: channelID,
+		Message:   message,
+	})
+	if err != nil {
+		m.Logger.With(bot.LogContext{"channelID": channelID, "err": err.Error()}).Warnf("Failed to post in channel.")
+	}
+}
+
+// processChannelNotification posts the new, changed and cancelled events of the calendar of a channel.
+func (processor *notificationProcessor) processChannelNotification(n *remote.Notification, sub *store.Subscription) error {
+	channelCalendar, err := processor.Store.LoadChannelCalendar(sub.ChannelID)
+	if err != nil {
+		return err
+	}
+	if sub.Remote.ID != channelCalendar.SubscriptionID {
+		return errors.New("subscription is orphaned")
+	}
+	if sub.Remote.ClientState != "" && sub.Remote.ClientState != n.ClientState {
+		return errors.New("unauthorized webhook")
+	}
+
+	creator, err := processor.Store.LoadUser(channelCalendar.MattermostCreatorID)
+	if err != nil {
+		return err
+	}
+
+	n.Subscription = sub.Remote
+	n.SubscriptionCreator = creator.Remote
+
+	client := processor.Remote.MakeClient(context.Background(), creator.OAuth2Token)
+
+	if n.RecommendRenew {
+		var renewed *remote.Subscription
+		renewed, err = client.RenewSubscription(processor.Config.GetNotificationURL(), sub.Remote.CreatorID, n.Subscription)
+		if err != nil {
+			return err
+		}
+
+		sub.Remote = renewed
+		err = processor.Store.StoreChannelSubscription(channelCalendar, sub)
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.IsBare {
+		n, err = client.GetNotificationData(n)
+		if err != nil {
+			return err
+		}
+	}
+
+	prior, err := processor.Store.LoadChannelEvent(channelCalendar.ChannelID, n.Event.ID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	timezone := channelCalendar.Timezone
+	var sa *model.SlackAttachment
+	switch {
+	case n.ChangeType == remote.ChangeTypeDeleted || n.Event.IsCancelled:
+		if prior == nil {
+			// The event was never posted in the channel
+			return nil
+		}
+		sa = processor.newSlackAttachment(&remote.Notification{Event: channelEvent(prior.Remote)})
+		sa.Title = "(cancelled) " + sa.Title
+		err = processor.Store.DeleteChannelEvent(channelCalendar.ChannelID, n.Event.ID)
+		if err != nil {
+			return err
+		}
+
+	case prior == nil:
+		n.Event = channelEvent(n.Event)
+		sa = processor.newEventSlackAttachment(n, timezone)
+		prior = &store.Event{}
+
+	default:
+		n.Event = channelEvent(n.Event)
+		var changed bool
+		changed, sa = processor.updatedEventSlackAttachment(n, channelEvent(prior.Remote), timezone)
+		if !changed {
+			return nil
+		}
+	}
+
+	// The actions of the event are for the attendees, not for the channel
+	sa.Actions = nil
+	post := &model.Post{ChannelId: channelCalendar.ChannelID}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{sa})
+	err = processor.Poster.CreatePost(post)
+	if err != nil {
+		return err
+	}
+
+	if n.ChangeType == remote.ChangeTypeDeleted || n.Event.IsCancelled {
+		return nil
+	}
+	prior.Remote = n.Event
+	prior.PluginVersion = processor.Config.PluginVersion
+	return processor.Store.StoreChannelEvent(channelCalendar.ChannelID, prior)
+}
+
+// channelEvent returns a copy of the event with the fields needed by the notification attachments, as the
+// events of shared calendars and group mailboxes may miss some of them. The responses to the event are for
+// the attendees, so the event of the channel never requests one. Private events are masked.
+func channelEvent(event *remote.Event) *remote.Event {
+	e := *maskPrivateEvent(event)
+	if e.Organizer == nil || e.Organizer.EmailAddress == nil {
+		e.Organizer = &remote.Attendee{EmailAddress: &remote.EmailAddress{}}
+	}
+	if e.Location == nil {
+		e.Location = &remote.Location{}
+	}
+	if e.ResponseStatus == nil {
+		e.ResponseStatus = &remote.EventResponseStatus{}
+	}
+	e.ResponseRequested = false
+	return &e
+}
+
+// maskPrivateEvent returns a copy of a private or confidential event keeping only its time, so that the members
+// of the channel don't see its details.
+func maskPrivateEvent(event *remote.Event) *remote.Event {
+	if !isPrivateEvent(event) {
+		return event
+	}
+	return &remote.Event{
+		ID:             event.ID,
+		ICalUID:        event.ICalUID,
+		Weblink:        event.Weblink,
+		Subject:        privateEventSubject,
+		Start:          event.Start,
+		End:            event.End,
+		IsAllDay:       event.IsAllDay,
+		IsCancelled:    event.IsCancelled,
+		ShowAs:         event.ShowAs,
+		Sensitivity:    event.Sensitivity,
+		Type:           event.Type,
+		SeriesMasterID: event.SeriesMasterID,
+		Recurrence:     event.Recurrence,
+	}
+}
+
+func agendaDescription(channelCalendar *store.ChannelCalendar) string {
+	if channelCalendar.AgendaTime == "" {
+		return "without a daily agenda"
+	}
+	return fmt.Sprintf("with the agenda of the day at %s %s", channelCalendar.AgendaTime, channelCalendar.Timezone)
+}
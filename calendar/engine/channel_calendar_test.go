@@ -0,0 +1,246 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot/mock_bot"
+)
+
+func TestProcessChannelNotification(t *testing.T) {
+	for name, tc := range map[string]struct {
+		changeType    string
+		event         *remote.Event
+		prior         *remote.Event
+		expectedTitle string
+		expectStore   bool
+		expectDelete  bool
+		// hidden are the details of the event that must not be posted in the channel
+		hidden []string
+	}{
+		"new event": {
+			changeType:    remote.ChangeTypeCreated,
+			event:         newTestEvent("1", "Room 1", "Planning"),
+			expectedTitle: "(new) Planning",
+			expectStore:   true,
+		},
+		"new event without organizer": {
+			changeType:    remote.ChangeTypeCreated,
+			event:         &remote.Event{ID: "remote_event_id_1", Subject: "Planning"},
+			expectedTitle: "(new) Planning",
+			expectStore:   true,
+		},
+		"new private event": {
+			changeType:    remote.ChangeTypeCreated,
+			event:         newTestPrivateEvent("1", "Room 1", "Salary review"),
+			expectedTitle: "(new) Private event",
+			expectStore:   true,
+			hidden:        []string{"Salary review", "Room 1", "event_body_preview"},
+		},
+		"changed event": {
+			changeType:    remote.ChangeTypeUpdated,
+			event:         newTestEvent("1", "Room 1", "Planning review"),
+			prior:         newTestEvent("1", "Room 1", "Planning"),
+			expectedTitle: "(updated) Planning review",
+			expectStore:   true,
+		},
+		"changed private event": {
+			changeType:    remote.ChangeTypeUpdated,
+			event:         newTestPrivateEvent("1", "Room 2", "Salary review"),
+			prior:         newTestEvent("1", "Room 1", "Planning"),
+			expectedTitle: "(updated) Private event",
+			expectStore:   true,
+			hidden:        []string{"Salary review", "Room 2", "event_body_preview"},
+		},
+		"unimportant change": {
+			changeType: remote.ChangeTypeUpdated,
+			event:      newTestEvent("1", "Room 2", "Planning"),
+			prior:      newTestEvent("1", "Room 1", "Planning"),
+		},
+		"cancelled event": {
+			changeType:    remote.ChangeTypeUpdated,
+			event:         &remote.Event{ID: "remote_event_id_1", Subject: "Canceled: Planning", IsCancelled: true},
+			prior:         newTestEvent("1", "Room 1", "Planning"),
+			expectedTitle: "(cancelled) Planning",
+			expectDelete:  true,
+		},
+		"deleted event": {
+			changeType:    remote.ChangeTypeDeleted,
+			event:         &remote.Event{ID: "remote_event_id_1"},
+			prior:         newTestEvent("1", "Room 1", "Planning"),
+			expectedTitle: "(cancelled) Planning",
+			expectDelete:  true,
+		},
+		"deleted event never posted": {
+			changeType: remote.ChangeTypeDeleted,
+			event:      &remote.Event{ID: "remote_event_id_1"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mock_store.NewMockStore(ctrl)
+			mockPoster := mock_bot.NewMockPoster(ctrl)
+			mockRemote := mock_remote.NewMockRemote(ctrl)
+			mockClient := mock_remote.NewMockClient(ctrl)
+			env := Env{
+				Config: &config.Config{PluginVersion: "x.x.x"},
+				Dependencies: &Dependencies{
+					Store:  mockStore,
+					Logger: &bot.NilLogger{},
+					Poster: mockPoster,
+					Remote: mockRemote,
+				},
+			}
+
+			sub := newTestSubscription()
+			sub.ChannelID = "channel_id"
+			user := newTestUser()
+			n := &remote.Notification{
+				SubscriptionID: "remote_subscription_id",
+				ChangeType:     tc.changeType,
+				ClientState:    "stored_client_state",
+				Event:          tc.event,
+			}
+
+			mockStore.EXPECT().LoadSubscription("remote_subscription_id").Return(sub, nil)
+			mockStore.EXPECT().LoadChannelCalendar("channel_id").Return(&store.ChannelCalendar{
+				ChannelID:           "channel_id",
+				CalendarAddress:     "team@example.com",
+				MattermostCreatorID: "creator_mm_id",
+				SubscriptionID:      "remote_subscription_id",
+				Timezone:            "Eastern Standard Time",
+			}, nil)
+			mockStore.EXPECT().LoadUser("creator_mm_id").Return(user, nil)
+			mockRemote.EXPECT().MakeClient(context.Background(), user.OAuth2Token).Return(mockClient)
+
+			if tc.prior != nil {
+				mockStore.EXPECT().LoadChannelEvent("channel_id", "remote_event_id_1").Return(&store.Event{Remote: tc.prior}, nil)
+			} else {
+				mockStore.EXPECT().LoadChannelEvent("channel_id", "remote_event_id_1").Return(nil, store.ErrNotFound)
+			}
+
+			if tc.expectedTitle != "" {
+				mockPoster.EXPECT().CreatePost(gomock.Any()).DoAndReturn(func(post *model.Post) error {
+					require.Equal(t, "channel_id", post.ChannelId)
+					attachments := post.Attachments()
+					require.Len(t, attachments, 1)
+					require.Equal(t, tc.expectedTitle, attachments[0].Title)
+					require.Empty(t, attachments[0].Actions)
+					for _, hidden := range tc.hidden {
+						require.NotContains(t, attachments[0].Title, hidden)
+						require.NotContains(t, attachments[0].Text, hidden)
+						for _, field := range attachments[0].Fields {
+							require.NotContains(t, field.Value, hidden)
+						}
+					}
+					return nil
+				})
+			}
+			if tc.expectStore {
+				mockStore.EXPECT().StoreChannelEvent("channel_id", gomock.Any()).DoAndReturn(func(_ string, event *store.Event) error {
+					require.Equal(t, "remote_event_id_1", event.Remote.ID)
+					return nil
+				})
+			}
+			if tc.expectDelete {
+				mockStore.EXPECT().DeleteChannelEvent("channel_id", "remote_event_id_1").Return(nil)
+			}
+
+			processor := newTestNotificationProcessor(env).(*notificationProcessor)
+			err := processor.processNotification(n)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestChannelEvent(t *testing.T) {
+	event := newTestEvent("1", "Room 1", "Planning")
+	require.Same(t, event.Organizer, channelEvent(event).Organizer)
+	require.False(t, channelEvent(event).ResponseRequested)
+	require.True(t, event.ResponseRequested)
+
+	bare := channelEvent(&remote.Event{Subject: "Planning"})
+	require.NotNil(t, bare.Organizer.EmailAddress)
+	require.NotNil(t, bare.Location)
+	require.NotNil(t, bare.ResponseStatus)
+
+	private := channelEvent(newTestPrivateEvent("1", "Room 1", "Salary review"))
+	require.Equal(t, "remote_event_id_1", private.ID)
+	require.Equal(t, "Private event", private.Subject)
+	require.Empty(t, private.BodyPreview)
+	require.Empty(t, private.Location.DisplayName)
+	require.Empty(t, private.Organizer.EmailAddress.Address)
+}
+
+func TestProcessAllChannelAgendas(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	now := time.Date(2024, time.May, 16, 8, 0, 0, 0, loc)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	env, client := makeStatusSyncTestEnv(ctrl)
+	mockClient := client.(*mock_remote.MockClient)
+	mockRemote := env.Dependencies.Remote.(*mock_remote.MockRemote)
+	s := env.Dependencies.Store.(*mock_store.MockStore)
+	papi := env.Dependencies.PluginAPI.(*mock_plugin_api.MockPluginAPI)
+	poster := env.Dependencies.Poster.(*mock_bot.MockPoster)
+	m := New(env, "")
+
+	planning := newTestEvent("1", "Room 1", "Planning")
+	planning.Start = remote.NewDateTime(now.Add(time.Hour), "Eastern Standard Time")
+	planning.End = remote.NewDateTime(now.Add(2*time.Hour), "Eastern Standard Time")
+	private := newTestPrivateEvent("2", "Room 2", "Salary review")
+	private.Start = remote.NewDateTime(now.Add(3*time.Hour), "Eastern Standard Time")
+	private.End = remote.NewDateTime(now.Add(4*time.Hour), "Eastern Standard Time")
+
+	s.EXPECT().LoadChannelCalendarIndex().Return([]string{"channel_id"}, nil)
+	s.EXPECT().LoadChannelCalendar("channel_id").Return(&store.ChannelCalendar{
+		ChannelID:           "channel_id",
+		CalendarAddress:     "team@example.com",
+		MattermostCreatorID: "creator_mm_id",
+		AgendaTime:          "8:00AM",
+		Timezone:            "Eastern Standard Time",
+	}, nil)
+	s.EXPECT().LoadUser("creator_mm_id").Return(&store.User{MattermostUserID: "creator_mm_id", Remote: &remote.User{ID: "creator_remote_id"}}, nil)
+	papi.EXPECT().GetMattermostUser("creator_mm_id").Return(&model.User{Id: "creator_mm_id"}, nil)
+	mockRemote.EXPECT().MakeClient(gomock.Any(), gomock.Any()).Return(client)
+	mockClient.EXPECT().GetSharedCalendarView("team@example.com", gomock.Any(), gomock.Any()).Return([]*remote.Event{planning, private}, nil)
+	poster.EXPECT().CreatePost(gomock.Any()).DoAndReturn(func(post *model.Post) error {
+		require.Equal(t, "channel_id", post.ChannelId)
+		require.Contains(t, post.Message, "Planning")
+		require.Contains(t, post.Message, "Private event")
+		require.NotContains(t, post.Message, "Salary review")
+		return nil
+	})
+	s.EXPECT().StoreChannelCalendar(gomock.Any()).DoAndReturn(func(channelCalendar *store.ChannelCalendar) error {
+		require.Equal(t, now.Format(time.RFC3339), channelCalendar.LastAgendaPostTime)
+		return nil
+	})
+
+	require.NoError(t, m.ProcessAllChannelAgendas(now))
+}
+
+func newTestPrivateEvent(identifier, locationDisplayName string, subjectDisplayName string) *remote.Event {
+	event := newTestEvent(identifier, locationDisplayName, subjectDisplayName)
+	event.Sensitivity = "private"
+	return event
+}
@@ -0,0 +1,399 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	CreateEventDateTimeFormat = "2006-01-02 15:04"
+	CreateEventDateFormat     = "2006-01-02"
+	CreateEventTimeFormat     = "15:04"
+)
+
+const (
+	RecurrenceDaily    = "daily"
+	RecurrenceWeekdays = "weekdays"
+	RecurrenceWeekly   = "weekly"
+	RecurrenceMonthly  = "monthly"
+	RecurrenceYearly   = "yearly"
+)
+
+// ErrInvalidEventPayload is returned when an event can't be created because of the user input.
+var ErrInvalidEventPayload = errors.New("invalid event")
+
+// CreateEventPayload describes an event to be created, both from the webapp and the slash command.
+// Date, StartTime and EndTime are interpreted in the timezone of the user mailbox.
+type CreateEventPayload struct {
+	AllDay    bool     `json:"all_day"`
+	Attendees []string `json:"attendees"`
+	Date      string   `json:"date"`
+	StartTime string   `json:"start_time"`
+	EndTime   string   `json:"end_time"`
+	// Reminder  bool     `json:"reminder"
+	Description string `json:"description,omitempty"`
+	Subject     string `json:"subject"`
+	Location    string `json:"location,omitempty"`
+	ChannelID   string `json:"channel_id"`
+
+	Recurrence *CreateEventRecurrence `json:"recurrence,omitempty"`
+}
+
+// CreateEventRecurrence describes how an event repeats, anchored on the event date. Weekly events repeat
+// on the event weekday unless DaysOfWeek is set. The series ends on EndDate, after a number of Occurrences,
+// or never if none of them is set.
+type CreateEventRecurrence struct {
+	Type        string   `json:"type"`
+	Interval    int      `json:"interval,omitempty"`
+	DaysOfWeek  []string `json:"days_of_week,omitempty"`
+	EndDate     string   `json:"end_date,omitempty"`
+	Occurrences int      `json:"occurrences,omitempty"`
+}
+
+func (cer CreateEventRecurrence) ToRemoteRecurrence(date time.Time, loc *time.Location) (*remote.PatternedRecurrence, error) {
+	interval := cer.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	if interval < 0 {
+		return nil, fmt.Errorf("the recurrence interval must be a positive number")
+	}
+
+	pattern := &remote.RecurrencePattern{
+		Interval: interval,
+	}
+	switch cer.Type {
+	case RecurrenceDaily:
+		pattern.Type = remote.RecurrencePatternDaily
+	case RecurrenceWeekdays:
+		pattern.Type = remote.RecurrencePatternWeekly
+		for d := time.Monday; d <= time.Friday; d++ {
+			pattern.DaysOfWeek = append(pattern.DaysOfWeek, strings.ToLower(d.String()))
+		}
+	case RecurrenceWeekly:
+		pattern.Type = remote.RecurrencePatternWeekly
+		pattern.DaysOfWeek = []string{strings.ToLower(date.Weekday().String())}
+		if len(cer.DaysOfWeek) > 0 {
+			pattern.DaysOfWeek = nil
+			for _, day := range cer.DaysOfWeek {
+				weekday, err := parseWeekday(day)
+				if err != nil {
+					return nil, err
+				}
+				pattern.DaysOfWeek = append(pattern.DaysOfWeek, strings.ToLower(weekday.String()))
+			}
+		}
+	case RecurrenceMonthly:
+		pattern.Type = remote.RecurrencePatternAbsoluteMonthly
+		pattern.DayOfMonth = date.Day()
+	case RecurrenceYearly:
+		pattern.Type = remote.RecurrencePatternAbsoluteYearly
+		pattern.DayOfMonth = date.Day()
+		pattern.Month = int(date.Month())
+	default:
+		return nil, fmt.Errorf("invalid recurrence %q, please use one of daily, weekdays, weekly, monthly or yearly", cer.Type)
+	}
+
+	rrange := &remote.RecurrenceRange{
+		Type:               remote.RecurrenceRangeNoEnd,
+		StartDate:          date.Format(CreateEventDateFormat),
+		RecurrenceTimeZone: loc.String(),
+	}
+	switch {
+	case cer.EndDate != "" && cer.Occurrences != 0:
+		return nil, fmt.Errorf("a recurrence can end by date or after a number of occurrences, but not both")
+	case cer.EndDate != "":
+		endDate, err := time.ParseInLocation(CreateEventDateFormat, cer.EndDate, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurrence end date")
+		}
+		if endDate.Before(date) {
+			return nil, fmt.Errorf("the recurrence end date cannot be earlier than the event date")
+		}
+		rrange.Type = remote.RecurrenceRangeEndDate
+		rrange.EndDate = cer.EndDate
+	case cer.Occurrences < 0:
+		return nil, fmt.Errorf("the number of occurrences must be a positive number")
+	case cer.Occurrences > 0:
+		rrange.Type = remote.RecurrenceRangeNumbered
+		rrange.NumberOfOccurrences = cer.Occurrences
+	}
+
+	return &remote.PatternedRecurrence{
+		Pattern: pattern,
+		Range:   rrange,
+	}, nil
+}
+
+func parseWeekday(day string) (time.Weekday, error) {
+	day = strings.ToLower(day)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if day == name || day == name[:3] {
+			return weekday, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid day of the week %q", day)
+}
+
+func (cep CreateEventPayload) ToRemoteEvent(loc *time.Location) (*remote.Event, error) {
+	var evt remote.Event
+
+	evt.IsAllDay = cep.AllDay
+
+	if !cep.AllDay {
+		start, err := cep.parseStartTime(loc)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing start time")
+		}
+
+		end, err := cep.parseEndTime(loc)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing end time")
+		}
+
+		evt.Start = &remote.DateTime{
+			DateTime: start.Format(remote.RFC3339NanoNoTimezone),
+			TimeZone: loc.String(),
+		}
+		evt.End = &remote.DateTime{
+			DateTime: end.Format(remote.RFC3339NanoNoTimezone),
+			TimeZone: loc.String(),
+		}
+	} else {
+		date, err := cep.parseDate(loc)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing date")
+		}
+
+		// All day events must start and end at midnight
+		evt.Start = &remote.DateTime{
+			DateTime: time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc).Format(remote.RFC3339NanoNoTimezone),
+			TimeZone: loc.String(),
+		}
+		evt.End = &remote.DateTime{
+			DateTime: time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, loc).Format(remote.RFC3339NanoNoTimezone),
+			TimeZone: loc.String(),
+		}
+	}
+
+	if cep.Description != "" {
+		evt.Body = &remote.ItemBody{
+			Content:     cep.Description,
+			ContentType: "text/plain",
+		}
+	}
+	evt.Subject = cep.Subject
+	if cep.Location != "" {
+		evt.Location = &remote.Location{
+			DisplayName: cep.Location,
+		}
+	}
+
+	if cep.Recurrence != nil {
+		date, err := cep.parseDate(loc)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing date")
+		}
+
+		evt.Recurrence, err = cep.Recurrence.ToRemoteRecurrence(date, loc)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing recurrence")
+		}
+	}
+
+	return &evt, nil
+}
+
+func (cep CreateEventPayload) parseStartTime(loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(CreateEventDateTimeFormat, fmt.Sprintf("%s %s", cep.Date, cep.StartTime), loc)
+}
+
+func (cep CreateEventPayload) parseEndTime(loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(CreateEventDateTimeFormat, fmt.Sprintf("%s %s", cep.Date, cep.EndTime), loc)
+}
+
+func (cep CreateEventPayload) parseDate(loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(CreateEventDateFormat, cep.Date, loc)
+}
+
+func (cep CreateEventPayload) IsValid(loc *time.Location) error {
+	if cep.Subject == "" {
+		return fmt.Errorf("subject must not be empty")
+	}
+
+	if cep.Date == "" {
+		return fmt.Errorf("date must not be empty")
+	}
+
+	date, err := cep.parseDate(loc)
+	if err != nil {
+		return fmt.Errorf("invalid date")
+	}
+
+	if cep.Recurrence != nil {
+		if _, err = cep.Recurrence.ToRemoteRecurrence(date, loc); err != nil {
+			return err
+		}
+	}
+
+	if cep.AllDay {
+		now := time.Now().In(loc)
+		if date.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)) {
+			return fmt.Errorf("please select a date that is not prior to the current date")
+		}
+		return nil
+	}
+
+	if cep.StartTime == "" && cep.EndTime == "" {
+		return fmt.Errorf("start time/end time must be set or event should last all day")
+	}
+
+	start, err := cep.parseStartTime(loc)
+	if err != nil {
+		return fmt.Errorf("please use a valid start time")
+	}
+
+	if start.Before(time.Now()) {
+		return fmt.Errorf("please select a start date and time that is not prior to the current time")
+	}
+
+	end, err := cep.parseEndTime(loc)
+	if err != nil {
+		return fmt.Errorf("please use a valid end time")
+	}
+
+	if end.Before(time.Now()) {
+		return fmt.Errorf("please select an end date and time that is not prior to the current time")
+	}
+
+	if start.After(end) {
+		return fmt.Errorf("end date cannot be earlier than start date")
+	}
+
+	return nil
+}
+
+// CreateEventFromPayload validates the payload in the user mailbox timezone, creates the event inviting
+// the payload attendees and links it to the payload channel, if any. The creator is notified via DM,
+// or with a post in the linked channel.
+func (m *mscalendar) CreateEventFromPayload(user *User, payload CreateEventPayload) (*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.ChannelID != "" && !m.PluginAPI.CanLinkEventToChannel(payload.ChannelID, user.MattermostUserID) {
+		return nil, fmt.Errorf("%w: you don't have permission to link events in the selected channel", ErrInvalidEventPayload)
+	}
+
+	mailbox, err := m.client.GetMailboxSettings(user.Remote.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting mailbox settings")
+	}
+
+	loc, err := time.LoadLocation(tz.Go(mailbox.TimeZone))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading mailbox timezone %s", mailbox.TimeZone)
+	}
+
+	if err = payload.IsValid(loc); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEventPayload, err.Error())
+	}
+
+	event, err := payload.ToRemoteEvent(loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEventPayload, err.Error())
+	}
+
+	var mattermostUserIDs []string
+	for _, pa := range payload.Attendees {
+		var emailAddress string
+
+		if strings.Contains(pa, "@") {
+			emailAddress = pa
+		} else {
+			mattermostUserIDs = append(mattermostUserIDs, pa)
+			attendeeUser, errLoad := m.Store.LoadUser(pa)
+			if errLoad != nil {
+				m.Logger.With(bot.LogContext{"err": errLoad.Error(), "attendee_mm_id": pa}).Errorf("error loading attendee from mattermost user id")
+				continue
+			}
+
+			emailAddress = attendeeUser.Remote.Mail
+		}
+
+		event.Attendees = append(event.Attendees, &remote.Attendee{
+			EmailAddress: &remote.EmailAddress{
+				Address: emailAddress,
+			},
+		})
+	}
+
+	event, err = m.CreateEvent(user, event, mattermostUserIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating event")
+	}
+
+	attachment, err := views.RenderEventAsAttachment(event, mailbox.TimeZone, views.ShowTimezoneOption(mailbox.TimeZone))
+	if err != nil {
+		m.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("error rendering event as attachment")
+	}
+
+	if payload.ChannelID == "" {
+		if attachment == nil {
+			_, err = m.Poster.DM(user.MattermostUserID, "Your event: **%s** was created successfully.", event.Subject)
+		} else {
+			_, err = m.Poster.DMWithMessageAndAttachments(user.MattermostUserID, "Your event was created successfully.", attachment)
+		}
+		if err != nil {
+			m.Logger.With(bot.LogContext{"err": err.Error()}).Warnf("error notifying user about created event")
+		}
+		return event, nil
+	}
+
+	if err = m.linkEventToChannel(user, event, payload.ChannelID, attachment); err != nil {
+		m.Logger.With(bot.LogContext{"err": err.Error(), "userID": user.MattermostUserID, "channelID": payload.ChannelID}).Errorf("error linking event to channel")
+		_, _ = m.Poster.DM(user.MattermostUserID, "Your event **%s** could not be linked to a channel. Please contact an administrator for more details.", event.Subject)
+	}
+
+	return event, nil
+}
+
+func (m *mscalendar) linkEventToChannel(user *User, event *remote.Event, channelID string, attachment *model.SlackAttachment) error {
+	if err := m.Store.StoreUserLinkedEvent(user.MattermostUserID, event.ICalUID, channelID); err != nil {
+		return errors.Wrap(err, "error storing user linked event")
+	}
+
+	if err := m.Store.AddLinkedChannelToEvent(event.ICalUID, channelID); err != nil {
+		return errors.Wrap(err, "error storing event linked channel")
+	}
+
+	post := &model.Post{
+		Message:   fmt.Sprintf("The event **%s** was linked to this channel by @%s", event.Subject, user.MattermostUsername),
+		ChannelId: channelID,
+	}
+	if attachment != nil {
+		model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+	}
+	if err := m.Poster.CreatePost(post); err != nil {
+		m.Logger.With(bot.LogContext{"err": err}).Errorf("error sending post to channel about linked event")
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestCreateEventRecurrenceToRemoteRecurrence(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	// Wednesday
+	date := time.Date(2024, time.May, 15, 0, 0, 0, 0, loc)
+
+	for name, tc := range map[string]struct {
+		recurrence          CreateEventRecurrence
+		expected            *remote.PatternedRecurrence
+		expectedDescription string
+		expectedError       string
+	}{
+		"weekly on the event weekday with no end": {
+			recurrence: CreateEventRecurrence{Type: RecurrenceWeekly},
+			expected: &remote.PatternedRecurrence{
+				Pattern: &remote.RecurrencePattern{Type: remote.RecurrencePatternWeekly, Interval: 1, DaysOfWeek: []string{"wednesday"}},
+				Range:   &remote.RecurrenceRange{Type: remote.RecurrenceRangeNoEnd, StartDate: "2024-05-15", RecurrenceTimeZone: "Europe/Madrid"},
+			},
+			expectedDescription: "Every week on Wednesday",
+		},
+		"every two weeks on selected days until a date": {
+			recurrence: CreateEventRecurrence{Type: RecurrenceWeekly, Interval: 2, DaysOfWeek: []string{"Mon", "thursday"}, EndDate: "2024-06-30"},
+			expected: &remote.PatternedRecurrence{
+				Pattern: &remote.RecurrencePattern{Type: remote.RecurrencePatternWeekly, Interval: 2, DaysOfWeek: []string{"monday", "thursday"}},
+				Range:   &remote.RecurrenceRange{Type: remote.RecurrenceRangeEndDate, StartDate: "2024-05-15", EndDate: "2024-06-30", RecurrenceTimeZone: "Europe/Madrid"},
+			},
+			expectedDescription: "Every 2 weeks on Monday, Thursday, until 2024-06-30",
+		},
+		"monthly ten times": {
+			recurrence: CreateEventRecurrence{Type: RecurrenceMonthly, Occurrences: 10},
+			expected: &remote.PatternedRecurrence{
+				Pattern: &remote.RecurrencePattern{Type: remote.RecurrencePatternAbsoluteMonthly, Interval: 1, DayOfMonth: 15},
+				Range:   &remote.RecurrenceRange{Type: remote.RecurrenceRangeNumbered, StartDate: "2024-05-15", NumberOfOccurrences: 10, RecurrenceTimeZone: "Europe/Madrid"},
+			},
+			expectedDescription: "Every month on day 15, 10 times",
+		},
+		"yearly": {
+			recurrence: CreateEventRecurrence{Type: RecurrenceYearly},
+			expected: &remote.PatternedRecurrence{
+				Pattern: &remote.RecurrencePattern{Type: remote.RecurrencePatternAbsoluteYearly, Interval: 1, DayOfMonth: 15, Month: 5},
+				Range:   &remote.RecurrenceRange{Type: remote.RecurrenceRangeNoEnd, StartDate: "2024-05-15", RecurrenceTimeZone: "Europe/Madrid"},
+			},
+			expectedDescription: "Every year on May 15",
+		},
+		"invalid type": {
+			recurrence:    CreateEventRecurrence{Type: "hourly"},
+			expectedError: `invalid recurrence "hourly", please use one of daily, weekdays, weekly, monthly or yearly`,
+		},
+		"both end date and occurrences": {
+			recurrence:    CreateEventRecurrence{Type: RecurrenceDaily, EndDate: "2024-06-30", Occurrences: 3},
+			expectedError: "a recurrence can end by date or after a number of occurrences, but not both",
+		},
+		"end date before the event": {
+			recurrence:    CreateEventRecurrence{Type: RecurrenceDaily, EndDate: "2024-05-01"},
+			expectedError: "the recurrence end date cannot be earlier than the event date",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			recurrence, err := tc.recurrence.ToRemoteRecurrence(date, loc)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, recurrence)
+			require.Equal(t, tc.expectedDescription, recurrence.String())
+		})
+	}
+}
+
+func TestCreateEventPayloadAllDay(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	now := time.Now().In(loc)
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc)
+	payload := CreateEventPayload{
+		Subject: "Offsite",
+		Date:    tomorrow.Format(CreateEventDateFormat),
+		AllDay:  true,
+	}
+	require.NoError(t, payload.IsValid(loc))
+
+	event, err := payload.ToRemoteEvent(loc)
+	require.NoError(t, err)
+	require.True(t, event.IsAllDay)
+	require.Equal(t, &remote.DateTime{DateTime: tomorrow.Format(remote.RFC3339NanoNoTimezone), TimeZone: "Europe/Madrid"}, event.Start)
+	require.Equal(t, &remote.DateTime{DateTime: tomorrow.AddDate(0, 0, 1).Format(remote.RFC3339NanoNoTimezone), TimeZone: "Europe/Madrid"}, event.End)
+
+	payload.Date = tomorrow.AddDate(0, 0, -2).Format(CreateEventDateFormat)
+	require.EqualError(t, payload.IsValid(loc), "please select a date that is not prior to the current date")
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+const (
+	defaultCustomStatusEmoji = "calendar"
+	defaultCustomStatusText  = "In a meeting"
+
+	customStatusSubjectPlaceholder = "{subject}"
+	customStatusEndPlaceholder     = "{end}"
+	customStatusMaxTextLength      = 100
+
+	privateEventSubject   = "Private event"
+	teamsMeetingProvider  = "teamsForBusiness"
+	teamsMeetingURLDomain = "teams.microsoft.com"
+)
+
+var ErrInvalidCustomStatusRule = errors.New("invalid custom status rule")
+
+type CustomStatusRules interface {
+	GetCustomStatusRules(user *User) ([]*store.CustomStatusRule, error)
+	AddCustomStatusRule(user *User, rule *store.CustomStatusRule) error
+	RemoveCustomStatusRule(user *User, index int) (*store.CustomStatusRule, error)
+}
+
+func (m *mscalendar) GetCustomStatusRules(user *User) ([]*store.CustomStatusRule, error) {
+	err := m.Filter(withUserExpanded(user))
+	if err != nil {
+		return nil, err
+	}
+
+	return user.Settings.CustomStatusRules, nil
+}
+
+// AddCustomStatusRule validates the rule and adds it after the existing rules of the user.
+func (m *mscalendar) AddCustomStatusRule(user *User, rule *store.CustomStatusRule) error {
+	err := m.Filter(withUserExpanded(user))
+	if err != nil {
+		return err
+	}
+
+	rule.Emoji = strings.Trim(rule.Emoji, ":")
+	if rule.Expiry == "" {
+		rule.Expiry = store.CustomStatusExpiryEventEnd
+	}
+	if err = validateCustomStatusRule(rule); err != nil {
+		return err
+	}
+
+	rules := append(append([]*store.CustomStatusRule{}, user.Settings.CustomStatusRules...), rule)
+	if err = m.Store.SetSetting(user.MattermostUserID, store.CustomStatusRulesSettingID, rules); err != nil {
+		return errors.Wrap(err, "error storing custom status rules")
+	}
+	user.Settings.CustomStatusRules = rules
+
+	return nil
+}
+
+// RemoveCustomStatusRule removes the rule at the given index, starting at 0, and returns it.
+func (m *mscalendar) RemoveCustomStatusRule(user *User, index int) (*store.CustomStatusRule, error) {
+	err := m.Filter(withUserExpanded(user))
+	if err != nil {
+		return nil, err
+	}
+
+	rules := user.Settings.CustomStatusRules
+	if index < 0 || index >= len(rules) {
+		return nil, fmt.Errorf("%w: rule %d not found", ErrInvalidCustomStatusRule, index+1)
+	}
+
+	removed := rules[index]
+	updated := append(append([]*store.CustomStatusRule{}, rules[:index]...), rules[index+1:]...)
+	if err = m.Store.SetSetting(user.MattermostUserID, store.CustomStatusRulesSettingID, updated); err != nil {
+		return nil, errors.Wrap(err, "error storing custom status rules")
+	}
+	user.Settings.CustomStatusRules = updated
+
+	return removed, nil
+}
+
+func validateCustomStatusRule(rule *store.CustomStatusRule) error {
+	if rule.Category == "" && rule.SubjectPattern == "" && rule.ShowAs == "" && !rule.HasTeamsLink {
+		return fmt.Errorf("%w: please set at least one condition", ErrInvalidCustomStatusRule)
+	}
+	if rule.SubjectPattern != "" {
+		if _, err := regexp.Compile(rule.SubjectPattern); err != nil {
+			return fmt.Errorf("%w: invalid subject pattern %q", ErrInvalidCustomStatusRule, rule.SubjectPattern)
+		}
+	}
+	if rule.Emoji == "" {
+		return fmt.Errorf("%w: please set an emoji", ErrInvalidCustomStatusRule)
+	}
+	if rule.Text == "" {
+		return fmt.Errorf("%w: please set a text", ErrInvalidCustomStatusRule)
+	}
+
+	switch rule.Expiry {
+	case store.CustomStatusExpiryEventEnd, store.CustomStatusExpiryNone:
+	default:
+		d, err := time.ParseDuration(rule.Expiry)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("%w: invalid expiry %q", ErrInvalidCustomStatusRule, rule.Expiry)
+		}
+	}
+
+	return nil
+}
+
+// matchCustomStatusRule returns the first rule matching the event, or nil. The subject of private events
+// is never matched.
+func matchCustomStatusRule(rules []*store.CustomStatusRule, event *remote.Event) *store.CustomStatusRule {
+	for _, rule := range rules {
+		if customStatusRuleMatches(rule, event) {
+			return rule
+		}
+	}
+	return nil
+}
+
+func customStatusRuleMatches(rule *store.CustomStatusRule, event *remote.Event) bool {
+	if rule.Category != "" {
+		found := false
+		for _, category := range event.Categories {
+			if strings.EqualFold(category, rule.Category) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if rule.ShowAs != "" && !strings.EqualFold(rule.ShowAs, event.ShowAs) {
+		return false
+	}
+
+	if rule.HasTeamsLink && !hasTeamsLink(event) {
+		return false
+	}
+
+	if rule.SubjectPattern != "" {
+		if isPrivateEvent(event) {
+			return false
+		}
+		re, err := regexp.Compile(rule.SubjectPattern)
+		if err != nil || !re.MatchString(event.Subject) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filterCustomStatusRuleEvents returns copies of the events matching a rule, so that merging the meetings
+// does not change them.
+func filterCustomStatusRuleEvents(events []*remote.Event, rules []*store.CustomStatusRule) []*remote.Event {
+	result := []*remote.Event{}
+	if len(rules) == 0 {
+		return result
+	}
+
+	for _, e := range events {
+		if e.IsCancelled || matchCustomStatusRule(rules, e) == nil {
+			continue
+		}
+		event := *e
+		result = append(result, &event)
+	}
+	return result
+}
+
+// newCustomStatus returns the custom status for the event, from the first matching rule, or the default
+// meeting custom status. The end time in the text is shown in loc.
+func newCustomStatus(rules []*store.CustomStatusRule, event *remote.Event, loc *time.Location) *model.CustomStatus {
+	rule := matchCustomStatusRule(rules, event)
+	if rule == nil {
+		return &model.CustomStatus{
+			Emoji:     defaultCustomStatusEmoji,
+			Text:      defaultCustomStatusText,
+			ExpiresAt: event.End.Time(),
+			Duration:  "date_and_time",
+		}
+	}
+
+	customStatus := &model.CustomStatus{
+		Emoji: rule.Emoji,
+		Text:  renderCustomStatusText(rule.Text, event, loc),
+	}
+
+	switch rule.Expiry {
+	case store.CustomStatusExpiryNone:
+	case store.CustomStatusExpiryEventEnd, "":
+		customStatus.ExpiresAt = event.End.Time()
+		customStatus.Duration = "date_and_time"
+	default:
+		d, err := time.ParseDuration(rule.Expiry)
+		if err != nil {
+			customStatus.ExpiresAt = event.End.Time()
+		} else {
+			customStatus.ExpiresAt = event.Start.Time().Add(d)
+		}
+		customStatus.Duration = "date_and_time"
+	}
+
+	return customStatus
+}
+
+func renderCustomStatusText(text string, event *remote.Event, loc *time.Location) string {
+	subject := views.EnsureSubject(event.Subject)
+	if isPrivateEvent(event) {
+		subject = privateEventSubject
+	}
+
+	end := event.End.Time().In(loc)
+	endText := end.Format("3:04pm")
+	if end.Minute() == 0 {
+		endText = end.Format("3pm")
+	}
+
+	rendered := strings.NewReplacer(
+		customStatusSubjectPlaceholder, subject,
+		customStatusEndPlaceholder, endText,
+	).Replace(text)
+
+	if runes := []rune(rendered); len(runes) > customStatusMaxTextLength {
+		rendered = string(runes[:customStatusMaxTextLength-1]) + "…"
+	}
+	return rendered
+}
+
+func isPrivateEvent(event *remote.Event) bool {
+	return event.Sensitivity != "" && !strings.EqualFold(event.Sensitivity, "normal")
+}
+
+func hasTeamsLink(event *remote.Event) bool {
+	return strings.EqualFold(event.OnlineMeetingProvider, teamsMeetingProvider) ||
+		strings.Contains(event.JoinURL(), teamsMeetingURLDomain)
+}
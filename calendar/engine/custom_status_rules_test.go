@@ -0,0 +1,153 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func TestNewCustomStatus(t *testing.T) {
+	start := time.Date(2024, time.May, 15, 13, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.May, 15, 15, 0, 0, 0, time.UTC)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	rules := []*store.CustomStatusRule{
+		{Category: "Focus time", Emoji: "headphones", Text: "Focus time until {end}", Expiry: store.CustomStatusExpiryEventEnd},
+		{ShowAs: "oof", Emoji: "airplane", Text: "Travelling", Expiry: store.CustomStatusExpiryNone},
+		{SubjectPattern: "(?i)^1:1", Emoji: "speech_balloon", Text: "{subject}", Expiry: "30m"},
+		{HasTeamsLink: true, Emoji: "video_camera", Text: "In a call: {subject}"},
+	}
+
+	for name, tc := range map[string]struct {
+		event          *remote.Event
+		expectedEmoji  string
+		expectedText   string
+		expectedExpiry time.Time
+	}{
+		"matching category": {
+			event:          &remote.Event{Subject: "Deep work", Categories: []string{"focus time"}},
+			expectedEmoji:  "headphones",
+			expectedText:   "Focus time until 11am",
+			expectedExpiry: end,
+		},
+		"matching show as, without expiry": {
+			event:         &remote.Event{Subject: "Flight", ShowAs: "oof"},
+			expectedEmoji: "airplane",
+			expectedText:  "Travelling",
+		},
+		"matching subject, expiring after the start": {
+			event:          &remote.Event{Subject: "1:1 with Alice", ShowAs: "busy"},
+			expectedEmoji:  "speech_balloon",
+			expectedText:   "1:1 with Alice",
+			expectedExpiry: start.Add(30 * time.Minute),
+		},
+		"private event not matched on its subject": {
+			event:          &remote.Event{Subject: "1:1 with Alice", Sensitivity: "private"},
+			expectedEmoji:  defaultCustomStatusEmoji,
+			expectedText:   defaultCustomStatusText,
+			expectedExpiry: end,
+		},
+		"private event never showing its subject": {
+			event:          &remote.Event{Subject: "Doctor", Sensitivity: "private", OnlineMeetingProvider: "teamsForBusiness"},
+			expectedEmoji:  "video_camera",
+			expectedText:   "In a call: Private event",
+			expectedExpiry: end,
+		},
+		"Teams link": {
+			event:          &remote.Event{Subject: "Standup", OnlineMeeting: &remote.OnlineMeetingInfo{JoinURL: "https://teams.microsoft.com/l/meetup-join/123"}},
+			expectedEmoji:  "video_camera",
+			expectedText:   "In a call: Standup",
+			expectedExpiry: end,
+		},
+		"no matching rule": {
+			event:          &remote.Event{Subject: "Planning", ShowAs: "busy"},
+			expectedEmoji:  defaultCustomStatusEmoji,
+			expectedText:   defaultCustomStatusText,
+			expectedExpiry: end,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tc.event.Start = remote.NewDateTime(start, "UTC")
+			tc.event.End = remote.NewDateTime(end, "UTC")
+
+			customStatus := newCustomStatus(rules, tc.event, newYork)
+			require.Equal(t, tc.expectedEmoji, customStatus.Emoji)
+			require.Equal(t, tc.expectedText, customStatus.Text)
+			require.True(t, tc.expectedExpiry.Equal(customStatus.ExpiresAt), "expected expiry %v, got %v", tc.expectedExpiry, customStatus.ExpiresAt)
+		})
+	}
+}
+
+func TestRenderCustomStatusText(t *testing.T) {
+	event := &remote.Event{
+		Subject: "Design review",
+		End:     remote.NewDateTime(time.Date(2024, time.May, 15, 15, 30, 0, 0, time.UTC), "UTC"),
+	}
+
+	require.Equal(t, "Design review until 3:30pm", renderCustomStatusText("{subject} until {end}", event, time.UTC))
+
+	event.Subject = strings.Repeat("a", 150)
+	require.Len(t, []rune(renderCustomStatusText("{subject}", event, time.UTC)), customStatusMaxTextLength)
+}
+
+func TestValidateCustomStatusRule(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rule          store.CustomStatusRule
+		expectedError string
+	}{
+		"valid rule": {
+			rule: store.CustomStatusRule{Category: "Travel", Emoji: "airplane", Text: "Travelling", Expiry: "2h"},
+		},
+		"no condition": {
+			rule:          store.CustomStatusRule{Emoji: "airplane", Text: "Travelling", Expiry: store.CustomStatusExpiryEventEnd},
+			expectedError: "invalid custom status rule: please set at least one condition",
+		},
+		"invalid subject pattern": {
+			rule:          store.CustomStatusRule{SubjectPattern: "(", Emoji: "airplane", Text: "Travelling", Expiry: store.CustomStatusExpiryEventEnd},
+			expectedError: `invalid custom status rule: invalid subject pattern "("`,
+		},
+		"no emoji": {
+			rule:          store.CustomStatusRule{Category: "Travel", Text: "Travelling", Expiry: store.CustomStatusExpiryEventEnd},
+			expectedError: "invalid custom status rule: please set an emoji",
+		},
+		"invalid expiry": {
+			rule:          store.CustomStatusRule{Category: "Travel", Emoji: "airplane", Text: "Travelling", Expiry: "tomorrow"},
+			expectedError: `invalid custom status rule: invalid expiry "tomorrow"`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := validateCustomStatusRule(&tc.rule)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestFilterCustomStatusRuleEvents(t *testing.T) {
+	rules := []*store.CustomStatusRule{{Category: "Focus time", Emoji: "headphones", Text: "Focus time"}}
+	focus := &remote.Event{ID: "focus", Categories: []string{"Focus time"}}
+	events := []*remote.Event{
+		{ID: "meeting", ShowAs: "busy"},
+		focus,
+		{ID: "cancelled", Categories: []string{"Focus time"}, IsCancelled: true},
+	}
+
+	result := filterCustomStatusRuleEvents(events, rules)
+	require.Len(t, result, 1)
+	require.Equal(t, "focus", result[0].ID)
+	require.NotSame(t, focus, result[0])
+
+	require.Empty(t, filterCustomStatusRuleEvents(events, nil))
+}
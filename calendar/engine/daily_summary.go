@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/pkg/errors"
 
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
@@ -44,13 +46,9 @@ func (m *mscalendar) SetDailySummaryPostTime(user *User, timeStr string) (*store
 		return nil, err
 	}
 
-	t, err := time.Parse(time.Kitchen, timeStr)
+	err = validatePostTime(timeStr)
 	if err != nil {
-		return nil, errors.New("Invalid time value: " + timeStr)
-	}
-
-	if t.Minute()%int(DailySummaryJobInterval/time.Minute) != 0 {
-		return nil, fmt.Errorf("time must be a multiple of %d minutes", DailySummaryJobInterval/time.Minute)
+		return nil, err
 	}
 
 	timezone, err := m.GetTimezone(user)
@@ -94,21 +92,37 @@ func (m *mscalendar) SetDailySummaryEnabled(user *User, enable bool) (*store.Dai
 }
 
 func (m *mscalendar) ProcessAllDailySummary(now time.Time) error {
-	userIndex, err := m.Store.LoadUserIndex()
-	if err != nil {
-		return err
-	}
-	if len(userIndex) == 0 {
+	filtered, fetchIndividually := false, false
+	processed := 0
+	err := m.Store.ForEachUserIndexPage(func(page store.UserIndex) error {
+		if !filtered {
+			err := m.Filter(withSuperuserClient)
+			if err != nil && !errors.Is(err, remote.ErrSuperUserClientNotSupported) {
+				return err
+			}
+			fetchIndividually = errors.Is(err, remote.ErrSuperUserClientNotSupported)
+			filtered = true
+		}
+
+		// A failing page doesn't keep the users of the next ones from getting their summary
+		n, err := m.processDailySummaryPage(page, now, fetchIndividually)
+		if err != nil {
+			m.Logger.Warnf("Error processing the daily summary of %d users, continuing with the next page. err=%v", len(page), err)
+		}
+		processed += n
 		return nil
-	}
+	})
 
-	err = m.Filter(withSuperuserClient)
-	if err != nil && !errors.Is(err, remote.ErrSuperUserClientNotSupported) {
-		return err
+	// The users of the shards of the user index failing to load are the only ones missing their summary
+	if filtered {
+		m.Logger.Infof("Processed daily summary for %d users", processed)
 	}
+	return err
+}
 
-	fetchIndividually := errors.Is(err, remote.ErrSuperUserClientNotSupported)
-
+// processDailySummaryPage posts the daily summary of the users of a page of the user index, and returns the
+// number of calendar views processed.
+func (m *mscalendar) processDailySummaryPage(userIndex store.UserIndex, now time.Time, fetchIndividually bool) (int, error) {
 	calendarViews := []*remote.ViewCalendarResponse{}
 	requests := []*remote.ViewCalendarParams{}
 	byRemoteID := map[string]*store.User{}
@@ -157,7 +171,8 @@ func (m *mscalendar) ProcessAllDailySummary(now time.Time) error {
 				continue
 			}
 
-			events, err := engine.getTodayCalendarEvents(u, now, timezone)
+			from, to := dailySummaryRange(dsum, now, timezone)
+			events, err := engine.getCalendarEventsBetween(u, from, to)
 			if err != nil {
 				m.Logger.With(bot.LogContext{
 					"mm_user_id": storeUser.MattermostUserID,
@@ -174,22 +189,18 @@ func (m *mscalendar) ProcessAllDailySummary(now time.Time) error {
 				Events:       events,
 			})
 		} else {
-			start, end := getTodayHoursForTimezone(now, dsum.Timezone)
-			req := &remote.ViewCalendarParams{
-				RemoteUserID: storeUser.Remote.ID,
-				StartTime:    start,
-				EndTime:      end,
-			}
-			requests = append(requests, req)
+			start, end := dailySummaryRange(dsum, now, dsum.Timezone)
+			requests = append(requests, calendarViewParams(storeUser, start, end)...)
 		}
 	}
 
-	if !fetchIndividually {
+	if !fetchIndividually && len(requests) > 0 {
 		var err error
 		calendarViews, err = m.client.DoBatchViewCalendarRequests(requests)
 		if err != nil {
-			return err
+			return 0, err
 		}
+		calendarViews = m.mergeCalendarViews(calendarViews)
 	}
 
 	for _, res := range calendarViews {
@@ -202,14 +213,36 @@ func (m *mscalendar) ProcessAllDailySummary(now time.Time) error {
 			// Should never reach this point
 			continue
 		}
-		postStr, err := views.RenderCalendarView(res.Events, dsum.Timezone)
+
+		postStr := ""
+		outOfOffice, err := m.isOutOfOfficeForDailySummary(user, res.Events, now, fetchIndividually)
 		if err != nil {
-			m.Logger.Warnf("Error rendering user %s calendar. err=%v", user.MattermostUserID, err)
+			m.Logger.Warnf("Error checking whether user %s is out of office. err=%v", user.MattermostUserID, err)
+		}
+		var attachments []*model.SlackAttachment
+		if !outOfOffice {
+			actionURL := ""
+			if dsum.Interactive {
+				actionURL = m.Config.PluginURLPath + config.PathPostAction
+			}
+			postStr, attachments, err = renderDailySummary(dsum, res.Events, now, dsum.Timezone, actionURL)
+			if err != nil {
+				m.Logger.Warnf("Error rendering user %s calendar. err=%v", user.MattermostUserID, err)
+			}
 		}
 
-		m.Poster.DM(user.MattermostUserID, postStr)
-
-		m.Dependencies.Tracker.TrackDailySummarySent(user.MattermostUserID)
+		// The sections of the summary may all be for other days of the week, or skipped on empty days
+		if postStr != "" {
+			if len(attachments) > 0 {
+				_, err = m.Poster.DMWithMessageAndAttachments(user.MattermostUserID, postStr, attachments...)
+				if err != nil {
+					m.Logger.Warnf("Error posting daily summary for user %s. err=%v", user.MattermostUserID, err)
+				}
+			} else {
+				m.Poster.DM(user.MattermostUserID, postStr)
+			}
+			m.Dependencies.Tracker.TrackDailySummarySent(user.MattermostUserID)
+		}
 		dsum.LastPostTime = time.Now().Format(time.RFC3339)
 		err = m.Store.StoreUser(user)
 		if err != nil {
@@ -217,8 +250,7 @@ func (m *mscalendar) ProcessAllDailySummary(now time.Time) error {
 		}
 	}
 
-	m.Logger.Infof("Processed daily summary for %d users", len(calendarViews))
-	return nil
+	return len(calendarViews), nil
 }
 
 func (m *mscalendar) GetDaySummaryForUser(day time.Time, user *User) (string, error) {
@@ -247,7 +279,12 @@ func shouldPostDailySummary(dsum *store.DailySummaryUserSettings, now time.Time)
 		return false, nil
 	}
 
-	lastPostStr := dsum.LastPostTime
+	return isScheduledPostTime(dsum.PostTime, dsum.Timezone, dsum.LastPostTime, now, dsum.PostsOn)
+}
+
+// isScheduledPostTime returns whether now is the time to post something scheduled daily at postTime in the
+// timezone, on the weekdays accepted by postsOn, or every day if it is nil.
+func isScheduledPostTime(postTime, timezone, lastPostStr string, now time.Time, postsOn func(time.Weekday) bool) (bool, error) {
 	if lastPostStr != "" {
 		lastPost, err := time.Parse(time.RFC3339, lastPostStr)
 		if err != nil {
@@ -259,7 +296,7 @@ func shouldPostDailySummary(dsum *store.DailySummaryUserSettings, now time.Time)
 		}
 	}
 
-	timezone := tz.Go(dsum.Timezone)
+	timezone = tz.Go(timezone)
 	if timezone == "" {
 		return false, errors.New("invalid timezone")
 	}
@@ -267,13 +304,13 @@ func shouldPostDailySummary(dsum *store.DailySummaryUserSettings, now time.Time)
 	if err != nil {
 		return false, err
 	}
-	t, err := time.ParseInLocation(time.Kitchen, dsum.PostTime, loc)
+	t, err := time.ParseInLocation(time.Kitchen, postTime, loc)
 	if err != nil {
 		return false, err
 	}
 
 	now = now.In(loc)
-	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
+	if postsOn != nil && !postsOn(now.Weekday()) {
 		return false, nil
 	}
 
@@ -285,6 +322,41 @@ func shouldPostDailySummary(dsum *store.DailySummaryUserSettings, now time.Time)
 	return -diff < dailySummaryTimeWindow, nil
 }
 
+// validatePostTime checks that the time of a daily post is in Kitchen format, and matches a run of the job.
+func validatePostTime(timeStr string) error {
+	t, err := time.Parse(time.Kitchen, timeStr)
+	if err != nil {
+		return errors.New("Invalid time value: " + timeStr)
+	}
+
+	if t.Minute()%int(DailySummaryJobInterval/time.Minute) != 0 {
+		return fmt.Errorf("time must be a multiple of %d minutes", DailySummaryJobInterval/time.Minute)
+	}
+	return nil
+}
+
+// isOutOfOfficeForDailySummary returns whether the summary of the user is skipped because they are out of
+// office: they have an all-day out of office event today, or their automatic replies are on.
+func (m *mscalendar) isOutOfOfficeForDailySummary(user *store.User, events []*remote.Event, now time.Time, fetchIndividually bool) (bool, error) {
+	dsum := user.Settings.DailySummary
+	if !dsum.Skips(store.DailySummarySkipOutOfOffice) {
+		return false, nil
+	}
+
+	dayStart, _ := getTodayHoursForTimezone(now, dsum.Timezone)
+	for _, e := range events {
+		if isAllDayOutOfOffice(e, dayStart) {
+			return true, nil
+		}
+	}
+
+	p, err := m.getPresence(user, fetchIndividually, now)
+	if err != nil {
+		return false, err
+	}
+	return p.outOfOffice, nil
+}
+
 func getTodayHoursForTimezone(now time.Time, timezone string) (start, end time.Time) {
 	t := remote.NewDateTime(now.UTC(), "UTC").In(timezone).Time()
 	start = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
@@ -0,0 +1,274 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	weekPreviewDay = time.Monday
+	weekRecapDay   = time.Friday
+
+	// Meetings separated by less than backToBackMaxGap are back-to-back
+	backToBackMaxGap = 5 * time.Minute
+	// The recap lists the invitations awaiting a response over the next week
+	awaitingResponseWindow = 7 * 24 * time.Hour
+)
+
+// dailySummaryRange returns the time range of the events needed for the summary posted at now: the day, the
+// week ahead for the preview, and the week so far and the week ahead for the recap.
+func dailySummaryRange(dsum *store.DailySummaryUserSettings, now time.Time, timezone string) (time.Time, time.Time) {
+	start, end := getTodayHoursForTimezone(now, timezone)
+	switch start.Weekday() {
+	case weekPreviewDay:
+		if dsum.HasSection(store.DailySummarySectionWeekPreview) {
+			end = start.AddDate(0, 0, 7)
+		}
+	case weekRecapDay:
+		if dsum.HasWeekRecap() {
+			start = start.AddDate(0, 0, -int(weekRecapDay-time.Monday))
+			if dsum.HasSection(store.DailySummarySectionAwaitingResponse) {
+				end = now.Add(awaitingResponseWindow)
+			}
+		}
+	}
+	return start, end
+}
+
+// renderDailySummary renders the sections of the summary that are due at now. It returns an empty string if
+// there is nothing to post. In the interactive mode, the events are rendered as attachments with the actions
+// posted to actionURL.
+func renderDailySummary(dsum *store.DailySummaryUserSettings, events []*remote.Event, now time.Time, timezone, actionURL string) (string, []*model.SlackAttachment, error) {
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "error loading timezone %s", timezone)
+	}
+	dayStart, dayEnd := getTodayHoursForTimezone(now, timezone)
+
+	sections := []string{}
+	var attachments []*model.SlackAttachment
+	isPreviewDay := dayStart.Weekday() == weekPreviewDay && dsum.HasSection(store.DailySummarySectionWeekPreview)
+	isRecapDay := dayStart.Weekday() == weekRecapDay && dsum.HasWeekRecap()
+	if isPreviewDay || dsum.HasSection(store.DailySummarySectionToday) {
+		// The week preview includes the events of the day
+		title := ""
+		to := dayEnd
+		switch {
+		case isPreviewDay:
+			title = "#### Your week ahead\n"
+			to = dayStart.AddDate(0, 0, 7)
+		case isRecapDay:
+			title = "#### Your day\n"
+		}
+
+		dayEvents := eventsBetween(events, dayStart, to)
+		switch {
+		case len(scheduledEvents(dayEvents)) > 0 && dsum.Interactive:
+			message, eventAttachments, renderErr := views.RenderDaySummary(dayEvents, timezone, func(e *remote.Event) []*model.PostAction {
+				return dailySummaryActions(e, actionURL)
+			})
+			if renderErr != nil {
+				return "", nil, renderErr
+			}
+			sections = append(sections, title+message)
+			attachments = eventAttachments
+		case len(scheduledEvents(dayEvents)) > 0:
+			view, renderErr := views.RenderCalendarView(dayEvents, timezone)
+			if renderErr != nil {
+				return "", nil, renderErr
+			}
+			sections = append(sections, title+view)
+		case !dsum.Skips(store.DailySummarySkipEmptyDays):
+			nothingScheduled := "Nothing scheduled today."
+			if isPreviewDay {
+				nothingScheduled = "Nothing scheduled this week."
+			}
+			sections = append(sections, title+nothingScheduled)
+		}
+	}
+
+	if isRecapDay {
+		recap := []string{"#### Your week in review"}
+		weekStart := dayStart.AddDate(0, 0, -int(weekRecapDay-time.Monday))
+		meetings := recapMeetings(eventsBetween(events, weekStart, dayEnd))
+
+		if dsum.HasSection(store.DailySummarySectionMeetingHours) {
+			recap = append(recap, views.RenderMeetingTime(meetingTimePerDay(meetings, weekStart, loc)))
+		}
+		if dsum.HasSection(store.DailySummarySectionBackToBack) {
+			recap = append(recap, views.RenderBackToBackStreaks(backToBackStreaks(meetings, loc)))
+		}
+		if dsum.HasSection(store.DailySummarySectionAwaitingResponse) {
+			awaiting, renderErr := views.RenderAwaitingResponse(awaitingResponseEvents(events, now), timezone)
+			if renderErr != nil {
+				return "", nil, renderErr
+			}
+			recap = append(recap, awaiting)
+		}
+		sections = append(sections, strings.Join(recap, "\n\n"))
+	}
+
+	return strings.Join(sections, "\n\n"), attachments, nil
+}
+
+// dailySummaryActions returns the actions of an event of the interactive summary: the response to the
+// invitations still needing one, joining the online meetings, and linking the event to a channel.
+func dailySummaryActions(e *remote.Event, actionURL string) []*model.PostAction {
+	actions := []*model.PostAction{}
+	if !e.IsOrganizer && !e.IsCancelled && e.ResponseStatus != nil && e.ResponseStatus.Response == ResponseNone {
+		actions = append(actions, NewPostActionForEventResponse(e.ID, e.ResponseStatus.Response, actionURL+config.PathRespond)...)
+	}
+	if joinURL := e.JoinURL(); joinURL != "" && !e.IsCancelled {
+		actions = append(actions, NewPostActionForJoinMeeting(e.ID, joinURL, actionURL+config.PathJoinMeeting))
+	}
+	if !e.IsCancelled {
+		actions = append(actions, NewPostActionForLinkToChannel(e.ID, actionURL+config.PathLinkEvent))
+	}
+	return actions
+}
+
+// eventsBetween returns the events overlapping the time range.
+func eventsBetween(events []*remote.Event, from, to time.Time) []*remote.Event {
+	result := []*remote.Event{}
+	for _, e := range events {
+		if e.Start.Time().Before(to) && e.End.Time().After(from) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// scheduledEvents returns the events the user did not decline, and that were not cancelled.
+func scheduledEvents(events []*remote.Event) []*remote.Event {
+	result := []*remote.Event{}
+	for _, e := range events {
+		if e.IsCancelled {
+			continue
+		}
+		if e.ResponseStatus != nil && e.ResponseStatus.Response == ResponseNo {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// isAllDayOutOfOffice returns whether the event is an all-day out of office event on the day starting at
+// dayStart. All-day events are compared by date, as they start at midnight in the time zone of the calendar.
+func isAllDayOutOfOffice(e *remote.Event, dayStart time.Time) bool {
+	if !e.IsAllDay || e.IsCancelled || e.ShowAs != remote.ScheduleStatusOof || e.Start == nil || e.End == nil {
+		return false
+	}
+	day := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), 0, 0, 0, 0, time.UTC)
+	start, end := e.Start.Time(), e.End.Time()
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	endDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return !day.Before(startDay) && day.Before(endDay)
+}
+
+// recapMeetings returns the meetings the user attended, sorted by start time.
+func recapMeetings(events []*remote.Event) []*remote.Event {
+	result := []*remote.Event{}
+	for _, e := range events {
+		if e.IsCancelled || e.IsAllDay || e.ShowAs == "free" || len(e.Attendees) == 0 {
+			continue
+		}
+		if e.ResponseStatus != nil && e.ResponseStatus.Response == ResponseNo {
+			continue
+		}
+		result = append(result, e)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start.Time().Before(result[j].Start.Time())
+	})
+	return result
+}
+
+// meetingTimePerDay returns the time spent in the meetings for each weekday of the week starting on weekStart.
+func meetingTimePerDay(meetings []*remote.Event, weekStart time.Time, loc *time.Location) []views.DayMeetingTime {
+	days := []views.DayMeetingTime{}
+	for day := weekStart; day.Weekday() != time.Saturday; day = day.AddDate(0, 0, 1) {
+		dayEnd := day.AddDate(0, 0, 1)
+		dayMeetingTime := views.DayMeetingTime{Day: day.In(loc)}
+
+		// The meetings are sorted, so overlaps are counted once by moving the covered time forward
+		covered := day
+		for _, e := range meetings {
+			start, end := e.Start.Time(), e.End.Time()
+			if !start.Before(dayEnd) || !end.After(day) {
+				continue
+			}
+			dayMeetingTime.Count++
+
+			if start.Before(covered) {
+				start = covered
+			}
+			if end.After(dayEnd) {
+				end = dayEnd
+			}
+			if end.After(start) {
+				dayMeetingTime.Duration += end.Sub(start)
+				covered = end
+			}
+		}
+		days = append(days, dayMeetingTime)
+	}
+	return days
+}
+
+// backToBackStreaks returns the series of at least two meetings without a break between them.
+func backToBackStreaks(meetings []*remote.Event, loc *time.Location) []views.BackToBackStreak {
+	streaks := []views.BackToBackStreak{}
+	var current *views.BackToBackStreak
+	for _, e := range meetings {
+		start, end := e.Start.Time().In(loc), e.End.Time().In(loc)
+		if current != nil && start.Sub(current.End) < backToBackMaxGap {
+			current.Count++
+			if end.After(current.End) {
+				current.End = end
+			}
+			continue
+		}
+
+		if current != nil && current.Count > 1 {
+			streaks = append(streaks, *current)
+		}
+		current = &views.BackToBackStreak{Start: start, End: end, Count: 1}
+	}
+	if current != nil && current.Count > 1 {
+		streaks = append(streaks, *current)
+	}
+	return streaks
+}
+
+// awaitingResponseEvents returns the upcoming invitations the user did not respond to.
+func awaitingResponseEvents(events []*remote.Event, now time.Time) []*remote.Event {
+	result := []*remote.Event{}
+	for _, e := range events {
+		if e.IsCancelled || e.IsOrganizer || !e.Start.Time().After(now) || e.ResponseStatus == nil {
+			continue
+		}
+		if e.ResponseStatus.Response == ResponseNone || e.ResponseStatus.Response == "none" {
+			result = append(result, e)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start.Time().Before(result[j].Start.Time())
+	})
+	return result
+}
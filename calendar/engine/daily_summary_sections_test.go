@@ -0,0 +1,234 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func makeRecapEvent(subject string, start time.Time, d time.Duration) *remote.Event {
+	return &remote.Event{
+		Subject:   subject,
+		ShowAs:    "busy",
+		Start:     remote.NewDateTime(start, "UTC"),
+		End:       remote.NewDateTime(start.Add(d), "UTC"),
+		Attendees: []*remote.Attendee{{}},
+	}
+}
+
+func TestDailySummaryRange(t *testing.T) {
+	monday := time.Date(2024, time.May, 13, 8, 0, 0, 0, time.UTC)
+	friday := time.Date(2024, time.May, 17, 8, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		sections      []string
+		now           time.Time
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		"today only": {
+			now:           monday,
+			expectedStart: time.Date(2024, time.May, 13, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2024, time.May, 14, 0, 0, 0, 0, time.UTC),
+		},
+		"week preview on Monday": {
+			sections:      []string{store.DailySummarySectionWeekPreview},
+			now:           monday,
+			expectedStart: time.Date(2024, time.May, 13, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2024, time.May, 20, 0, 0, 0, 0, time.UTC),
+		},
+		"week preview on Friday": {
+			sections:      []string{store.DailySummarySectionWeekPreview},
+			now:           friday,
+			expectedStart: time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2024, time.May, 18, 0, 0, 0, 0, time.UTC),
+		},
+		"recap on Friday": {
+			sections:      []string{store.DailySummarySectionMeetingHours},
+			now:           friday,
+			expectedStart: time.Date(2024, time.May, 13, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2024, time.May, 18, 0, 0, 0, 0, time.UTC),
+		},
+		"recap with the invitations awaiting a response": {
+			sections:      []string{store.DailySummarySectionAwaitingResponse},
+			now:           friday,
+			expectedStart: time.Date(2024, time.May, 13, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   friday.Add(awaitingResponseWindow),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			start, end := dailySummaryRange(&store.DailySummaryUserSettings{Sections: tc.sections}, tc.now, "UTC")
+			require.True(t, tc.expectedStart.Equal(start), "expected start %v, got %v", tc.expectedStart, start)
+			require.True(t, tc.expectedEnd.Equal(end), "expected end %v, got %v", tc.expectedEnd, end)
+		})
+	}
+}
+
+func TestMeetingTimePerDay(t *testing.T) {
+	monday := time.Date(2024, time.May, 13, 0, 0, 0, 0, time.UTC)
+	meetings := recapMeetings([]*remote.Event{
+		makeRecapEvent("Standup", monday.Add(9*time.Hour), 30*time.Minute),
+		makeRecapEvent("Overlapping", monday.Add(9*time.Hour+15*time.Minute), 30*time.Minute),
+		makeRecapEvent("Planning", monday.Add(14*time.Hour), time.Hour),
+		makeRecapEvent("Review", monday.AddDate(0, 0, 2).Add(10*time.Hour), 2*time.Hour),
+		{Subject: "Focus", ShowAs: "busy", Start: remote.NewDateTime(monday.Add(11*time.Hour), "UTC"), End: remote.NewDateTime(monday.Add(12*time.Hour), "UTC")},
+	})
+
+	days := meetingTimePerDay(meetings, monday, time.UTC)
+	require.Len(t, days, 5)
+	require.Equal(t, time.Monday, days[0].Day.Weekday())
+	require.Equal(t, 3, days[0].Count)
+	require.Equal(t, time.Hour+45*time.Minute, days[0].Duration)
+	require.Equal(t, time.Duration(0), days[1].Duration)
+	require.Equal(t, 2*time.Hour, days[2].Duration)
+}
+
+func TestBackToBackStreaks(t *testing.T) {
+	monday := time.Date(2024, time.May, 13, 0, 0, 0, 0, time.UTC)
+	meetings := recapMeetings([]*remote.Event{
+		makeRecapEvent("1", monday.Add(9*time.Hour), time.Hour),
+		makeRecapEvent("2", monday.Add(10*time.Hour), 30*time.Minute),
+		makeRecapEvent("3", monday.Add(10*time.Hour+32*time.Minute), time.Hour),
+		makeRecapEvent("Alone", monday.Add(14*time.Hour), time.Hour),
+		makeRecapEvent("4", monday.Add(16*time.Hour), time.Hour),
+		makeRecapEvent("5", monday.Add(17*time.Hour), time.Hour),
+	})
+
+	streaks := backToBackStreaks(meetings, time.UTC)
+	require.Len(t, streaks, 2)
+	require.Equal(t, 3, streaks[0].Count)
+	require.Equal(t, monday.Add(9*time.Hour), streaks[0].Start)
+	require.Equal(t, monday.Add(11*time.Hour+32*time.Minute), streaks[0].End)
+	require.Equal(t, 2, streaks[1].Count)
+}
+
+func TestAwaitingResponseEvents(t *testing.T) {
+	now := time.Date(2024, time.May, 17, 8, 0, 0, 0, time.UTC)
+	pending := makeRecapEvent("Pending", now.Add(24*time.Hour), time.Hour)
+	pending.ResponseStatus = &remote.EventResponseStatus{Response: ResponseNone}
+	accepted := makeRecapEvent("Accepted", now.Add(24*time.Hour), time.Hour)
+	accepted.ResponseStatus = &remote.EventResponseStatus{Response: ResponseYes}
+	past := makeRecapEvent("Past", now.Add(-time.Hour), time.Hour)
+	past.ResponseStatus = &remote.EventResponseStatus{Response: ResponseNone}
+	organized := makeRecapEvent("Organized", now.Add(time.Hour), time.Hour)
+	organized.ResponseStatus = &remote.EventResponseStatus{Response: "none"}
+	organized.IsOrganizer = true
+
+	result := awaitingResponseEvents([]*remote.Event{pending, accepted, past, organized}, now)
+	require.Equal(t, []*remote.Event{pending}, result)
+}
+
+func TestRenderDailySummary(t *testing.T) {
+	friday := time.Date(2024, time.May, 17, 8, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, time.May, 13, 0, 0, 0, 0, time.UTC)
+	events := []*remote.Event{
+		makeRecapEvent("Standup", monday.Add(9*time.Hour), 30*time.Minute),
+		makeRecapEvent("Retro", friday.Add(2*time.Hour), time.Hour),
+	}
+
+	dsum := &store.DailySummaryUserSettings{Sections: []string{store.DailySummarySectionMeetingHours}}
+	summary, _, err := renderDailySummary(dsum, events, friday, "UTC", "")
+	require.NoError(t, err)
+	require.Contains(t, summary, "#### Your week in review")
+	require.Contains(t, summary, "| Monday | 1 | 0h30 |")
+	require.Contains(t, summary, "| **Total** | | **1h30** |")
+	require.NotContains(t, summary, "Your day")
+
+	summary, _, err = renderDailySummary(dsum, events, friday.AddDate(0, 0, -1), "UTC", "")
+	require.NoError(t, err)
+	require.Empty(t, summary)
+
+	dsum.Sections = []string{store.DailySummarySectionToday, store.DailySummarySectionBackToBack}
+	summary, _, err = renderDailySummary(dsum, events, friday, "UTC", "")
+	require.NoError(t, err)
+	require.Contains(t, summary, "#### Your day\n")
+	require.Contains(t, summary, "You had no back-to-back meetings this week.")
+}
+
+func TestRenderDailySummaryEmptyDay(t *testing.T) {
+	tuesday := time.Date(2024, time.May, 14, 8, 0, 0, 0, time.UTC)
+	declined := makeRecapEvent("Declined", tuesday.Add(2*time.Hour), time.Hour)
+	declined.ResponseStatus = &remote.EventResponseStatus{Response: ResponseNo}
+	cancelled := makeRecapEvent("Cancelled", tuesday.Add(4*time.Hour), time.Hour)
+	cancelled.IsCancelled = true
+	events := []*remote.Event{declined, cancelled}
+
+	summary, _, err := renderDailySummary(&store.DailySummaryUserSettings{}, events, tuesday, "UTC", "")
+	require.NoError(t, err)
+	require.Equal(t, "Nothing scheduled today.", summary)
+
+	dsum := &store.DailySummaryUserSettings{Skip: []string{store.DailySummarySkipEmptyDays}}
+	summary, _, err = renderDailySummary(dsum, events, tuesday, "UTC", "")
+	require.NoError(t, err)
+	require.Empty(t, summary)
+
+	dsum.Sections = []string{store.DailySummarySectionToday, store.DailySummarySectionMeetingHours}
+	summary, _, err = renderDailySummary(dsum, events, tuesday.AddDate(0, 0, 3), "UTC", "")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(summary, "#### Your week in review"))
+}
+
+func TestIsAllDayOutOfOffice(t *testing.T) {
+	dayStart := time.Date(2024, time.May, 14, 0, 0, 0, 0, time.FixedZone("PDT", -7*3600))
+	makeAllDay := func(showAs string, startDay, endDay int) *remote.Event {
+		return &remote.Event{
+			ShowAs:   showAs,
+			IsAllDay: true,
+			Start:    remote.NewDateTime(time.Date(2024, time.May, startDay, 0, 0, 0, 0, time.UTC), "UTC"),
+			End:      remote.NewDateTime(time.Date(2024, time.May, endDay, 0, 0, 0, 0, time.UTC), "UTC"),
+		}
+	}
+
+	require.True(t, isAllDayOutOfOffice(makeAllDay("oof", 14, 15), dayStart))
+	require.True(t, isAllDayOutOfOffice(makeAllDay("oof", 13, 17), dayStart))
+	require.False(t, isAllDayOutOfOffice(makeAllDay("oof", 15, 16), dayStart))
+	require.False(t, isAllDayOutOfOffice(makeAllDay("oof", 13, 14), dayStart))
+	require.False(t, isAllDayOutOfOffice(makeAllDay("busy", 14, 15), dayStart))
+	require.False(t, isAllDayOutOfOffice(makeRecapEvent("Not all day", dayStart, 24*time.Hour), dayStart))
+}
+
+func TestRenderInteractiveDailySummary(t *testing.T) {
+	tuesday := time.Date(2024, time.May, 14, 8, 0, 0, 0, time.UTC)
+	pending := makeRecapEvent("Pending", tuesday.Add(3*time.Hour), time.Hour)
+	pending.ID = "pending"
+	pending.ResponseStatus = &remote.EventResponseStatus{Response: ResponseNone}
+	pending.OnlineMeeting = &remote.OnlineMeetingInfo{JoinURL: "https://teams.example.com/join"}
+	accepted := makeRecapEvent("Accepted", tuesday.Add(time.Hour), time.Hour)
+	accepted.ID = "accepted"
+	accepted.ResponseStatus = &remote.EventResponseStatus{Response: ResponseYes}
+	tomorrow := makeRecapEvent("Tomorrow", tuesday.Add(24*time.Hour), time.Hour)
+
+	dsum := &store.DailySummaryUserSettings{Interactive: true}
+	summary, attachments, err := renderDailySummary(dsum, []*remote.Event{pending, accepted, tomorrow}, tuesday, "UTC", "/action")
+	require.NoError(t, err)
+	require.Equal(t, "Agenda for Tuesday, 14 May.\nTimes are shown in UTC", summary)
+	require.Len(t, attachments, 2)
+
+	require.Equal(t, "Accepted", attachments[0].Title)
+	require.Len(t, attachments[0].Actions, 1)
+	require.Equal(t, "/action"+config.PathLinkEvent, attachments[0].Actions[0].Integration.URL)
+	require.Equal(t, "channels", attachments[0].Actions[0].DataSource)
+
+	require.Equal(t, "Pending", attachments[1].Title)
+	require.Len(t, attachments[1].Actions, 3)
+	require.Equal(t, "/action"+config.PathRespond, attachments[1].Actions[0].Integration.URL)
+	require.Equal(t, "pending", attachments[1].Actions[0].Integration.Context[config.EventIDKey])
+	require.Equal(t, OptionNotResponded, attachments[1].Actions[0].DefaultOption)
+	require.Equal(t, "/action"+config.PathJoinMeeting, attachments[1].Actions[1].Integration.URL)
+	require.Equal(t, "https://teams.example.com/join", attachments[1].Actions[1].Integration.Context[config.JoinURLKey])
+	require.Equal(t, "pending", attachments[1].Actions[2].Integration.Context[config.EventIDKey])
+
+	summary, attachments, err = renderDailySummary(dsum, []*remote.Event{tomorrow}, tuesday, "UTC", "/action")
+	require.NoError(t, err)
+	require.Equal(t, "Nothing scheduled today.", summary)
+	require.Empty(t, attachments)
+}
@@ -110,7 +110,7 @@ func TestProcessAllDailySummary(t *testing.T) {
 			err:  "index store error",
 			runAssertions: func(deps *Dependencies, client remote.Client) {
 				s := deps.Store.(*mock_store.MockStore)
-				s.EXPECT().LoadUserIndex().Return(nil, errors.New("index store error"))
+				s.EXPECT().ForEachUserIndexPage(gomock.Any()).Return(errors.New("index store error"))
 			},
 		},
 		{
@@ -118,18 +118,90 @@ func TestProcessAllDailySummary(t *testing.T) {
 			err:  "",
 			runAssertions: func(deps *Dependencies, client remote.Client) {
 				s := deps.Store.(*mock_store.MockStore)
-				s.EXPECT().LoadUserIndex().Return(store.UserIndex{}, nil)
+				s.EXPECT().ForEachUserIndexPage(gomock.Any()).Return(nil)
 			},
 		},
 		{
-			name: "Error fetching events",
-			err:  "error fetching events",
+			name: "Error fetching events of a page",
+			err:  "",
 			runAssertions: func(deps *Dependencies, client remote.Client) {
 				s := deps.Store.(*mock_store.MockStore)
-				s.EXPECT().LoadUserIndex().Return(store.UserIndex{{
+				s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(func(f func(store.UserIndex) error) error {
+					err := f(store.UserIndex{{
+						MattermostUserID: "user1_mm_id",
+						RemoteID:         "user1_remote_id",
+					}})
+					if err != nil {
+						return err
+					}
+					return f(store.UserIndex{{
+						MattermostUserID: "user2_mm_id",
+						RemoteID:         "user2_remote_id",
+					}})
+				})
+
+				s.EXPECT().LoadUser("user1_mm_id").Return(&store.User{
 					MattermostUserID: "user1_mm_id",
-					RemoteID:         "user1_remote_id",
-				}}, nil)
+					Remote:           &remote.User{ID: "user1_remote_id"},
+					Settings: store.Settings{
+						DailySummary: &store.DailySummaryUserSettings{
+							Enable:       true,
+							PostTime:     "9:00AM",
+							Timezone:     "Eastern Standard Time",
+							LastPostTime: "",
+						},
+					},
+				}, nil)
+				s.EXPECT().LoadUser("user2_mm_id").Return(&store.User{
+					MattermostUserID: "user2_mm_id",
+					Remote:           &remote.User{ID: "user2_remote_id"},
+					Settings: store.Settings{
+						DailySummary: &store.DailySummaryUserSettings{
+							Enable:       true,
+							PostTime:     "9:00AM",
+							Timezone:     "Eastern Standard Time",
+							LastPostTime: "",
+						},
+					},
+				}, nil)
+
+				mockClient := client.(*mock_remote.MockClient)
+				mockRemote := deps.Remote.(*mock_remote.MockRemote)
+				mockRemote.EXPECT().MakeSuperuserClient(context.Background()).Return(mockClient, nil).Times(1)
+
+				gomock.InOrder(
+					mockClient.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).Return([]*remote.ViewCalendarResponse{}, errors.New("error fetching events")),
+					mockClient.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).Return([]*remote.ViewCalendarResponse{
+						{RemoteUserID: "user2_remote_id", Events: []*remote.Event{}},
+					}, nil),
+				)
+				mockPoster := deps.Poster.(*mock_bot.MockPoster)
+				mockPoster.EXPECT().DM("user2_mm_id", "Nothing scheduled today.").Return("post_id", nil)
+				s.EXPECT().StoreUser(gomock.Any()).DoAndReturn(func(u *store.User) error {
+					require.Equal(t, "user2_mm_id", u.MattermostUserID)
+					return nil
+				})
+
+				mockLogger := deps.Logger.(*mock_bot.MockLogger)
+				mockLogger.EXPECT().Warnf("Error processing the daily summary of %d users, continuing with the next page. err=%v", 1, gomock.Any())
+				mockLogger.EXPECT().Infof("Processed daily summary for %d users", 1)
+			},
+		},
+		{
+			name: "Error loading a shard of the index",
+			err:  "error loading user index shard 3: shard error",
+			runAssertions: func(deps *Dependencies, client remote.Client) {
+				s := deps.Store.(*mock_store.MockStore)
+				s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(func(f func(store.UserIndex) error) error {
+					err := f(store.UserIndex{{
+						MattermostUserID: "user1_mm_id",
+						RemoteID:         "user1_remote_id",
+					}})
+					if err != nil {
+						return err
+					}
+					return store.UserIndexShardErrors{3: errors.New("shard error")}
+				})
 
 				s.EXPECT().LoadUser("user1_mm_id").Return(&store.User{
 					MattermostUserID: "user1_mm_id",
@@ -147,8 +219,16 @@ func TestProcessAllDailySummary(t *testing.T) {
 				mockClient := client.(*mock_remote.MockClient)
 				mockRemote := deps.Remote.(*mock_remote.MockRemote)
 				mockRemote.EXPECT().MakeSuperuserClient(context.Background()).Return(mockClient, nil).Times(1)
+				mockClient.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).Return([]*remote.ViewCalendarResponse{
+					{RemoteUserID: "user1_remote_id", Events: []*remote.Event{}},
+				}, nil)
 
-				mockClient.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).Return([]*remote.ViewCalendarResponse{}, errors.New("error fetching events"))
+				mockPoster := deps.Poster.(*mock_bot.MockPoster)
+				mockPoster.EXPECT().DM("user1_mm_id", "Nothing scheduled today.").Return("post_id", nil)
+				s.EXPECT().StoreUser(gomock.Any()).Return(nil)
+
+				mockLogger := deps.Logger.(*mock_bot.MockLogger)
+				mockLogger.EXPECT().Infof("Processed daily summary for %d users", 1)
 			},
 		},
 		{
@@ -156,16 +236,18 @@ func TestProcessAllDailySummary(t *testing.T) {
 			err:  "",
 			runAssertions: func(deps *Dependencies, client remote.Client) {
 				s := deps.Store.(*mock_store.MockStore)
-				s.EXPECT().LoadUserIndex().Return(store.UserIndex{{
-					MattermostUserID: "user1_mm_id",
-					RemoteID:         "user1_remote_id",
-				}, {
-					MattermostUserID: "user2_mm_id",
-					RemoteID:         "user2_remote_id",
-				}, {
-					MattermostUserID: "user3_mm_id",
-					RemoteID:         "user3_remote_id",
-				}}, nil)
+				s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(func(f func(store.UserIndex) error) error {
+					return f(store.UserIndex{{
+						MattermostUserID: "user1_mm_id",
+						RemoteID:         "user1_remote_id",
+					}, {
+						MattermostUserID: "user2_mm_id",
+						RemoteID:         "user2_remote_id",
+					}, {
+						MattermostUserID: "user3_mm_id",
+						RemoteID:         "user3_remote_id",
+					}})
+				})
 
 				s.EXPECT().LoadUser("user1_mm_id").Return(&store.User{
 					MattermostUserID: "user1_mm_id",
@@ -229,7 +311,7 @@ func TestProcessAllDailySummary(t *testing.T) {
 
 				mockPoster := deps.Poster.(*mock_bot.MockPoster)
 				gomock.InOrder(
-					mockPoster.EXPECT().DM("user1_mm_id", "You have no upcoming events.").Return("postID1", nil).Times(1),
+					mockPoster.EXPECT().DM("user1_mm_id", "Nothing scheduled today.").Return("postID1", nil).Times(1),
 					mockPoster.EXPECT().DM("user2_mm_id", `Times are shown in Pacific Standard Time
 Wednesday February 12, 2020
 
@@ -261,16 +343,18 @@ Wednesday February 12, 2020
 				hour, minute := 10, 0 // Time is "10:00AM"
 				moment := makeTime(hour, minute, loc)
 
-				s.EXPECT().LoadUserIndex().Return(store.UserIndex{{
-					MattermostUserID: "user1_mm_id",
-					RemoteID:         "user1_remote_id",
-				}, {
-					MattermostUserID: "user2_mm_id",
-					RemoteID:         "user2_remote_id",
-				}, {
-					MattermostUserID: "user3_mm_id",
-					RemoteID:         "user3_remote_id",
-				}}, nil)
+				s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(func(f func(store.UserIndex) error) error {
+					return f(store.UserIndex{{
+						MattermostUserID: "user1_mm_id",
+						RemoteID:         "user1_remote_id",
+					}, {
+						MattermostUserID: "user2_mm_id",
+						RemoteID:         "user2_remote_id",
+					}, {
+						MattermostUserID: "user3_mm_id",
+						RemoteID:         "user3_remote_id",
+					}})
+				})
 
 				mockRemote.EXPECT().MakeSuperuserClient(context.Background()).Return(nil, remote.ErrSuperUserClientNotSupported).Times(1)
 
@@ -336,7 +420,7 @@ Wednesday February 12, 2020
 
 				mockPoster := deps.Poster.(*mock_bot.MockPoster)
 				gomock.InOrder(
-					mockPoster.EXPECT().DM("user1_mm_id", "You have no upcoming events.").Return("postID1", nil).Times(1),
+					mockPoster.EXPECT().DM("user1_mm_id", "Nothing scheduled today.").Return("postID1", nil).Times(1),
 					mockPoster.EXPECT().DM("user2_mm_id", `Times are shown in Pacific Standard Time
 Wednesday February 12, 2020
 
@@ -350,6 +434,84 @@ Wednesday February 12, 2020
 					return nil
 				})
 
+				mockLogger := deps.Logger.(*mock_bot.MockLogger)
+				mockLogger.EXPECT().Infof("Processed daily summary for %d users", 2)
+			},
+		},
+		{
+			name: "Daily summary is skipped when the user is out of office",
+			err:  "",
+			runAssertions: func(deps *Dependencies, client remote.Client) {
+				s := deps.Store.(*mock_store.MockStore)
+				s.EXPECT().ForEachUserIndexPage(gomock.Any()).DoAndReturn(func(f func(store.UserIndex) error) error {
+					return f(store.UserIndex{{
+						MattermostUserID: "user1_mm_id",
+						RemoteID:         "user1_remote_id",
+					}, {
+						MattermostUserID: "user2_mm_id",
+						RemoteID:         "user2_remote_id",
+					}})
+				})
+
+				s.EXPECT().LoadUser("user1_mm_id").Return(&store.User{
+					MattermostUserID: "user1_mm_id",
+					Remote:           &remote.User{ID: "user1_remote_id"},
+					Settings: store.Settings{
+						DailySummary: &store.DailySummaryUserSettings{
+							Enable:   true,
+							PostTime: "9:00AM",
+							Timezone: "Eastern Standard Time",
+							Skip:     []string{store.DailySummarySkipOutOfOffice},
+						},
+					},
+				}, nil)
+
+				s.EXPECT().LoadUser("user2_mm_id").Return(&store.User{
+					MattermostUserID: "user2_mm_id",
+					Remote:           &remote.User{ID: "user2_remote_id"},
+					Settings: store.Settings{
+						DailySummary: &store.DailySummaryUserSettings{
+							Enable:   true,
+							PostTime: "9:00AM",
+							Timezone: "Eastern Standard Time",
+							Skip:     []string{store.DailySummarySkipOutOfOffice, store.DailySummarySkipEmptyDays},
+						},
+					},
+				}, nil)
+
+				mockClient := client.(*mock_remote.MockClient)
+				mockRemote := deps.Remote.(*mock_remote.MockRemote)
+				mockRemote.EXPECT().MakeSuperuserClient(context.Background()).Return(mockClient, nil).Times(1)
+
+				mockClient.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).Return([]*remote.ViewCalendarResponse{
+					{RemoteUserID: "user1_remote_id", Events: []*remote.Event{}},
+					{RemoteUserID: "user2_remote_id", Events: []*remote.Event{
+						{
+							Subject:  "Vacation",
+							ShowAs:   "oof",
+							IsAllDay: true,
+							Start:    remote.NewDateTime(time.Date(2020, 2, 12, 0, 0, 0, 0, time.UTC), "UTC"),
+							End:      remote.NewDateTime(time.Date(2020, 2, 13, 0, 0, 0, 0, time.UTC), "UTC"),
+						},
+					}},
+				}, nil)
+				s.EXPECT().LoadMailboxSettings("user1_mm_id").Return(nil, store.ErrNotFound)
+				mockClient.EXPECT().GetMailboxSettings("user1_remote_id").Return(&remote.MailboxSettings{
+					TimeZone: "Eastern Standard Time",
+					AutomaticRepliesSetting: remote.AutomaticRepliesSetting{
+						Status: remote.AutomaticRepliesStatusAlwaysEnabled,
+					},
+				}, nil)
+				s.EXPECT().StoreMailboxSettings("user1_mm_id", gomock.Any(), mailboxSettingsTTL).Return(nil)
+
+				mockPoster := deps.Poster.(*mock_bot.MockPoster)
+				mockPoster.EXPECT().DM(gomock.Any(), gomock.Any()).Times(0)
+
+				s.EXPECT().StoreUser(gomock.Any()).Times(2).DoAndReturn(func(u *store.User) error {
+					require.NotEmpty(t, u.Settings.DailySummary.LastPostTime)
+					return nil
+				})
+
 				mockLogger := deps.Logger.(*mock_bot.MockLogger)
 				mockLogger.EXPECT().Infof("Processed daily summary for %d users", 2)
 			},
@@ -402,6 +564,7 @@ func TestShouldPostDailySummary(t *testing.T) {
 		name        string
 		postTime    string
 		timeZone    string
+		days        []string
 		enabled     bool
 		shouldRun   bool
 		shouldError bool
@@ -414,6 +577,24 @@ func TestShouldPostDailySummary(t *testing.T) {
 			shouldRun:   false,
 			shouldError: false,
 		},
+		{
+			name:        "Day not selected",
+			enabled:     true,
+			postTime:    "9:00AM",
+			timeZone:    "Eastern Standard Time",
+			days:        []string{"monday", "saturday"},
+			shouldRun:   false,
+			shouldError: false,
+		},
+		{
+			name:        "Day selected",
+			enabled:     true,
+			postTime:    "9:00AM",
+			timeZone:    "Eastern Standard Time",
+			days:        []string{"wednesday", "sunday"},
+			shouldRun:   true,
+			shouldError: false,
+		},
 		{
 			name:        "Same timezone, wrong time",
 			enabled:     true,
@@ -487,6 +668,7 @@ func TestShouldPostDailySummary(t *testing.T) {
 				Enable:   tc.enabled,
 				PostTime: tc.postTime,
 				Timezone: tc.timeZone,
+				Days:     tc.days,
 			}
 
 			hour, minute := 9, 0 // Time is "9:00AM"
@@ -4,7 +4,13 @@
 package engine
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
 )
 
 type EventResponder interface {
@@ -12,6 +18,49 @@ type EventResponder interface {
 	DeclineEvent(user *User, eventID string) error
 	TentativelyAcceptEvent(user *User, eventID string) error
 	RespondToEvent(user *User, eventID, response string) error
+	ProposeNewTime(user *User, eventID string, payload ProposeNewTimePayload) error
+	AcceptProposedTime(user *User, eventID string, proposedTime *remote.TimeSlot) error
+}
+
+// ProposeNewTimePayload describes a new time proposed to the organizer of an event. Response is either
+// OptionNo or OptionMaybe, and Date, StartTime and EndTime are interpreted in the user mailbox timezone.
+type ProposeNewTimePayload struct {
+	Response  string
+	Date      string
+	StartTime string
+	EndTime   string
+	Comment   string
+}
+
+func (pnt ProposeNewTimePayload) ToTimeSlot(loc *time.Location, now time.Time) (*remote.TimeSlot, error) {
+	start, err := time.ParseInLocation(CreateEventDateTimeFormat, fmt.Sprintf("%s %s", pnt.Date, pnt.StartTime), loc)
+	if err != nil {
+		return nil, fmt.Errorf("please use a valid date and start time")
+	}
+
+	end, err := time.ParseInLocation(CreateEventDateTimeFormat, fmt.Sprintf("%s %s", pnt.Date, pnt.EndTime), loc)
+	if err != nil {
+		return nil, fmt.Errorf("please use a valid end time")
+	}
+
+	if start.Before(now) {
+		return nil, fmt.Errorf("please select a start date and time that is not prior to the current time")
+	}
+
+	if !end.After(start) {
+		return nil, fmt.Errorf("end date must be later than start date")
+	}
+
+	return &remote.TimeSlot{
+		Start: &remote.DateTime{
+			DateTime: start.Format(remote.RFC3339NanoNoTimezone),
+			TimeZone: loc.String(),
+		},
+		End: &remote.DateTime{
+			DateTime: end.Format(remote.RFC3339NanoNoTimezone),
+			TimeZone: loc.String(),
+		},
+	}, nil
 }
 
 func (m *mscalendar) AcceptEvent(user *User, eventID string) error {
@@ -74,3 +123,57 @@ func (m *mscalendar) RespondToEvent(user *User, eventID, response string) error
 		return errors.New(response + " is not a valid response")
 	}
 }
+
+func (m *mscalendar) ProposeNewTime(user *User, eventID string, payload ProposeNewTimePayload) error {
+	var response string
+	switch payload.Response {
+	case OptionNo:
+		response = remote.EventResponseStatusDeclined
+	case OptionMaybe:
+		response = remote.EventResponseStatusTentative
+	default:
+		return fmt.Errorf("%w: %s is not a valid response when proposing a new time", ErrInvalidEventPayload, payload.Response)
+	}
+
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return err
+	}
+
+	mailbox, err := m.client.GetMailboxSettings(user.Remote.ID)
+	if err != nil {
+		return errors.Wrap(err, "error getting mailbox settings")
+	}
+
+	loc, err := time.LoadLocation(tz.Go(mailbox.TimeZone))
+	if err != nil {
+		return errors.Wrapf(err, "error loading mailbox timezone %s", mailbox.TimeZone)
+	}
+
+	proposedTime, err := payload.ToTimeSlot(loc, time.Now())
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidEventPayload, err.Error())
+	}
+
+	return m.client.ProposeNewTime(user.Remote.ID, eventID, response, proposedTime, payload.Comment)
+}
+
+// AcceptProposedTime moves an event organized by the user to the time proposed by one of the attendees.
+func (m *mscalendar) AcceptProposedTime(user *User, eventID string, proposedTime *remote.TimeSlot) error {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.UpdateEvent(user.Remote.ID, eventID, &remote.Event{
+		Start: proposedTime.Start,
+		End:   proposedTime.End,
+	})
+	return err
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+)
+
+// ExportCalendar returns the events of the user between from and to as an iCalendar object.
+func (m *mscalendar) ExportCalendar(user *User, from, to time.Time) (string, error) {
+	if !to.After(from) {
+		return "", fmt.Errorf("%w: the export range must end after it starts", ErrInvalidEventPayload)
+	}
+
+	events, err := m.ViewCalendar(user, from, to)
+	if err != nil {
+		return "", errors.Wrap(err, "error getting events to export")
+	}
+
+	return views.RenderICalendar(events, time.Now()), nil
+}
+
+// SendCalendarExport uploads the events of the user between from and to as an .ics file in the DM with the user.
+func (m *mscalendar) SendCalendarExport(user *User, from, to time.Time) error {
+	ics, err := m.ExportCalendar(user, from, to)
+	if err != nil {
+		return err
+	}
+
+	// The end of the range is excluded
+	last := to.AddDate(0, 0, -1)
+	fileName := "calendar-" + from.Format(CreateEventDateFormat)
+	message := fmt.Sprintf("Here are your events of %s.", from.Format("Monday, January 2"))
+	if last.After(from) {
+		fileName += "-" + last.Format(CreateEventDateFormat)
+		message = fmt.Sprintf("Here are your events from %s to %s.", from.Format("Monday, January 2"), last.Format("Monday, January 2"))
+	}
+
+	_, err = m.Poster.DMWithFile(user.MattermostUserID, message, fileName+views.ICalendarExtension, []byte(ics))
+	if err != nil {
+		return errors.Wrap(err, "error sending the calendar export")
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	FindTimeDefaultSubject = "Meeting"
+	findTimeMaxCandidates  = 5
+)
+
+// FindTimePayload describes a search for a time slot that suits the user and the given attendees,
+// identified by their Mattermost user IDs.
+type FindTimePayload struct {
+	Attendees []string
+	Duration  time.Duration
+	From      time.Time
+	To        time.Time
+	Subject   string
+}
+
+// FindTime looks for meeting times suiting the user and the attendees between From and To, and sends the
+// ranked suggestions to the user, each of them with a button to book the slot.
+func (m *mscalendar) FindTime(user *User, payload FindTimePayload) (*remote.MeetingTimeSuggestionResults, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload.Attendees) == 0 {
+		return nil, fmt.Errorf("%w: please mention at least one attendee", ErrInvalidEventPayload)
+	}
+	if payload.Duration < time.Minute {
+		return nil, fmt.Errorf("%w: the meeting must last at least one minute", ErrInvalidEventPayload)
+	}
+	if !payload.To.After(payload.From) {
+		return nil, fmt.Errorf("%w: the search range must end after it starts", ErrInvalidEventPayload)
+	}
+	if payload.Subject == "" {
+		payload.Subject = FindTimeDefaultSubject
+	}
+
+	attendeeNames := map[string]string{}
+	params := &remote.FindMeetingTimesParameters{
+		MeetingDuration: remote.FormatMeetingDuration(payload.Duration),
+		MaxCandidates:   model.NewInt(findTimeMaxCandidates),
+		TimeConstraint: &remote.TimeConstraint{
+			ActivityDomain: "work",
+			TimeSlots: []remote.TimeSlot{{
+				Start: remote.NewDateTime(payload.From.UTC(), "UTC"),
+				End:   remote.NewDateTime(payload.To.UTC(), "UTC"),
+			}},
+		},
+		ReturnSuggestionReasons: model.NewBool(true),
+	}
+	for _, mattermostUserID := range payload.Attendees {
+		attendee, errLoad := m.Store.LoadUser(mattermostUserID)
+		if errors.Is(errLoad, store.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s has not connected their %s account", ErrInvalidEventPayload, m.mattermostUserMarkdown(mattermostUserID), config.Provider.DisplayName)
+		}
+		if errLoad != nil {
+			return nil, errors.Wrap(errLoad, "error loading attendee")
+		}
+
+		attendeeNames[strings.ToLower(attendee.Remote.Mail)] = m.mattermostUserMarkdown(mattermostUserID)
+		params.Attendees = append(params.Attendees, remote.Attendee{
+			Type: "required",
+			EmailAddress: &remote.EmailAddress{
+				Address: attendee.Remote.Mail,
+			},
+		})
+	}
+
+	mailbox, err := m.client.GetMailboxSettings(user.Remote.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting mailbox settings")
+	}
+
+	loc, err := time.LoadLocation(tz.Go(mailbox.TimeZone))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading mailbox timezone %s", mailbox.TimeZone)
+	}
+
+	results, err := m.client.FindMeetingTimes(user.Remote.ID, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "error finding meeting times")
+	}
+	results.MeetingTimeSuggestions = bookableMeetingTimes(results.MeetingTimeSuggestions, payload.From, payload.To, loc)
+	if len(results.MeetingTimeSuggestions) == 0 {
+		return results, nil
+	}
+
+	sortMeetingTimeSuggestions(results.MeetingTimeSuggestions)
+
+	attachments := []*model.SlackAttachment{}
+	for i, suggestion := range results.MeetingTimeSuggestions {
+		sa, errRender := m.meetingTimeSuggestionAttachment(i+1, suggestion, payload, attendeeNames, loc)
+		if errRender != nil {
+			m.Logger.With(bot.LogContext{"err": errRender.Error()}).Warnf("Failed to render meeting time suggestion.")
+			continue
+		}
+		attachments = append(attachments, sa)
+	}
+
+	_, err = m.Poster.DMWithMessageAndAttachments(user.MattermostUserID,
+		fmt.Sprintf("Here are the best times for a %s meeting with %s. Times are shown in %s.", formatMeetingDuration(payload.Duration), joinAttendeeNames(attendeeNames), loc.String()),
+		attachments...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending meeting time suggestions")
+	}
+
+	return results, nil
+}
+
+func (m *mscalendar) meetingTimeSuggestionAttachment(rank int, suggestion *remote.MeetingTimeSuggestion, payload FindTimePayload, attendeeNames map[string]string, loc *time.Location) (*model.SlackAttachment, error) {
+	start := suggestion.MeetingTimeSlot.Start.Time().In(loc)
+	end := suggestion.MeetingTimeSlot.End.Time().In(loc)
+	when := fmt.Sprintf("%s · (%s - %s)", start.Format("Monday, January 2"), start.Format(time.Kitchen), end.Format(time.Kitchen))
+
+	fields := []*model.SlackAttachmentField{
+		{
+			Title: "You",
+			Value: formatAvailability(suggestion.OrganizerAvailability),
+			Short: true,
+		},
+	}
+	for _, aa := range suggestion.AttendeeAvailability {
+		if aa.Attendee == nil || aa.Attendee.EmailAddress == nil {
+			continue
+		}
+		name, ok := attendeeNames[strings.ToLower(aa.Attendee.EmailAddress.Address)]
+		if !ok {
+			name = aa.Attendee.EmailAddress.Address
+		}
+		fields = append(fields, &model.SlackAttachmentField{
+			Title: name,
+			Value: formatAvailability(aa.Availability),
+			Short: true,
+		})
+	}
+
+	eventPayload, err := json.Marshal(CreateEventPayload{
+		Subject:   payload.Subject,
+		Attendees: payload.Attendees,
+		Date:      start.Format(CreateEventDateFormat),
+		StartTime: start.Format(CreateEventTimeFormat),
+		EndTime:   end.Format(CreateEventTimeFormat),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	text := fmt.Sprintf("Confidence: %.0f%%", suggestion.Confidence)
+	if suggestion.SuggestionReason != "" {
+		text += "\n" + suggestion.SuggestionReason
+	}
+
+	return &model.SlackAttachment{
+		Title:    fmt.Sprintf("%d. %s", rank, when),
+		Text:     text,
+		Fallback: fmt.Sprintf("%d. %s (confidence %.0f%%)", rank, when, suggestion.Confidence),
+		Fields:   fields,
+		Actions: []*model.PostAction{
+			NewPostActionForBookMeetingTime(string(eventPayload), m.Config.PluginURLPath+config.PathPostAction+config.PathBookMeetingTime),
+		},
+	}, nil
+}
+
+func NewPostActionForBookMeetingTime(eventPayload, url string) *model.PostAction {
+	return &model.PostAction{
+		Name:  "Book this time",
+		Type:  model.PostActionTypeButton,
+		Style: "primary",
+		Integration: &model.PostActionIntegration{
+			URL: url,
+			Context: map[string]interface{}{
+				config.EventPayloadKey: eventPayload,
+			},
+		},
+	}
+}
+
+func (m *mscalendar) mattermostUserMarkdown(mattermostUserID string) string {
+	mattermostUser, err := m.PluginAPI.GetMattermostUser(mattermostUserID)
+	if err != nil {
+		return mattermostUserID
+	}
+	return "@" + mattermostUser.Username
+}
+
+// bookableMeetingTimes keeps the suggestions within the search window that start and end on the same day in the
+// time zone of the user, as the booking buttons create events on a single date.
+func bookableMeetingTimes(suggestions []*remote.MeetingTimeSuggestion, from, to time.Time, loc *time.Location) []*remote.MeetingTimeSuggestion {
+	bookable := []*remote.MeetingTimeSuggestion{}
+	for _, suggestion := range suggestions {
+		if suggestion.MeetingTimeSlot == nil || suggestion.MeetingTimeSlot.Start == nil || suggestion.MeetingTimeSlot.End == nil {
+			continue
+		}
+
+		start := suggestion.MeetingTimeSlot.Start.Time().In(loc)
+		end := suggestion.MeetingTimeSlot.End.Time().In(loc)
+		if start.Before(from) || end.After(to) {
+			continue
+		}
+		if start.Format(CreateEventDateFormat) != end.Format(CreateEventDateFormat) {
+			continue
+		}
+		bookable = append(bookable, suggestion)
+	}
+	return bookable
+}
+
+// sortMeetingTimeSuggestions orders the suggestions by the rank given by the remote, then by confidence.
+func sortMeetingTimeSuggestions(suggestions []*remote.MeetingTimeSuggestion) {
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Order != suggestions[j].Order {
+			return suggestions[i].Order < suggestions[j].Order
+		}
+		return suggestions[i].Confidence > suggestions[j].Confidence
+	})
+}
+
+func formatAvailability(availability string) string {
+	switch availability {
+	case "free":
+		return "Free"
+	case "tentative":
+		return "Tentative"
+	case "busy":
+		return "Busy"
+	case "oof":
+		return "Out of office"
+	case "workingElsewhere":
+		return "Working elsewhere"
+	}
+	return "Unknown"
+}
+
+func formatMeetingDuration(d time.Duration) string {
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh%dm", hours, minutes)
+}
+
+func joinAttendeeNames(attendeeNames map[string]string) string {
+	names := make([]string, 0, len(attendeeNames))
+	for _, name := range attendeeNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestSortMeetingTimeSuggestions(t *testing.T) {
+	suggestions := []*remote.MeetingTimeSuggestion{
+		{Order: 2, Confidence: 100},
+		{Order: 1, Confidence: 50},
+		{Order: 0, Confidence: 50},
+		{Order: 0, Confidence: 100},
+	}
+
+	sortMeetingTimeSuggestions(suggestions)
+
+	require.Equal(t, []*remote.MeetingTimeSuggestion{
+		{Order: 0, Confidence: 100},
+		{Order: 0, Confidence: 50},
+		{Order: 1, Confidence: 50},
+		{Order: 2, Confidence: 100},
+	}, suggestions)
+}
+
+func TestFormatMeetingDuration(t *testing.T) {
+	for duration, expected := range map[time.Duration]string{
+		30 * time.Minute: "30m",
+		time.Hour:        "1h",
+		90 * time.Minute: "1h30m",
+	} {
+		require.Equal(t, expected, formatMeetingDuration(duration))
+	}
+	require.Equal(t, "PT1H30M", remote.FormatMeetingDuration(90*time.Minute))
+}
+
+func TestBookableMeetingTimes(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	from := time.Date(2024, time.May, 15, 0, 0, 0, 0, loc)
+	to := time.Date(2024, time.May, 17, 0, 0, 0, 0, loc)
+
+	for name, tc := range map[string]struct {
+		start, end time.Time
+		expected   bool
+	}{
+		"within a day": {
+			start:    time.Date(2024, time.May, 15, 10, 0, 0, 0, loc),
+			end:      time.Date(2024, time.May, 15, 11, 0, 0, 0, loc),
+			expected: true,
+		},
+		"same day in UTC, crossing midnight for the user": {
+			start: time.Date(2024, time.May, 16, 3, 30, 0, 0, time.UTC),
+			end:   time.Date(2024, time.May, 16, 4, 30, 0, 0, time.UTC),
+		},
+		"ending at midnight": {
+			start: time.Date(2024, time.May, 15, 23, 0, 0, 0, loc),
+			end:   time.Date(2024, time.May, 16, 0, 0, 0, 0, loc),
+		},
+		"starting before the search window": {
+			start: time.Date(2024, time.May, 14, 23, 0, 0, 0, loc),
+			end:   time.Date(2024, time.May, 15, 0, 30, 0, 0, loc),
+		},
+		"ending after the search window": {
+			start: time.Date(2024, time.May, 16, 23, 30, 0, 0, loc),
+			end:   time.Date(2024, time.May, 17, 0, 30, 0, 0, loc),
+		},
+		"no time slot": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			suggestion := &remote.MeetingTimeSuggestion{}
+			if !tc.start.IsZero() {
+				suggestion.MeetingTimeSlot = &remote.TimeSlot{
+					Start: remote.NewDateTime(tc.start.UTC(), "UTC"),
+					End:   remote.NewDateTime(tc.end.UTC(), "UTC"),
+				}
+			}
+
+			bookable := bookableMeetingTimes([]*remote.MeetingTimeSuggestion{suggestion}, from, to, loc)
+			if tc.expected {
+				require.Equal(t, []*remote.MeetingTimeSuggestion{suggestion}, bookable)
+			} else {
+				require.Empty(t, bookable)
+			}
+		})
+	}
+}
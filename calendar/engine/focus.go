@@ -0,0 +1,227 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const (
+	FocusJobInterval    = time.Minute
+	DefaultFocusSubject = "Focus time"
+	MaxFocusDuration    = 12 * time.Hour
+)
+
+// ErrFocusBlockInProgress is returned when a user starts a focus block during another one.
+var ErrFocusBlockInProgress = errors.New("a focus block is already in progress")
+
+type Focus interface {
+	StartFocusBlock(user *User, d time.Duration, subject string) (*store.FocusBlock, error)
+	StopFocusBlock(user *User) (*store.FocusBlock, error)
+	EndFocusBlocks(now time.Time) error
+}
+
+// StartFocusBlock creates a busy event for the next d in the calendar of the user, and sets their status to DND.
+// The notifications of the plugin are held back until the end of the block.
+func (m *mscalendar) StartFocusBlock(user *User, d time.Duration, subject string) (*store.FocusBlock, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	current, err := m.Store.LoadFocusBlock(user.MattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+	if current.IsActive(now) {
+		return nil, ErrFocusBlockInProgress
+	}
+	if d <= 0 || d > MaxFocusDuration {
+		return nil, fmt.Errorf("%w: the duration must be between 1 minute and %d hours", ErrInvalidEventPayload, int(MaxFocusDuration/time.Hour))
+	}
+	if subject == "" {
+		subject = DefaultFocusSubject
+	}
+
+	end := now.Add(d)
+	event, err := m.client.CreateEvent(user.Remote.ID, &remote.Event{
+		Subject: subject,
+		ShowAs:  "busy",
+		Start:   remote.NewDateTime(now.UTC(), "UTC"),
+		End:     remote.NewDateTime(end.UTC(), "UTC"),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating focus event")
+	}
+
+	block := &store.FocusBlock{
+		MattermostUserID: user.MattermostUserID,
+		EventID:          event.ID,
+		Subject:          subject,
+		End:              end,
+	}
+
+	status, err := m.PluginAPI.GetMattermostUserStatus(user.MattermostUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting user status")
+	}
+	if status.Status != model.StatusDnd {
+		if _, err = m.PluginAPI.UpdateMattermostUserStatus(user.MattermostUserID, model.StatusDnd); err != nil {
+			return nil, errors.Wrap(err, "error setting user status")
+		}
+		block.PreviousStatus = model.StatusOnline
+		if status.Manual {
+			block.PreviousStatus = status.Status
+		}
+	}
+
+	if err = m.Store.StoreFocusBlock(block); err != nil {
+		return nil, errors.Wrap(err, "error storing focus block")
+	}
+
+	return block, nil
+}
+
+// StopFocusBlock ends the focus block of the user before its time, shortening its event. It returns the
+// stopped block, or nil if the user was not focusing.
+func (m *mscalendar) StopFocusBlock(user *User) (*store.FocusBlock, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := m.Store.PopFocusBlock(user.MattermostUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error removing focus block")
+	}
+	if block == nil {
+		return nil, nil
+	}
+
+	now := time.Now()
+	if block.End.After(now) {
+		_, err = m.client.UpdateEvent(user.Remote.ID, block.EventID, &remote.Event{
+			End: remote.NewDateTime(now.UTC(), "UTC"),
+		})
+		if err != nil {
+			m.Logger.With(bot.LogContext{"err": err.Error(), "eventID": block.EventID}).Warnf("Failed to shorten focus event.")
+		}
+	}
+
+	return block, m.endFocusBlock(block)
+}
+
+// EndFocusBlocks ends the focus blocks that are over, delivering the notifications held back during them.
+func (m *mscalendar) EndFocusBlocks(now time.Time) error {
+	blocks, err := m.Store.PopEndedFocusBlocks(now)
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		err = m.endFocusBlock(block)
+		if err != nil {
+			m.Logger.With(bot.LogContext{
+				"mattermostUserID": block.MattermostUserID,
+				"err":              err.Error(),
+			}).Warnf("Failed to end focus block.")
+		}
+	}
+
+	return nil
+}
+
+// endFocusBlock restores the status of the user, unless they changed it during the block, and sends the
+// notifications held back as one digest. The digest is sent even when the status can't be restored.
+func (m *mscalendar) endFocusBlock(block *store.FocusBlock) error {
+	if err := m.restoreFocusStatus(block); err != nil {
+		m.Logger.With(bot.LogContext{
+			"mattermostUserID": block.MattermostUserID,
+			"err":              err.Error(),
+		}).Warnf("Failed to restore the status after a focus block.")
+	}
+
+	held, err := m.Store.PopHeldNotifications(block.MattermostUserID)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Poster.DMWithMessageAndAttachments(block.MattermostUserID, focusDigestMessage(block, held), focusDigestAttachments(held)...)
+	if err != nil {
+		return errors.Wrap(err, "error sending focus digest")
+	}
+	return nil
+}
+
+func (m *mscalendar) restoreFocusStatus(block *store.FocusBlock) error {
+	if block.PreviousStatus == "" {
+		return nil
+	}
+
+	status, err := m.PluginAPI.GetMattermostUserStatus(block.MattermostUserID)
+	if err != nil {
+		return errors.Wrap(err, "error getting user status")
+	}
+	if status.Status != model.StatusDnd {
+		return nil
+	}
+	if _, err = m.PluginAPI.UpdateMattermostUserStatus(block.MattermostUserID, block.PreviousStatus); err != nil {
+		return errors.Wrap(err, "error restoring user status")
+	}
+	return nil
+}
+
+// holdOrDM sends the attachments to the user, or holds them back until the end of their focus block. The block
+// is read from the store, as it may have started or ended since the user was loaded.
+func holdOrDM(deps *Dependencies, user *store.User, attachments ...*model.SlackAttachment) error {
+	block, err := deps.Store.LoadFocusBlock(user.MattermostUserID)
+	if err != nil {
+		deps.Logger.Warnf("Error loading the focus block of user %s, sending the notification. err=%v", user.MattermostUserID, err)
+	}
+
+	now := time.Now()
+	if block.IsActive(now) {
+		return deps.Store.HoldNotification(user.MattermostUserID, &store.HeldNotification{
+			Time:        now,
+			Attachments: attachments,
+		})
+	}
+
+	_, err = deps.Poster.DMWithAttachments(user.MattermostUserID, attachments...)
+	return err
+}
+
+func focusDigestMessage(block *store.FocusBlock, held []*store.HeldNotification) string {
+	switch len(held) {
+	case 0:
+		return fmt.Sprintf("Your focus block **%s** is over. Nothing happened in your calendar in the meantime.", block.Subject)
+	case 1:
+		return fmt.Sprintf("Your focus block **%s** is over. Here is the notification you received in the meantime:", block.Subject)
+	default:
+		return fmt.Sprintf("Your focus block **%s** is over. Here are the %d notifications you received in the meantime:", block.Subject, len(held))
+	}
+}
+
+func focusDigestAttachments(held []*store.HeldNotification) []*model.SlackAttachment {
+	attachments := []*model.SlackAttachment{}
+	for _, n := range held {
+		attachments = append(attachments, n.Attachments...)
+	}
+	return attachments
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot/mock_bot"
+)
+
+func TestStartFocusBlock(t *testing.T) {
+	for name, tc := range map[string]struct {
+		currentStatus          *model.Status
+		focusBlock             *store.FocusBlock
+		expectedPreviousStatus string
+		expectedError          string
+	}{
+		"online": {
+			currentStatus:          &model.Status{Status: model.StatusOnline},
+			expectedPreviousStatus: model.StatusOnline,
+		},
+		"manually away": {
+			currentStatus:          &model.Status{Status: model.StatusAway, Manual: true},
+			expectedPreviousStatus: model.StatusAway,
+		},
+		"already DND": {
+			currentStatus: &model.Status{Status: model.StatusDnd, Manual: true},
+		},
+		"already focusing": {
+			focusBlock:    &store.FocusBlock{End: time.Now().Add(time.Hour)},
+			expectedError: "a focus block is already in progress",
+		},
+		"previous block over": {
+			currentStatus:          &model.Status{Status: model.StatusOnline},
+			focusBlock:             &store.FocusBlock{End: time.Now().Add(-time.Minute)},
+			expectedPreviousStatus: model.StatusOnline,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			env, client := makeStatusSyncTestEnv(ctrl)
+			mockClient := client.(*mock_remote.MockClient)
+			s := env.Dependencies.Store.(*mock_store.MockStore)
+			papi := env.Dependencies.PluginAPI.(*mock_plugin_api.MockPluginAPI)
+
+			user := &User{
+				MattermostUserID: "user_mm_id",
+				User:             &store.User{MattermostUserID: "user_mm_id", Remote: &remote.User{ID: "user_remote_id"}},
+				MattermostUser:   &model.User{Id: "user_mm_id"},
+			}
+			m := &mscalendar{Env: env, client: client}
+
+			s.EXPECT().LoadFocusBlock("user_mm_id").Return(tc.focusBlock, nil)
+			if tc.expectedError == "" {
+				mockClient.EXPECT().CreateEvent("user_remote_id", gomock.Any()).DoAndReturn(func(_ string, event *remote.Event) (*remote.Event, error) {
+					require.Equal(t, "Deep work", event.Subject)
+					require.Equal(t, "busy", event.ShowAs)
+					require.Equal(t, 90*time.Minute, event.End.Time().Sub(event.Start.Time()))
+					event.ID = "event_id"
+					return event, nil
+				})
+				papi.EXPECT().GetMattermostUserStatus("user_mm_id").Return(tc.currentStatus, nil)
+				if tc.currentStatus.Status != model.StatusDnd {
+					papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", model.StatusDnd).Return(&model.Status{}, nil)
+				}
+				s.EXPECT().StoreFocusBlock(gomock.Any()).DoAndReturn(func(block *store.FocusBlock) error {
+					require.Equal(t, "user_mm_id", block.MattermostUserID)
+					return nil
+				})
+			}
+
+			block, err := m.StartFocusBlock(user, 90*time.Minute, "Deep work")
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "event_id", block.EventID)
+			require.Equal(t, tc.expectedPreviousStatus, block.PreviousStatus)
+			require.True(t, block.IsActive(time.Now()))
+		})
+	}
+}
+
+func TestHoldOrDM(t *testing.T) {
+	attachment := &model.SlackAttachment{Title: "Upcoming event"}
+
+	for name, tc := range map[string]struct {
+		focusBlock   *store.FocusBlock
+		loadError    error
+		expectedHeld bool
+	}{
+		"no focus block": {},
+		"focusing": {
+			focusBlock:   &store.FocusBlock{End: time.Now().Add(time.Hour)},
+			expectedHeld: true,
+		},
+		"focus block over": {
+			focusBlock: &store.FocusBlock{End: time.Now().Add(-time.Minute)},
+		},
+		"store failure": {
+			loadError: errors.New("unavailable"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			env, _ := makeStatusSyncTestEnv(ctrl)
+			s := env.Dependencies.Store.(*mock_store.MockStore)
+			poster := env.Dependencies.Poster.(*mock_bot.MockPoster)
+			logger := env.Dependencies.Logger.(*mock_bot.MockLogger)
+			logger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			// The user was loaded before the block started
+			user := &store.User{MattermostUserID: "user_mm_id"}
+			s.EXPECT().LoadFocusBlock("user_mm_id").Return(tc.focusBlock, tc.loadError)
+			if tc.expectedHeld {
+				s.EXPECT().HoldNotification("user_mm_id", gomock.Any()).DoAndReturn(func(_ string, n *store.HeldNotification) error {
+					require.Equal(t, []*model.SlackAttachment{attachment}, n.Attachments)
+					return nil
+				})
+			} else {
+				poster.EXPECT().DMWithAttachments("user_mm_id", attachment).Return("post_id", nil)
+			}
+
+			require.NoError(t, holdOrDM(env.Dependencies, user, attachment))
+		})
+	}
+}
+
+func TestEndFocusBlocks(t *testing.T) {
+	for name, tc := range map[string]struct {
+		currentStatus   string
+		statusError     error
+		held            []*store.HeldNotification
+		expectedRestore bool
+		expectedMessage string
+	}{
+		"still DND, with held notifications": {
+			currentStatus: model.StatusDnd,
+			held: []*store.HeldNotification{
+				{Attachments: []*model.SlackAttachment{{Title: "New event"}}},
+				{Attachments: []*model.SlackAttachment{{Title: "Upcoming event"}}},
+			},
+			expectedRestore: true,
+			expectedMessage: "Your focus block **Deep work** is over. Here are the 2 notifications you received in the meantime:",
+		},
+		"status changed during the block": {
+			currentStatus:   model.StatusOnline,
+			expectedMessage: "Your focus block **Deep work** is over. Nothing happened in your calendar in the meantime.",
+		},
+		"status not restored, digest sent": {
+			statusError: errors.New("unavailable"),
+			held: []*store.HeldNotification{
+				{Attachments: []*model.SlackAttachment{{Title: "New event"}}},
+			},
+			expectedMessage: "Your focus block **Deep work** is over. Here is the notification you received in the meantime:",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			env, _ := makeStatusSyncTestEnv(ctrl)
+			s := env.Dependencies.Store.(*mock_store.MockStore)
+			papi := env.Dependencies.PluginAPI.(*mock_plugin_api.MockPluginAPI)
+			poster := env.Dependencies.Poster.(*mock_bot.MockPoster)
+			m := &mscalendar{Env: env}
+
+			now := time.Now()
+			s.EXPECT().PopEndedFocusBlocks(now).Return([]*store.FocusBlock{{
+				MattermostUserID: "user_mm_id",
+				Subject:          "Deep work",
+				PreviousStatus:   model.StatusAway,
+				End:              now,
+			}}, nil)
+			logger := env.Dependencies.Logger.(*mock_bot.MockLogger)
+			if tc.statusError != nil {
+				logger.EXPECT().With(gomock.Any()).Return(logger)
+				logger.EXPECT().Warnf("Failed to restore the status after a focus block.")
+			}
+			papi.EXPECT().GetMattermostUserStatus("user_mm_id").Return(&model.Status{Status: tc.currentStatus}, tc.statusError)
+			if tc.expectedRestore {
+				papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", model.StatusAway).Return(&model.Status{}, nil)
+			}
+			s.EXPECT().PopHeldNotifications("user_mm_id").Return(tc.held, nil)
+			poster.EXPECT().DMWithMessageAndAttachments("user_mm_id", tc.expectedMessage, gomock.Any()).DoAndReturn(
+				func(_, _ string, attachments ...*model.SlackAttachment) (string, error) {
+					require.Len(t, attachments, len(tc.held))
+					return "post_id", nil
+				})
+
+			require.NoError(t, m.EndFocusBlocks(now))
+		})
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+var icalDurationRegexp = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// icalProperty is a content line of an iCalendar object, with its parameter names in upper case.
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ParseICalendar returns the events of an iCalendar (RFC 5545) object. Recurrence rules are not supported,
+// so recurring events are read as their first occurrence. Times in time zones unknown to the server are read
+// as UTC.
+func ParseICalendar(data []byte) ([]*remote.Event, error) {
+	events := []*remote.Event{}
+	components := []string{}
+	var event *remote.Event
+	for _, line := range unfoldICalendarLines(string(data)) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		prop, err := parseICalendarProperty(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.value)
+			components = append(components, component)
+			if component == "VEVENT" {
+				event = &remote.Event{}
+			}
+			continue
+		case "END":
+			if len(components) == 0 {
+				return nil, errors.Errorf("unexpected END:%s", prop.value)
+			}
+			component := components[len(components)-1]
+			components = components[:len(components)-1]
+			if component == "VEVENT" && event != nil {
+				if err = completeICalendarEvent(event); err != nil {
+					return nil, err
+				}
+				events = append(events, event)
+				event = nil
+			}
+			continue
+		}
+
+		// Properties of nested components, like alarms, are ignored
+		if event == nil || components[len(components)-1] != "VEVENT" {
+			continue
+		}
+		if err = setICalendarEventProperty(event, prop); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(events) == 0 {
+		return nil, errors.New("no events found in the calendar file")
+	}
+
+	return events, nil
+}
+
+func setICalendarEventProperty(event *remote.Event, prop *icalProperty) error {
+	var err error
+	switch prop.name {
+	case "UID":
+		event.ICalUID = views.UnescapeICalendarText(prop.value)
+	case "SUMMARY":
+		event.Subject = views.UnescapeICalendarText(prop.value)
+	case "DESCRIPTION":
+		description := views.UnescapeICalendarText(prop.value)
+		event.BodyPreview = description
+		event.Body = &remote.ItemBody{
+			Content:     description,
+			ContentType: "text",
+		}
+	case "LOCATION":
+		event.Location = &remote.Location{
+			DisplayName: views.UnescapeICalendarText(prop.value),
+		}
+	case "URL":
+		event.Weblink = prop.value
+	case "X-MICROSOFT-SKYPETEAMSMEETINGURL":
+		event.Conference = &remote.Conference{
+			Application: "Microsoft Teams",
+			URL:         prop.value,
+		}
+	case "X-GOOGLE-CONFERENCE":
+		event.Conference = &remote.Conference{
+			Application: "Google Meet",
+			URL:         prop.value,
+		}
+	case "DTSTART":
+		event.Start, event.IsAllDay, err = parseICalendarDateTime(prop)
+	case "DTEND":
+		event.End, _, err = parseICalendarDateTime(prop)
+	case "DURATION":
+		if event.Start == nil {
+			return errors.New("DURATION must follow DTSTART")
+		}
+		var d time.Duration
+		d, err = parseICalendarDuration(prop.value)
+		if err == nil && event.End == nil {
+			event.End = remote.NewDateTime(event.Start.Time().Add(d), event.Start.TimeZone)
+		}
+	case "ORGANIZER":
+		event.Organizer = &remote.Attendee{
+			EmailAddress: icalEmailAddress(prop),
+		}
+	case "ATTENDEE":
+		attendee := &remote.Attendee{
+			Type:         "required",
+			EmailAddress: icalEmailAddress(prop),
+			Status: &remote.EventResponseStatus{
+				Response: icalResponse(prop.params["PARTSTAT"]),
+			},
+		}
+		if strings.EqualFold(prop.params["ROLE"], "OPT-PARTICIPANT") {
+			attendee.Type = "optional"
+		}
+		event.Attendees = append(event.Attendees, attendee)
+	case "STATUS":
+		event.IsCancelled = strings.EqualFold(prop.value, "CANCELLED")
+	case "TRANSP":
+		if strings.EqualFold(prop.value, "TRANSPARENT") {
+			event.ShowAs = "free"
+		}
+	}
+	if err != nil {
+		return errors.Wrapf(err, "invalid %s", prop.name)
+	}
+
+	return nil
+}
+
+func completeICalendarEvent(event *remote.Event) error {
+	if event.Start == nil {
+		return errors.Errorf("event %q has no start", event.Subject)
+	}
+
+	if event.End == nil {
+		// Without end nor duration, all-day events last one day and the other events have no duration
+		end := event.Start.Time()
+		if event.IsAllDay {
+			end = end.AddDate(0, 0, 1)
+		}
+		event.End = remote.NewDateTime(end, event.Start.TimeZone)
+	}
+
+	if event.End.Time().Before(event.Start.Time()) {
+		return errors.Errorf("event %q ends before it starts", event.Subject)
+	}
+
+	return nil
+}
+
+// unfoldICalendarLines joins the lines that were split, which start with a space or a tab.
+func unfoldICalendarLines(data string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\r"))
+	}
+	return lines
+}
+
+func parseICalendarProperty(line string) (*icalProperty, error) {
+	// The value starts after the first colon which is not in a quoted parameter value
+	quoted := false
+	valueStart := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			valueStart = i
+			break
+		}
+	}
+	if valueStart < 0 {
+		return nil, errors.Errorf("invalid content line %q", line)
+	}
+
+	prop := &icalProperty{
+		params: map[string]string{},
+		value:  line[valueStart+1:],
+	}
+
+	parts := splitICalendarParams(line[:valueStart])
+	prop.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		name, value, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+
+	return prop, nil
+}
+
+func splitICalendarParams(s string) []string {
+	parts := []string{}
+	quoted := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ';' && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseICalendarDateTime returns the date-time of the property, and whether it is a date.
+func parseICalendarDateTime(prop *icalProperty) (*remote.DateTime, bool, error) {
+	value := prop.value
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len(views.ICalendarDateFormat) {
+		t, err := time.Parse(views.ICalendarDateFormat, value)
+		if err != nil {
+			return nil, false, err
+		}
+		return remote.NewDateTime(t, "UTC"), true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(views.ICalendarDateTimeFormat, strings.TrimSuffix(value, "Z"))
+		if err != nil {
+			return nil, false, err
+		}
+		return remote.NewDateTime(t, "UTC"), false, nil
+	}
+
+	loc := time.UTC
+	if name := tz.Go(prop.params["TZID"]); name != "" {
+		if l, err := time.LoadLocation(name); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation(views.ICalendarDateTimeFormat, value, loc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return remote.NewDateTime(t, loc.String()), false, nil
+}
+
+func parseICalendarDuration(value string) (time.Duration, error) {
+	matches := icalDurationRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if matches[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(matches[i+2])
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(n) * unit
+	}
+	if matches[1] == "-" {
+		d = -d
+	}
+
+	return d, nil
+}
+
+func icalEmailAddress(prop *icalProperty) *remote.EmailAddress {
+	address := prop.value
+	if len(address) >= len("mailto:") && strings.EqualFold(address[:len("mailto:")], "mailto:") {
+		address = address[len("mailto:"):]
+	}
+	return &remote.EmailAddress{
+		Name:    prop.params["CN"],
+		Address: address,
+	}
+}
+
+func icalResponse(partStat string) string {
+	switch strings.ToUpper(partStat) {
+	case "ACCEPTED":
+		return remote.EventResponseStatusAccepted
+	case "DECLINED":
+		return remote.EventResponseStatusDeclined
+	case "TENTATIVE":
+		return remote.EventResponseStatusTentative
+	}
+	return remote.EventResponseStatusNotAnswered
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestParseICalendar(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VTIMEZONE",
+		"TZID:Europe/Paris",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:uid-1",
+		`SUMMARY:Retro\; sprint 12\, part 1`,
+		`DESCRIPTION:Agenda:\nwins`,
+		"DTSTART;TZID=Europe/Paris:20240516T170000",
+		"DURATION:PT1H30M",
+		"LOCATION:Room 1",
+		"X-MICROSOFT-SKYPETEAMSMEETINGURL:https://example.com/join",
+		`ORGANIZER;CN="Alice A.":mailto:alice@example.com`,
+		"ATTENDEE;CN=Bob;ROLE=OPT-PARTICIPANT;PARTSTAT=ACCEPTED:MAILTO:bob@exampl",
+		" e.com",
+		"BEGIN:VALARM",
+		"DESCRIPTION:Reminder",
+		"TRIGGER:-PT15M",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:uid-2",
+		"SUMMARY:Holiday",
+		"DTSTART;VALUE=DATE:20240517",
+		"TRANSP:TRANSPARENT",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := ParseICalendar([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	retro := events[0]
+	require.Equal(t, "uid-1", retro.ICalUID)
+	require.Equal(t, "Retro; sprint 12, part 1", retro.Subject)
+	require.Equal(t, "Agenda:\nwins", retro.BodyPreview)
+	require.Equal(t, time.Date(2024, time.May, 16, 15, 0, 0, 0, time.UTC), retro.Start.Time().UTC())
+	require.Equal(t, time.Date(2024, time.May, 16, 16, 30, 0, 0, time.UTC), retro.End.Time().UTC())
+	require.False(t, retro.IsAllDay)
+	require.Equal(t, "Room 1", retro.Location.DisplayName)
+	require.Equal(t, "https://example.com/join", retro.Conference.URL)
+	require.Equal(t, &remote.EmailAddress{Name: "Alice A.", Address: "alice@example.com"}, retro.Organizer.EmailAddress)
+	require.Equal(t, []*remote.Attendee{{
+		Type:         "optional",
+		EmailAddress: &remote.EmailAddress{Name: "Bob", Address: "bob@example.com"},
+		Status:       &remote.EventResponseStatus{Response: remote.EventResponseStatusAccepted},
+	}}, retro.Attendees)
+
+	holiday := events[1]
+	require.True(t, holiday.IsAllDay)
+	require.True(t, holiday.IsCancelled)
+	require.Equal(t, "free", holiday.ShowAs)
+	require.Equal(t, time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC), holiday.Start.Time().UTC())
+	require.Equal(t, time.Date(2024, time.May, 18, 0, 0, 0, 0, time.UTC), holiday.End.Time().UTC())
+}
+
+func TestParseICalendarErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		data          string
+		expectedError string
+	}{
+		"no events": {
+			data:          "BEGIN:VCALENDAR\r\nEND:VCALENDAR",
+			expectedError: "no events found in the calendar file",
+		},
+		"no start": {
+			data:          "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Standup\r\nEND:VEVENT\r\nEND:VCALENDAR",
+			expectedError: `event "Standup" has no start`,
+		},
+		"invalid date": {
+			data:          "BEGIN:VEVENT\r\nDTSTART:soon\r\nEND:VEVENT",
+			expectedError: `invalid DTSTART: parsing time "soon" as "20060102T150405": cannot parse "soon" as "2006"`,
+		},
+		"invalid line": {
+			data:          "BEGIN:VEVENT\r\nSUMMARY Standup\r\nEND:VEVENT",
+			expectedError: `invalid content line "SUMMARY Standup"`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseICalendar([]byte(tc.data))
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestParseRenderedICalendar(t *testing.T) {
+	event := &remote.Event{
+		ICalUID:  "uid",
+		Subject:  strings.Repeat("Quarterly planning, ", 5),
+		Start:    remote.NewDateTime(time.Date(2024, time.May, 16, 15, 0, 0, 0, time.UTC), "UTC"),
+		End:      remote.NewDateTime(time.Date(2024, time.May, 16, 16, 0, 0, 0, time.UTC), "UTC"),
+		Location: &remote.Location{DisplayName: "Room; 1"},
+	}
+
+	events, err := ParseICalendar([]byte(views.RenderICalendar([]*remote.Event{event}, time.Now())))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, event.Subject, events[0].Subject)
+	require.Equal(t, event.Location.DisplayName, events[0].Location.DisplayName)
+	require.Equal(t, event.Start.Time(), events[0].Start.Time())
+	require.Equal(t, event.End.Time(), events[0].End.Time())
+}
+
+func TestImportedEvent(t *testing.T) {
+	event := &remote.Event{
+		Subject:     "Retro",
+		BodyPreview: "Agenda",
+		Weblink:     "https://example.com/event",
+		Conference:  &remote.Conference{URL: "https://example.com/join"},
+		Organizer:   &remote.Attendee{EmailAddress: &remote.EmailAddress{Address: "alice@example.com"}},
+		Attendees:   []*remote.Attendee{{EmailAddress: &remote.EmailAddress{Address: "bob@example.com"}}},
+	}
+
+	imported := importedEvent(event)
+	require.Nil(t, imported.Organizer)
+	require.Nil(t, imported.Attendees)
+	require.Nil(t, imported.Conference)
+	require.Equal(t, "Agenda\n\nhttps://example.com/event\n\nhttps://example.com/join", imported.Body.Content)
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const importEventMaxPreviews = 10
+
+type EventImporter interface {
+	OfferEventImport(post *model.Post) error
+	PreviewEventImport(user *User, fileID, channelID string) error
+	ImportEvent(user *User, fileID string, eventIndex int, channelID string) (*remote.Event, error)
+}
+
+// OfferEventImport replies to a post with iCalendar files with a button to add their events to a calendar.
+func (m *mscalendar) OfferEventImport(post *model.Post) error {
+	attachments := []*model.SlackAttachment{}
+	for _, fileID := range post.FileIds {
+		fileInfo, err := m.PluginAPI.GetFileInfo(fileID)
+		if err != nil {
+			return errors.Wrap(err, "error getting file info")
+		}
+		if !isICalendarFile(fileInfo) {
+			continue
+		}
+
+		attachments = append(attachments, &model.SlackAttachment{
+			Text:     fmt.Sprintf("**%s** contains calendar events.", fileInfo.Name),
+			Fallback: fmt.Sprintf("%s contains calendar events.", fileInfo.Name),
+			Actions: []*model.PostAction{
+				NewPostActionForEventImport(fileID, post.ChannelId, m.Config.PluginURLPath+config.PathPostAction+config.PathPreviewEventImport),
+			},
+		})
+	}
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	rootID := post.RootId
+	if rootID == "" {
+		rootID = post.Id
+	}
+	reply := &model.Post{
+		ChannelId: post.ChannelId,
+		RootId:    rootID,
+	}
+	model.ParseSlackAttachment(reply, attachments)
+
+	return m.Poster.CreatePost(reply)
+}
+
+// PreviewEventImport sends the events of an iCalendar file to the user, each of them with buttons to add it to
+// the calendar of the user, and to link it to the channel the file was posted in.
+func (m *mscalendar) PreviewEventImport(user *User, fileID, channelID string) error {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return err
+	}
+
+	events, err := m.loadICalendarFile(user, fileID)
+	if err != nil {
+		return err
+	}
+
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		return errors.Wrap(err, "error getting timezone")
+	}
+
+	canLink := channelID != "" && m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID)
+	url := m.Config.PluginURLPath + config.PathPostAction + config.PathImportEvent
+	attachments := []*model.SlackAttachment{}
+	for i, event := range events {
+		if i == importEventMaxPreviews {
+			break
+		}
+
+		sa, errRender := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
+		if errRender != nil {
+			m.Logger.With(bot.LogContext{"err": errRender.Error()}).Warnf("Failed to render imported event.")
+			continue
+		}
+		sa.Title = views.EnsureSubject(sa.Title)
+		if event.Organizer != nil && event.Organizer.EmailAddress != nil {
+			sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+				Title: "Organizer",
+				Value: event.Organizer.EmailAddress.Address,
+				Short: true,
+			})
+		}
+
+		sa.Actions = []*model.PostAction{
+			NewPostActionForImportEvent("Add to calendar", fileID, i, "", url),
+		}
+		if canLink {
+			sa.Actions = append(sa.Actions, NewPostActionForImportEvent("Add and link to channel", fileID, i, channelID, url))
+		}
+		attachments = append(attachments, sa)
+	}
+
+	message := "Here are the events of the calendar file. Adding an event to your calendar does not notify its organizer nor its attendees."
+	if len(events) > importEventMaxPreviews {
+		message += fmt.Sprintf(" Only the first %d events are shown.", importEventMaxPreviews)
+	}
+	_, err = m.Poster.DMWithMessageAndAttachments(user.MattermostUserID, message, attachments...)
+	if err != nil {
+		return errors.Wrap(err, "error sending the events preview")
+	}
+
+	return nil
+}
+
+// ImportEvent adds an event of an iCalendar file to the calendar of the user, without inviting its
+// attendees, and links it to the channel, if any.
+func (m *mscalendar) ImportEvent(user *User, fileID string, eventIndex int, channelID string) (*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if channelID != "" && !m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID) {
+		return nil, fmt.Errorf("%w: you don't have permission to link events in the selected channel", ErrInvalidEventPayload)
+	}
+
+	events, err := m.loadICalendarFile(user, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if eventIndex < 0 || eventIndex >= len(events) {
+		return nil, fmt.Errorf("%w: the event was not found in the calendar file", ErrInvalidEventPayload)
+	}
+
+	event, err := m.CreateEvent(user, importedEvent(events[eventIndex]), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating event")
+	}
+
+	if channelID == "" {
+		return event, nil
+	}
+
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting timezone")
+	}
+	attachment, err := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
+	if err != nil {
+		m.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("error rendering event as attachment")
+	}
+	if err = m.linkEventToChannel(user, event, channelID, attachment); err != nil {
+		return nil, errors.Wrap(err, "error linking event to channel")
+	}
+
+	return event, nil
+}
+
+// loadICalendarFile parses the events of a file attached to a post in a channel the user can read.
+func (m *mscalendar) loadICalendarFile(user *User, fileID string) ([]*remote.Event, error) {
+	fileInfo, err := m.PluginAPI.GetFileInfo(fileID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting file info")
+	}
+	if !isICalendarFile(fileInfo) {
+		return nil, fmt.Errorf("%w: %s is not a calendar file", ErrInvalidEventPayload, fileInfo.Name)
+	}
+	if fileInfo.PostId == "" {
+		return nil, fmt.Errorf("%w: %s is not attached to a post", ErrInvalidEventPayload, fileInfo.Name)
+	}
+
+	post, err := m.PluginAPI.GetPost(fileInfo.PostId)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting post")
+	}
+	if !m.PluginAPI.CanReadChannel(post.ChannelId, user.MattermostUserID) {
+		return nil, fmt.Errorf("%w: you don't have access to the calendar file", ErrInvalidEventPayload)
+	}
+
+	data, err := m.PluginAPI.GetFile(fileID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting file")
+	}
+
+	events, err := ParseICalendar(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEventPayload, err.Error())
+	}
+
+	return events, nil
+}
+
+// importedEvent returns the event to create from a parsed event. The organizer and the attendees are left
+// out, so that they are not invited, and the links are moved to the body.
+func importedEvent(event *remote.Event) *remote.Event {
+	body := event.BodyPreview
+	for _, link := range []string{event.Weblink, event.JoinURL()} {
+		if link == "" || strings.Contains(body, link) {
+			continue
+		}
+		if body != "" {
+			body += "\n\n"
+		}
+		body += link
+	}
+
+	imported := &remote.Event{
+		Subject:  event.Subject,
+		Start:    event.Start,
+		End:      event.End,
+		IsAllDay: event.IsAllDay,
+		Location: event.Location,
+		ShowAs:   event.ShowAs,
+	}
+	if body != "" {
+		imported.Body = &remote.ItemBody{
+			Content:     body,
+			ContentType: "text",
+		}
+	}
+
+	return imported
+}
+
+func isICalendarFile(fileInfo *model.FileInfo) bool {
+	return strings.EqualFold(fileInfo.Extension, strings.TrimPrefix(views.ICalendarExtension, ".")) ||
+		strings.HasPrefix(fileInfo.MimeType, views.ICalendarContentType)
+}
+
+func NewPostActionForEventImport(fileID, channelID, url string) *model.PostAction {
+	return &model.PostAction{
+		Name:  "Add to my calendar",
+		Type:  model.PostActionTypeButton,
+		Style: "primary",
+		Integration: &model.PostActionIntegration{
+			URL: url,
+			Context: map[string]interface{}{
+				config.FileIDKey:    fileID,
+				config.ChannelIDKey: channelID,
+			},
+		},
+	}
+}
+
+func NewPostActionForImportEvent(name, fileID string, eventIndex int, channelID, url string) *model.PostAction {
+	return &model.PostAction{
+		Name: name,
+		Type: model.PostActionTypeButton,
+		Integration: &model.PostActionIntegration{
+			URL: url,
+			Context: map[string]interface{}{
+				config.FileIDKey:     fileID,
+				config.EventIndexKey: eventIndex,
+				config.ChannelIDKey:  channelID,
+			},
+		},
+	}
+}
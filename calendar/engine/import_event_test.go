@@ -0,0 +1,68 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_plugin_api"
+)
+
+func TestLoadICalendarFile(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Standup\r\nDTSTART:20240516T090000Z\r\nDTEND:20240516T091500Z\r\nEND:VEVENT\r\nEND:VCALENDAR"
+
+	for name, tc := range map[string]struct {
+		fileInfo      *model.FileInfo
+		canRead       bool
+		expectedError string
+	}{
+		"loaded": {
+			fileInfo: &model.FileInfo{Id: "file_id", PostId: "post_id", Name: "invite.ics", Extension: "ics"},
+			canRead:  true,
+		},
+		"no access to the channel": {
+			fileInfo:      &model.FileInfo{Id: "file_id", PostId: "post_id", Name: "invite.ics", Extension: "ics"},
+			expectedError: "invalid event: you don't have access to the calendar file",
+		},
+		"not attached to a post": {
+			fileInfo:      &model.FileInfo{Id: "file_id", Name: "invite.ics", Extension: "ics"},
+			expectedError: "invalid event: invite.ics is not attached to a post",
+		},
+		"not a calendar file": {
+			fileInfo:      &model.FileInfo{Id: "file_id", PostId: "post_id", Name: "notes.txt", Extension: "txt", MimeType: "text/plain"},
+			expectedError: "invalid event: notes.txt is not a calendar file",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			env, client := makeStatusSyncTestEnv(ctrl)
+			papi := env.Dependencies.PluginAPI.(*mock_plugin_api.MockPluginAPI)
+			m := &mscalendar{Env: env, client: client}
+
+			papi.EXPECT().GetFileInfo("file_id").Return(tc.fileInfo, nil)
+			if tc.fileInfo.PostId != "" && tc.fileInfo.Extension == "ics" {
+				papi.EXPECT().GetPost("post_id").Return(&model.Post{Id: "post_id", ChannelId: "channel_id"}, nil)
+				papi.EXPECT().CanReadChannel("channel_id", "user_mm_id").Return(tc.canRead)
+			}
+			if tc.canRead {
+				papi.EXPECT().GetFile("file_id").Return([]byte(data), nil)
+			}
+
+			events, err := m.loadICalendarFile(NewUser("user_mm_id"), "file_id")
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			require.Equal(t, "Standup", events[0].Subject)
+		})
+	}
+}
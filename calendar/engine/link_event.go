@@ -0,0 +1,139 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// EventLinker links the existing events of the user, including the ones created outside Mattermost, to channels.
+type EventLinker interface {
+	LinkEventToChannel(user *User, eventID, channelID string) (*remote.Event, error)
+	UnlinkEventFromChannel(user *User, eventID, channelID string) (*remote.Event, error)
+	// LinkedChannelNames returns the names of the channels linked to the events, by event ID.
+	LinkedChannelNames(events []*remote.Event) map[string][]string
+}
+
+func (m *mscalendar) LinkEventToChannel(user *User, eventID, channelID string) (*remote.Event, error) {
+	event, err := m.getEventToLink(user, eventID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if event.IsCancelled {
+		return nil, fmt.Errorf("%w: the event is cancelled", ErrInvalidEventPayload)
+	}
+
+	for _, linkedChannelID := range m.linkedChannelIDs(event) {
+		if linkedChannelID == channelID {
+			return nil, fmt.Errorf("%w: the event is already linked to this channel", ErrInvalidEventPayload)
+		}
+	}
+
+	var attachment *model.SlackAttachment
+	timezone, err := m.GetTimezone(user)
+	if err == nil {
+		attachment, err = views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
+	}
+	if err != nil {
+		m.Logger.With(bot.LogContext{"err": err.Error()}).Warnf("error rendering event as attachment")
+	}
+
+	if err = m.linkEventToChannel(user, event, channelID, attachment); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (m *mscalendar) UnlinkEventFromChannel(user *User, eventID, channelID string) (*remote.Event, error) {
+	event, err := m.getEventToLink(user, eventID, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	linked := false
+	for _, linkedChannelID := range m.linkedChannelIDs(event) {
+		linked = linked || linkedChannelID == channelID
+	}
+	if !linked {
+		return nil, fmt.Errorf("%w: the event is not linked to this channel", ErrInvalidEventPayload)
+	}
+
+	if err = m.Store.DeleteLinkedChannelFromEvent(event.ICalUID, channelID); err != nil {
+		return nil, errors.Wrap(err, "error deleting event linked channel")
+	}
+	if err = m.Store.DeleteUserLinkedEvent(user.MattermostUserID, event.ICalUID, channelID); err != nil {
+		return nil, errors.Wrap(err, "error deleting user linked event")
+	}
+
+	post := &model.Post{
+		Message:   fmt.Sprintf("The event **%s** was unlinked from this channel by @%s", views.EnsureSubject(event.Subject), user.MattermostUsername),
+		ChannelId: channelID,
+	}
+	if err = m.Poster.CreatePost(post); err != nil {
+		m.Logger.With(bot.LogContext{"err": err}).Errorf("error sending post to channel about unlinked event")
+	}
+
+	return event, nil
+}
+
+func (m *mscalendar) LinkedChannelNames(events []*remote.Event) map[string][]string {
+	names := map[string][]string{}
+	for _, event := range events {
+		for _, channelID := range m.linkedChannelIDs(event) {
+			channel, err := m.PluginAPI.GetChannel(channelID)
+			if err != nil {
+				m.Logger.With(bot.LogContext{"err": err.Error(), "channelID": channelID}).Warnf("Failed to get linked channel.")
+				continue
+			}
+			names[event.ID] = append(names[event.ID], channel.Name)
+		}
+		sort.Strings(names[event.ID])
+	}
+	return names
+}
+
+// getEventToLink checks that the user can post in the channel, and returns the event.
+func (m *mscalendar) getEventToLink(user *User, eventID, channelID string) (*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID) {
+		return nil, fmt.Errorf("%w: you don't have permission to link events in the selected channel", ErrInvalidEventPayload)
+	}
+
+	event, err := m.client.GetEvent(user.Remote.ID, eventID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting event")
+	}
+	return event, nil
+}
+
+// NewPostActionForLinkToChannel returns a channel selector linking the event to the selected channel.
+func NewPostActionForLinkToChannel(eventID, url string) *model.PostAction {
+	return &model.PostAction{
+		Name:       "Link to channel",
+		Type:       model.PostActionTypeSelect,
+		DataSource: "channels",
+		Integration: &model.PostActionIntegration{
+			URL: url,
+			Context: map[string]interface{}{
+				config.EventIDKey: eventID,
+			},
+		},
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot/mock_bot"
+)
+
+func TestLinkEventToChannel(t *testing.T) {
+	for name, tc := range map[string]struct {
+		canLink          bool
+		cancelled        bool
+		linkedChannelIDs map[string]struct{}
+		expectedError    string
+	}{
+		"linked": {
+			canLink: true,
+		},
+		"linked to another channel": {
+			canLink:          true,
+			linkedChannelIDs: map[string]struct{}{"other_channel_id": {}},
+		},
+		"no permission": {
+			expectedError: "invalid event: you don't have permission to link events in the selected channel",
+		},
+		"already linked": {
+			canLink:          true,
+			linkedChannelIDs: map[string]struct{}{"channel_id": {}},
+			expectedError:    "invalid event: the event is already linked to this channel",
+		},
+		"cancelled": {
+			canLink:       true,
+			cancelled:     true,
+			expectedError: "invalid event: the event is cancelled",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			env, client := makeStatusSyncTestEnv(ctrl)
+			mockClient := client.(*mock_remote.MockClient)
+			s := env.Dependencies.Store.(*mock_store.MockStore)
+			papi := env.Dependencies.PluginAPI.(*mock_plugin_api.MockPluginAPI)
+			poster := env.Dependencies.Poster.(*mock_bot.MockPoster)
+			logger := env.Dependencies.Logger.(*mock_bot.MockLogger)
+			logger.EXPECT().With(gomock.Any()).Return(logger).AnyTimes()
+			logger.EXPECT().Warnf(gomock.Any()).AnyTimes()
+
+			user := &User{
+				MattermostUserID: "user_mm_id",
+				User:             &store.User{MattermostUserID: "user_mm_id", MattermostUsername: "alice", Remote: &remote.User{ID: "user_remote_id"}},
+				MattermostUser:   &model.User{Id: "user_mm_id", Username: "alice"},
+			}
+			m := &mscalendar{Env: env, client: client}
+
+			event := newTestEvent("1", "Room 1", "Planning")
+			event.Start = remote.NewDateTime(time.Now().UTC(), "UTC")
+			event.End = remote.NewDateTime(time.Now().Add(time.Hour).UTC(), "UTC")
+			event.IsCancelled = tc.cancelled
+
+			papi.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(tc.canLink)
+			if tc.canLink {
+				mockClient.EXPECT().GetEvent("user_remote_id", "remote_event_id_1").Return(event, nil)
+			}
+			if tc.canLink && !tc.cancelled {
+				if tc.linkedChannelIDs != nil {
+					s.EXPECT().LoadEventMetadata("remote_event_uid_1").Return(&store.EventMetadata{LinkedChannelIDs: tc.linkedChannelIDs}, nil)
+				} else {
+					s.EXPECT().LoadEventMetadata("remote_event_uid_1").Return(nil, store.ErrNotFound)
+				}
+			}
+			if tc.expectedError == "" {
+				mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+				s.EXPECT().StoreUserLinkedEvent("user_mm_id", "remote_event_uid_1", "channel_id").Return(nil)
+				s.EXPECT().AddLinkedChannelToEvent("remote_event_uid_1", "channel_id").Return(nil)
+				poster.EXPECT().CreatePost(gomock.Any()).DoAndReturn(func(post *model.Post) error {
+					require.Equal(t, "channel_id", post.ChannelId)
+					require.Equal(t, "The event **Planning** was linked to this channel by @alice", post.Message)
+					return nil
+				})
+			}
+
+			linked, err := m.LinkEventToChannel(user, "remote_event_id_1", "channel_id")
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, event, linked)
+		})
+	}
+}
+
+func TestUnlinkEventFromChannel(t *testing.T) {
+	for name, tc := range map[string]struct {
+		linkedChannelIDs map[string]struct{}
+		expectedError    string
+	}{
+		"unlinked": {
+			linkedChannelIDs: map[string]struct{}{"channel_id": {}, "other_channel_id": {}},
+		},
+		"not linked": {
+			linkedChannelIDs: map[string]struct{}{"other_channel_id": {}},
+			expectedError:    "invalid event: the event is not linked to this channel",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			env, client := makeStatusSyncTestEnv(ctrl)
+			mockClient := client.(*mock_remote.MockClient)
+			s := env.Dependencies.Store.(*mock_store.MockStore)
+			papi := env.Dependencies.PluginAPI.(*mock_plugin_api.MockPluginAPI)
+			poster := env.Dependencies.Poster.(*mock_bot.MockPoster)
+
+			user := &User{
+				MattermostUserID: "user_mm_id",
+				User:             &store.User{MattermostUserID: "user_mm_id", MattermostUsername: "alice", Remote: &remote.User{ID: "user_remote_id"}},
+				MattermostUser:   &model.User{Id: "user_mm_id", Username: "alice"},
+			}
+			m := &mscalendar{Env: env, client: client}
+
+			papi.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+			mockClient.EXPECT().GetEvent("user_remote_id", "remote_event_id_1").Return(newTestEvent("1", "Room 1", "Planning"), nil)
+			s.EXPECT().LoadEventMetadata("remote_event_uid_1").Return(&store.EventMetadata{LinkedChannelIDs: tc.linkedChannelIDs}, nil)
+			if tc.expectedError == "" {
+				s.EXPECT().DeleteLinkedChannelFromEvent("remote_event_uid_1", "channel_id").Return(nil)
+				s.EXPECT().DeleteUserLinkedEvent("user_mm_id", "remote_event_uid_1", "channel_id").Return(nil)
+				poster.EXPECT().CreatePost(gomock.Any()).Return(nil)
+			}
+
+			_, err := m.UnlinkEventFromChannel(user, "remote_event_id_1", "channel_id")
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
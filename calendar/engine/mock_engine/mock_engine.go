@@ -12,6 +12,7 @@ import (
 	engine "github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
 	remote "github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 	store "github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	model "github.com/mattermost/mattermost/server/public/model"
 )
 
 // MockEngine is a mock of Engine interface.
@@ -51,6 +52,34 @@ func (mr *MockEngineMockRecorder) AcceptEvent(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptEvent", reflect.TypeOf((*MockEngine)(nil).AcceptEvent), arg0, arg1)
 }
 
+// AcceptProposedTime mocks base method.
+func (m *MockEngine) AcceptProposedTime(arg0 *engine.User, arg1 string, arg2 *remote.TimeSlot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptProposedTime", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptProposedTime indicates an expected call of AcceptProposedTime.
+func (mr *MockEngineMockRecorder) AcceptProposedTime(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptProposedTime", reflect.TypeOf((*MockEngine)(nil).AcceptProposedTime), arg0, arg1, arg2)
+}
+
+// AddCustomStatusRule mocks base method.
+func (m *MockEngine) AddCustomStatusRule(arg0 *engine.User, arg1 *store.CustomStatusRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCustomStatusRule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCustomStatusRule indicates an expected call of AddCustomStatusRule.
+func (mr *MockEngineMockRecorder) AddCustomStatusRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCustomStatusRule", reflect.TypeOf((*MockEngine)(nil).AddCustomStatusRule), arg0, arg1)
+}
+
 // AfterDisconnect mocks base method.
 func (m *MockEngine) AfterDisconnect(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -79,6 +108,20 @@ func (mr *MockEngineMockRecorder) AfterSuccessfullyConnect(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AfterSuccessfullyConnect", reflect.TypeOf((*MockEngine)(nil).AfterSuccessfullyConnect), arg0, arg1)
 }
 
+// CancelEvent mocks base method.
+func (m *MockEngine) CancelEvent(arg0 *engine.User, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelEvent indicates an expected call of CancelEvent.
+func (mr *MockEngineMockRecorder) CancelEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEvent", reflect.TypeOf((*MockEngine)(nil).CancelEvent), arg0, arg1, arg2)
+}
+
 // ClearSettingsPosts mocks base method.
 func (m *MockEngine) ClearSettingsPosts(arg0 string) {
 	m.ctrl.T.Helper()
@@ -121,6 +164,21 @@ func (mr *MockEngineMockRecorder) CreateEvent(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockEngine)(nil).CreateEvent), arg0, arg1, arg2)
 }
 
+// CreateEventFromPayload mocks base method.
+func (m *MockEngine) CreateEventFromPayload(arg0 *engine.User, arg1 engine.CreateEventPayload) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventFromPayload", arg0, arg1)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEventFromPayload indicates an expected call of CreateEventFromPayload.
+func (mr *MockEngineMockRecorder) CreateEventFromPayload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventFromPayload", reflect.TypeOf((*MockEngine)(nil).CreateEventFromPayload), arg0, arg1)
+}
+
 // CreateMyEventSubscription mocks base method.
 func (m *MockEngine) CreateMyEventSubscription() (*store.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -164,6 +222,20 @@ func (mr *MockEngineMockRecorder) DeleteCalendar(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendar", reflect.TypeOf((*MockEngine)(nil).DeleteCalendar), arg0, arg1)
 }
 
+// DeleteEvent mocks base method.
+func (m *MockEngine) DeleteEvent(arg0 *engine.User, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEvent indicates an expected call of DeleteEvent.
+func (mr *MockEngineMockRecorder) DeleteEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockEngine)(nil).DeleteEvent), arg0, arg1)
+}
+
 // DeleteMyEventSubscription mocks base method.
 func (m *MockEngine) DeleteMyEventSubscription() error {
 	m.ctrl.T.Helper()
@@ -192,6 +264,20 @@ func (mr *MockEngineMockRecorder) DeleteOrphanedSubscription(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedSubscription", reflect.TypeOf((*MockEngine)(nil).DeleteOrphanedSubscription), arg0)
 }
 
+// DeliverSnoozedReminders mocks base method.
+func (m *MockEngine) DeliverSnoozedReminders(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverSnoozedReminders", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeliverSnoozedReminders indicates an expected call of DeliverSnoozedReminders.
+func (mr *MockEngineMockRecorder) DeliverSnoozedReminders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverSnoozedReminders", reflect.TypeOf((*MockEngine)(nil).DeliverSnoozedReminders), arg0)
+}
+
 // DisconnectUser mocks base method.
 func (m *MockEngine) DisconnectUser(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -206,6 +292,35 @@ func (mr *MockEngineMockRecorder) DisconnectUser(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisconnectUser", reflect.TypeOf((*MockEngine)(nil).DisconnectUser), arg0)
 }
 
+// EndFocusBlocks mocks base method.
+func (m *MockEngine) EndFocusBlocks(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndFocusBlocks", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndFocusBlocks indicates an expected call of EndFocusBlocks.
+func (mr *MockEngineMockRecorder) EndFocusBlocks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndFocusBlocks", reflect.TypeOf((*MockEngine)(nil).EndFocusBlocks), arg0)
+}
+
+// ExportCalendar mocks base method.
+func (m *MockEngine) ExportCalendar(arg0 *engine.User, arg1, arg2 time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCalendar", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCalendar indicates an expected call of ExportCalendar.
+func (mr *MockEngineMockRecorder) ExportCalendar(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCalendar", reflect.TypeOf((*MockEngine)(nil).ExportCalendar), arg0, arg1, arg2)
+}
+
 // FindMeetingTimes mocks base method.
 func (m *MockEngine) FindMeetingTimes(arg0 *engine.User, arg1 *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error) {
 	m.ctrl.T.Helper()
@@ -221,6 +336,21 @@ func (mr *MockEngineMockRecorder) FindMeetingTimes(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMeetingTimes", reflect.TypeOf((*MockEngine)(nil).FindMeetingTimes), arg0, arg1)
 }
 
+// FindTime mocks base method.
+func (m *MockEngine) FindTime(arg0 *engine.User, arg1 engine.FindTimePayload) (*remote.MeetingTimeSuggestionResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTime", arg0, arg1)
+	ret0, _ := ret[0].(*remote.MeetingTimeSuggestionResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTime indicates an expected call of FindTime.
+func (mr *MockEngineMockRecorder) FindTime(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTime", reflect.TypeOf((*MockEngine)(nil).FindTime), arg0, arg1)
+}
+
 // GetActingUser mocks base method.
 func (m *MockEngine) GetActingUser() *engine.User {
 	m.ctrl.T.Helper()
@@ -265,6 +395,36 @@ func (mr *MockEngineMockRecorder) GetCalendars(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendars", reflect.TypeOf((*MockEngine)(nil).GetCalendars), arg0)
 }
 
+// GetChannelCalendar mocks base method.
+func (m *MockEngine) GetChannelCalendar(arg0 string) (*store.ChannelCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelCalendar", arg0)
+	ret0, _ := ret[0].(*store.ChannelCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelCalendar indicates an expected call of GetChannelCalendar.
+func (mr *MockEngineMockRecorder) GetChannelCalendar(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelCalendar", reflect.TypeOf((*MockEngine)(nil).GetChannelCalendar), arg0)
+}
+
+// GetCustomStatusRules mocks base method.
+func (m *MockEngine) GetCustomStatusRules(arg0 *engine.User) ([]*store.CustomStatusRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomStatusRules", arg0)
+	ret0, _ := ret[0].([]*store.CustomStatusRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomStatusRules indicates an expected call of GetCustomStatusRules.
+func (mr *MockEngineMockRecorder) GetCustomStatusRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomStatusRules", reflect.TypeOf((*MockEngine)(nil).GetCustomStatusRules), arg0)
+}
+
 // GetDailySummarySettingsForUser mocks base method.
 func (m *MockEngine) GetDailySummarySettingsForUser(arg0 *engine.User) (*store.DailySummaryUserSettings, error) {
 	m.ctrl.T.Helper()
@@ -310,6 +470,21 @@ func (mr *MockEngineMockRecorder) GetRemoteUser(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteUser", reflect.TypeOf((*MockEngine)(nil).GetRemoteUser), arg0)
 }
 
+// GetSchedule mocks base method.
+func (m *MockEngine) GetSchedule(arg0 *engine.User, arg1 []string, arg2 string) (*engine.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*engine.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockEngineMockRecorder) GetSchedule(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockEngine)(nil).GetSchedule), arg0, arg1, arg2)
+}
+
 // GetTimezone mocks base method.
 func (m *MockEngine) GetTimezone(arg0 *engine.User) (string, error) {
 	m.ctrl.T.Helper()
@@ -340,6 +515,21 @@ func (mr *MockEngineMockRecorder) GetUserSettings(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSettings", reflect.TypeOf((*MockEngine)(nil).GetUserSettings), arg0)
 }
 
+// ImportEvent mocks base method.
+func (m *MockEngine) ImportEvent(arg0 *engine.User, arg1 string, arg2 int, arg3 string) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportEvent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportEvent indicates an expected call of ImportEvent.
+func (mr *MockEngineMockRecorder) ImportEvent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportEvent", reflect.TypeOf((*MockEngine)(nil).ImportEvent), arg0, arg1, arg2, arg3)
+}
+
 // IsAuthorizedAdmin mocks base method.
 func (m *MockEngine) IsAuthorizedAdmin(arg0 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -355,6 +545,35 @@ func (mr *MockEngineMockRecorder) IsAuthorizedAdmin(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAuthorizedAdmin", reflect.TypeOf((*MockEngine)(nil).IsAuthorizedAdmin), arg0)
 }
 
+// LinkEventToChannel mocks base method.
+func (m *MockEngine) LinkEventToChannel(arg0 *engine.User, arg1, arg2 string) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkEventToChannel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkEventToChannel indicates an expected call of LinkEventToChannel.
+func (mr *MockEngineMockRecorder) LinkEventToChannel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkEventToChannel", reflect.TypeOf((*MockEngine)(nil).LinkEventToChannel), arg0, arg1, arg2)
+}
+
+// LinkedChannelNames mocks base method.
+func (m *MockEngine) LinkedChannelNames(arg0 []*remote.Event) map[string][]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkedChannelNames", arg0)
+	ret0, _ := ret[0].(map[string][]string)
+	return ret0
+}
+
+// LinkedChannelNames indicates an expected call of LinkedChannelNames.
+func (mr *MockEngineMockRecorder) LinkedChannelNames(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkedChannelNames", reflect.TypeOf((*MockEngine)(nil).LinkedChannelNames), arg0)
+}
+
 // ListRemoteSubscriptions mocks base method.
 func (m *MockEngine) ListRemoteSubscriptions() ([]*remote.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -385,6 +604,50 @@ func (mr *MockEngineMockRecorder) LoadMyEventSubscription() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadMyEventSubscription", reflect.TypeOf((*MockEngine)(nil).LoadMyEventSubscription))
 }
 
+// NotifyRunningLate mocks base method.
+func (m *MockEngine) NotifyRunningLate(arg0 *engine.User, arg1, arg2 string) (int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyRunningLate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// NotifyRunningLate indicates an expected call of NotifyRunningLate.
+func (mr *MockEngineMockRecorder) NotifyRunningLate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyRunningLate", reflect.TypeOf((*MockEngine)(nil).NotifyRunningLate), arg0, arg1, arg2)
+}
+
+// OfferEventImport mocks base method.
+func (m *MockEngine) OfferEventImport(arg0 *model.Post) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OfferEventImport", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OfferEventImport indicates an expected call of OfferEventImport.
+func (mr *MockEngineMockRecorder) OfferEventImport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferEventImport", reflect.TypeOf((*MockEngine)(nil).OfferEventImport), arg0)
+}
+
+// PreviewEventImport mocks base method.
+func (m *MockEngine) PreviewEventImport(arg0 *engine.User, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewEventImport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PreviewEventImport indicates an expected call of PreviewEventImport.
+func (mr *MockEngineMockRecorder) PreviewEventImport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewEventImport", reflect.TypeOf((*MockEngine)(nil).PreviewEventImport), arg0, arg1, arg2)
+}
+
 // PrintSettings mocks base method.
 func (m *MockEngine) PrintSettings(arg0 string) {
 	m.ctrl.T.Helper()
@@ -397,6 +660,20 @@ func (mr *MockEngineMockRecorder) PrintSettings(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrintSettings", reflect.TypeOf((*MockEngine)(nil).PrintSettings), arg0)
 }
 
+// ProcessAllChannelAgendas mocks base method.
+func (m *MockEngine) ProcessAllChannelAgendas(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessAllChannelAgendas", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessAllChannelAgendas indicates an expected call of ProcessAllChannelAgendas.
+func (mr *MockEngineMockRecorder) ProcessAllChannelAgendas(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAllChannelAgendas", reflect.TypeOf((*MockEngine)(nil).ProcessAllChannelAgendas), arg0)
+}
+
 // ProcessAllDailySummary mocks base method.
 func (m *MockEngine) ProcessAllDailySummary(arg0 time.Time) error {
 	m.ctrl.T.Helper()
@@ -411,6 +688,49 @@ func (mr *MockEngineMockRecorder) ProcessAllDailySummary(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAllDailySummary", reflect.TypeOf((*MockEngine)(nil).ProcessAllDailySummary), arg0)
 }
 
+// ProposeNewTime mocks base method.
+func (m *MockEngine) ProposeNewTime(arg0 *engine.User, arg1 string, arg2 engine.ProposeNewTimePayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeNewTime", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProposeNewTime indicates an expected call of ProposeNewTime.
+func (mr *MockEngineMockRecorder) ProposeNewTime(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeNewTime", reflect.TypeOf((*MockEngine)(nil).ProposeNewTime), arg0, arg1, arg2)
+}
+
+// RemoveCustomStatusRule mocks base method.
+func (m *MockEngine) RemoveCustomStatusRule(arg0 *engine.User, arg1 int) (*store.CustomStatusRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCustomStatusRule", arg0, arg1)
+	ret0, _ := ret[0].(*store.CustomStatusRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveCustomStatusRule indicates an expected call of RemoveCustomStatusRule.
+func (mr *MockEngineMockRecorder) RemoveCustomStatusRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCustomStatusRule", reflect.TypeOf((*MockEngine)(nil).RemoveCustomStatusRule), arg0, arg1)
+}
+
+// RenewChannelCalendarSubscriptions mocks base method.
+func (m *MockEngine) RenewChannelCalendarSubscriptions() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewChannelCalendarSubscriptions")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewChannelCalendarSubscriptions indicates an expected call of RenewChannelCalendarSubscriptions.
+func (mr *MockEngineMockRecorder) RenewChannelCalendarSubscriptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewChannelCalendarSubscriptions", reflect.TypeOf((*MockEngine)(nil).RenewChannelCalendarSubscriptions))
+}
+
 // RenewMyEventSubscription mocks base method.
 func (m *MockEngine) RenewMyEventSubscription() (*store.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -440,6 +760,35 @@ func (mr *MockEngineMockRecorder) RespondToEvent(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondToEvent", reflect.TypeOf((*MockEngine)(nil).RespondToEvent), arg0, arg1, arg2)
 }
 
+// SendCalendarExport mocks base method.
+func (m *MockEngine) SendCalendarExport(arg0 *engine.User, arg1, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCalendarExport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCalendarExport indicates an expected call of SendCalendarExport.
+func (mr *MockEngineMockRecorder) SendCalendarExport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCalendarExport", reflect.TypeOf((*MockEngine)(nil).SendCalendarExport), arg0, arg1, arg2)
+}
+
+// SetChannelAgendaTime mocks base method.
+func (m *MockEngine) SetChannelAgendaTime(arg0 *engine.User, arg1, arg2 string) (*store.ChannelCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChannelAgendaTime", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*store.ChannelCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChannelAgendaTime indicates an expected call of SetChannelAgendaTime.
+func (mr *MockEngineMockRecorder) SetChannelAgendaTime(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChannelAgendaTime", reflect.TypeOf((*MockEngine)(nil).SetChannelAgendaTime), arg0, arg1, arg2)
+}
+
 // SetDailySummaryEnabled mocks base method.
 func (m *MockEngine) SetDailySummaryEnabled(arg0 *engine.User, arg1 bool) (*store.DailySummaryUserSettings, error) {
 	m.ctrl.T.Helper()
@@ -470,6 +819,66 @@ func (mr *MockEngineMockRecorder) SetDailySummaryPostTime(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDailySummaryPostTime", reflect.TypeOf((*MockEngine)(nil).SetDailySummaryPostTime), arg0, arg1)
 }
 
+// SnoozeReminder mocks base method.
+func (m *MockEngine) SnoozeReminder(arg0 *engine.User, arg1 string, arg2 time.Duration) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnoozeReminder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnoozeReminder indicates an expected call of SnoozeReminder.
+func (mr *MockEngineMockRecorder) SnoozeReminder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeReminder", reflect.TypeOf((*MockEngine)(nil).SnoozeReminder), arg0, arg1, arg2)
+}
+
+// StartFocusBlock mocks base method.
+func (m *MockEngine) StartFocusBlock(arg0 *engine.User, arg1 time.Duration, arg2 string) (*store.FocusBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartFocusBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*store.FocusBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartFocusBlock indicates an expected call of StartFocusBlock.
+func (mr *MockEngineMockRecorder) StartFocusBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartFocusBlock", reflect.TypeOf((*MockEngine)(nil).StartFocusBlock), arg0, arg1, arg2)
+}
+
+// StopFocusBlock mocks base method.
+func (m *MockEngine) StopFocusBlock(arg0 *engine.User) (*store.FocusBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopFocusBlock", arg0)
+	ret0, _ := ret[0].(*store.FocusBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopFocusBlock indicates an expected call of StopFocusBlock.
+func (mr *MockEngineMockRecorder) StopFocusBlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopFocusBlock", reflect.TypeOf((*MockEngine)(nil).StopFocusBlock), arg0)
+}
+
+// SubscribeChannelCalendar mocks base method.
+func (m *MockEngine) SubscribeChannelCalendar(arg0 *engine.User, arg1, arg2 string) (*store.ChannelCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeChannelCalendar", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*store.ChannelCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeChannelCalendar indicates an expected call of SubscribeChannelCalendar.
+func (mr *MockEngineMockRecorder) SubscribeChannelCalendar(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeChannelCalendar", reflect.TypeOf((*MockEngine)(nil).SubscribeChannelCalendar), arg0, arg1, arg2)
+}
+
 // Sync mocks base method.
 func (m *MockEngine) Sync(arg0 string) (string, *engine.StatusSyncJobSummary, error) {
 	m.ctrl.T.Helper()
@@ -516,6 +925,51 @@ func (mr *MockEngineMockRecorder) TentativelyAcceptEvent(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TentativelyAcceptEvent", reflect.TypeOf((*MockEngine)(nil).TentativelyAcceptEvent), arg0, arg1)
 }
 
+// UnlinkEventFromChannel mocks base method.
+func (m *MockEngine) UnlinkEventFromChannel(arg0 *engine.User, arg1, arg2 string) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkEventFromChannel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlinkEventFromChannel indicates an expected call of UnlinkEventFromChannel.
+func (mr *MockEngineMockRecorder) UnlinkEventFromChannel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkEventFromChannel", reflect.TypeOf((*MockEngine)(nil).UnlinkEventFromChannel), arg0, arg1, arg2)
+}
+
+// UnsubscribeChannelCalendar mocks base method.
+func (m *MockEngine) UnsubscribeChannelCalendar(arg0 *engine.User, arg1 string) (*store.ChannelCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeChannelCalendar", arg0, arg1)
+	ret0, _ := ret[0].(*store.ChannelCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsubscribeChannelCalendar indicates an expected call of UnsubscribeChannelCalendar.
+func (mr *MockEngineMockRecorder) UnsubscribeChannelCalendar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeChannelCalendar", reflect.TypeOf((*MockEngine)(nil).UnsubscribeChannelCalendar), arg0, arg1)
+}
+
+// UpdateEvent mocks base method.
+func (m *MockEngine) UpdateEvent(arg0 *engine.User, arg1 string, arg2 engine.UpdateEventPayload) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEvent indicates an expected call of UpdateEvent.
+func (mr *MockEngineMockRecorder) UpdateEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockEngine)(nil).UpdateEvent), arg0, arg1, arg2)
+}
+
 // ViewCalendar mocks base method.
 func (m *MockEngine) ViewCalendar(arg0 *engine.User, arg1, arg2 time.Time) ([]*remote.Event, error) {
 	m.ctrl.T.Helper()
@@ -17,7 +17,13 @@ import (
 type Engine interface {
 	Availability
 	Calendar
+	ChannelCalendars
+	CustomStatusRules
+	EventImporter
+	EventLinker
 	EventResponder
+	Focus
+	ReminderActions
 	Subscriptions
 	Users
 	Welcomer
@@ -48,10 +54,15 @@ type PluginAPI interface {
 	UpdateMattermostUserCustomStatus(mattermostUserID string, customStatus *model.CustomStatus) *model.AppError
 	RemoveMattermostUserCustomStatus(mattermostUserID string) *model.AppError
 	GetPost(postID string) (*model.Post, error)
+	GetChannel(channelID string) (*model.Channel, error)
 	CanLinkEventToChannel(channelID, userID string) bool
+	CanReadChannel(channelID, userID string) bool
 	SearchLinkableChannelForUser(teamID, mattermostUserID, search string) ([]*model.Channel, error)
 	GetMattermostUserTeams(mattermostUserID string) ([]*model.Team, error)
 	PublishWebsocketEvent(mattermostUserID, event string, payload map[string]any)
+	OpenInteractiveDialog(dialog model.OpenDialogRequest) error
+	GetFileInfo(fileID string) (*model.FileInfo, error)
+	GetFile(fileID string) ([]byte, error)
 }
 
 type Env struct {
@@ -28,6 +28,7 @@ const (
 	FieldAttendees      = "Attendees"
 	FieldOrganizer      = "Organizer"
 	FieldResponseStatus = "ResponseStatus"
+	FieldRecurrence     = "Recurrence"
 )
 
 const (
@@ -44,13 +45,14 @@ const (
 	ResponseNone  = "notResponded"
 )
 
-var importantNotificationChanges = []string{FieldSubject, FieldWhen}
+var importantNotificationChanges = []string{FieldSubject, FieldWhen, FieldRecurrence}
 
 var notificationFieldOrder = []string{
 	FieldWhen,
 	FieldLocation,
 	FieldAttendees,
 	FieldImportance,
+	FieldRecurrence,
 }
 
 type NotificationProcessor interface {
@@ -122,6 +124,9 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 	if err != nil {
 		return err
 	}
+	if sub.ChannelID != "" {
+		return processor.processChannelNotification(n, sub)
+	}
 	creator, err := processor.Store.LoadUser(sub.MattermostCreatorID)
 	if err != nil {
 		return err
@@ -166,6 +171,10 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 			return err
 		}
 	}
+	if n.ChangeType == remote.ChangeTypeDeleted {
+		// The cancellations of the events of the user are notified as updates
+		return nil
+	}
 
 	var sa *model.SlackAttachment
 	prior, err := processor.Store.LoadUserEvent(creator.MattermostUserID, n.Event.ICalUID)
@@ -179,10 +188,17 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 	}
 	timezone := mailSettings.TimeZone
 
+	var priorEvent *remote.Event
+	if prior != nil {
+		priorEvent = prior.Remote
+	}
+	proposals := processor.proposedTimeSlackAttachments(n, priorEvent, timezone)
+
+	var attachments []*model.SlackAttachment
 	if prior != nil {
 		var changed bool
 		changed, sa = processor.updatedEventSlackAttachment(n, prior.Remote, timezone)
-		if !changed {
+		if !changed && len(proposals) == 0 {
 			processor.Logger.With(bot.LogContext{
 				"MattermostUserID": creator.MattermostUserID,
 				"SubscriptionID":   n.SubscriptionID,
@@ -192,12 +208,17 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 			}).Debugf("webhook notification: no changes detected in event.")
 			return nil
 		}
+		if changed {
+			attachments = append(attachments, sa)
+		}
 	} else {
 		sa = processor.newEventSlackAttachment(n, timezone)
+		attachments = append(attachments, sa)
 		prior = &store.Event{}
 	}
+	attachments = append(attachments, proposals...)
 
-	_, err = processor.Poster.DMWithAttachments(creator.MattermostUserID, sa)
+	err = holdOrDM(processor.Dependencies, creator, attachments...)
 	if err != nil {
 		return err
 	}
@@ -211,7 +232,7 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 	processor.Logger.With(bot.LogContext{
 		"MattermostUserID": creator.MattermostUserID,
 		"SubscriptionID":   n.SubscriptionID,
-	}).Debugf("Notified: %s.", sa.Title)
+	}).Debugf("Notified: %s.", attachments[0].Title)
 
 	return nil
 }
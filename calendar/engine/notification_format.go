@@ -33,7 +33,10 @@ func (processor *notificationProcessor) newEventSlackAttachment(n *remote.Notifi
 
 	fields := eventToFields(n.Event, timezone)
 	for _, k := range notificationFieldOrder {
-		v := fields[k]
+		v, ok := fields[k]
+		if !ok {
+			continue
+		}
 
 		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
 			Title: k,
@@ -44,6 +47,10 @@ func (processor *notificationProcessor) newEventSlackAttachment(n *remote.Notifi
 
 	if n.Event.ResponseRequested && !n.Event.IsOrganizer {
 		sa.Actions = NewPostActionForEventResponse(n.Event.ID, n.Event.ResponseStatus.Response, processor.actionURL(config.PathRespond))
+		sa.Actions = append(sa.Actions, NewPostActionForProposeNewTime(n.Event.ID, processor.actionURL(config.PathProposeNewTime)))
+	}
+	if !n.Event.IsCancelled {
+		sa.Actions = append(sa.Actions, NewPostActionForLinkToChannel(n.Event.ID, processor.actionURL(config.PathLinkEvent)))
 	}
 	return sa
 }
@@ -109,10 +116,85 @@ func (processor *notificationProcessor) updatedEventSlackAttachment(n *remote.No
 
 	if n.Event.ResponseRequested && !n.Event.IsOrganizer && !n.Event.IsCancelled {
 		sa.Actions = NewPostActionForEventResponse(n.Event.ID, n.Event.ResponseStatus.Response, processor.actionURL(config.PathRespond))
+		sa.Actions = append(sa.Actions, NewPostActionForProposeNewTime(n.Event.ID, processor.actionURL(config.PathProposeNewTime)))
+	}
+	if !n.Event.IsCancelled {
+		sa.Actions = append(sa.Actions, NewPostActionForLinkToChannel(n.Event.ID, processor.actionURL(config.PathLinkEvent)))
 	}
 	return true, sa
 }
 
+// proposedTimeSlackAttachments returns an attachment for every new time proposed by the attendees of an event
+// organized by the user, with an action to accept it.
+func (processor *notificationProcessor) proposedTimeSlackAttachments(n *remote.Notification, prior *remote.Event, timezone string) []*model.SlackAttachment {
+	if !n.Event.IsOrganizer || n.Event.IsCancelled {
+		return nil
+	}
+
+	priorProposals := map[string]string{}
+	if prior != nil {
+		for _, a := range prior.Attendees {
+			if a.EmailAddress != nil && a.ProposedNewTime != nil {
+				priorProposals[a.EmailAddress.Address] = formatTimeSlot(a.ProposedNewTime, timezone)
+			}
+		}
+	}
+
+	var attachments []*model.SlackAttachment
+	for _, a := range n.Event.Attendees {
+		if a.EmailAddress == nil || a.ProposedNewTime == nil || a.ProposedNewTime.Start == nil || a.ProposedNewTime.End == nil {
+			continue
+		}
+
+		proposal := formatTimeSlot(a.ProposedNewTime, timezone)
+		if priorProposals[a.EmailAddress.Address] == proposal {
+			continue
+		}
+
+		sa := processor.newSlackAttachment(n)
+		sa.Title = "(new time proposed) " + sa.Title
+		sa.Text = fmt.Sprintf("[%s](mailto:%s) proposed a new time for this event.", a.EmailAddress.Name, a.EmailAddress.Address)
+		sa.Fallback = fmt.Sprintf("%s proposed a new time for %s: %s", a.EmailAddress.Name, views.EnsureSubject(n.Event.Subject), proposal)
+		sa.Fields = []*model.SlackAttachmentField{
+			{
+				Title: "Current time",
+				Value: eventToFields(n.Event, timezone)[FieldWhen].Strings()[0],
+				Short: true,
+			},
+			{
+				Title: "Proposed time",
+				Value: proposal,
+				Short: true,
+			},
+		}
+		sa.Actions = []*model.PostAction{NewPostActionForAcceptProposedTime(n.Event.ID, a.ProposedNewTime, processor.actionURL(config.PathAcceptProposedTime))}
+		attachments = append(attachments, sa)
+	}
+
+	return attachments
+}
+
+// formatTimeRange formats the time range in the time zone, like "Monday, January 02 · (3:04PM - 4:04PM)", with
+// the year when it isn't the current one.
+func formatTimeRange(dtStart, dtEnd *remote.DateTime, timezone string) (time.Time, time.Time, string) {
+	if dtStart == nil || dtEnd == nil {
+		return time.Time{}, time.Time{}, "n/a"
+	}
+
+	start := dtStart.In(timezone).Time()
+	end := dtEnd.In(timezone).Time()
+	startFormat := "Monday, January 02"
+	if start.Year() != time.Now().Year() {
+		startFormat = "Monday, January 02, 2006"
+	}
+	return start, end, start.Format(startFormat+" · ("+time.Kitchen) + end.Format(" - "+time.Kitchen+")")
+}
+
+func formatTimeSlot(slot *remote.TimeSlot, timezone string) string {
+	_, _, formatted := formatTimeRange(slot.Start, slot.End, timezone)
+	return formatted
+}
+
 func isImportantChange(fieldName string) bool {
 	for _, ic := range importantNotificationChanges {
 		if ic == fieldName {
@@ -156,26 +238,37 @@ func NewPostActionForEventResponse(eventID, response, url string) []*model.PostA
 	return []*model.PostAction{pa}
 }
 
-func eventToFields(e *remote.Event, timezone string) fields.Fields {
-	date := func(dtStart, dtEnd *remote.DateTime) (time.Time, time.Time, string) {
-		if dtStart == nil || dtEnd == nil {
-			return time.Time{}, time.Time{}, "n/a"
-		}
+func NewPostActionForProposeNewTime(eventID, url string) *model.PostAction {
+	return &model.PostAction{
+		Name: "Propose new time",
+		Type: model.PostActionTypeButton,
+		Integration: &model.PostActionIntegration{
+			URL: url,
+			Context: map[string]interface{}{
+				config.EventIDKey: eventID,
+			},
+		},
+	}
+}
 
-		dtStart = dtStart.In(timezone)
-		dtEnd = dtEnd.In(timezone)
-		tStart := dtStart.Time()
-		tEnd := dtEnd.Time()
-		startFormat := "Monday, January 02"
-		if tStart.Year() != time.Now().Year() {
-			startFormat = "Monday, January 02, 2006"
-		}
-		startFormat += " · (" + time.Kitchen
-		endFormat := " - " + time.Kitchen + ")"
-		return tStart, tEnd, tStart.Format(startFormat) + tEnd.Format(endFormat)
+func NewPostActionForAcceptProposedTime(eventID string, proposedTime *remote.TimeSlot, url string) *model.PostAction {
+	return &model.PostAction{
+		Name:  "Accept proposed time",
+		Type:  model.PostActionTypeButton,
+		Style: "primary",
+		Integration: &model.PostActionIntegration{
+			URL: url,
+			Context: map[string]interface{}{
+				config.EventIDKey:       eventID,
+				config.ProposedStartKey: proposedTime.Start.Time().Format(time.RFC3339),
+				config.ProposedEndKey:   proposedTime.End.Time().Format(time.RFC3339),
+			},
+		},
 	}
+}
 
-	start, end, formattedDate := date(e.Start, e.End)
+func eventToFields(e *remote.Event, timezone string) fields.Fields {
+	start, end, formattedDate := formatTimeRange(e.Start, e.End, timezone)
 
 	minutes := int(end.Sub(start).Round(time.Minute).Minutes())
 	hours := int(end.Sub(start).Hours())
@@ -233,6 +326,10 @@ func eventToFields(e *remote.Event, timezone string) fields.Fields {
 		FieldAttendees:      fields.NewMultiValue(attendees...),
 	}
 
+	if recurrence := views.RenderRecurrence(e); recurrence != "" {
+		ff[FieldRecurrence] = fields.NewStringValue(recurrence)
+	}
+
 	return ff
 }
 
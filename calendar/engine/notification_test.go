@@ -216,3 +216,75 @@ func TestProcessNotificationOverflow(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestProposedTimeSlackAttachments(t *testing.T) {
+	processor := &notificationProcessor{
+		Env: Env{
+			Config: &config.Config{
+				PluginURLPath: "/plugins/com.mattermost.mscalendar",
+			},
+		},
+	}
+
+	proposal := &remote.TimeSlot{
+		Start: &remote.DateTime{DateTime: "2030-05-16T14:00:00", TimeZone: "UTC"},
+		End:   &remote.DateTime{DateTime: "2030-05-16T15:00:00", TimeZone: "UTC"},
+	}
+	newEvent := func(proposedNewTime *remote.TimeSlot) *remote.Event {
+		event := newTestEvent("1", "location", "subject")
+		event.IsOrganizer = true
+		event.Start = &remote.DateTime{DateTime: "2030-05-15T14:00:00", TimeZone: "UTC"}
+		event.End = &remote.DateTime{DateTime: "2030-05-15T15:00:00", TimeZone: "UTC"}
+		event.Attendees = []*remote.Attendee{
+			{
+				EmailAddress:    &remote.EmailAddress{Address: "attendee@example.com", Name: "Attendee"},
+				ProposedNewTime: proposedNewTime,
+			},
+		}
+		return event
+	}
+
+	for name, tc := range map[string]struct {
+		event               *remote.Event
+		prior               *remote.Event
+		expectedAttachments int
+	}{
+		"new proposal": {
+			event:               newEvent(proposal),
+			prior:               newEvent(nil),
+			expectedAttachments: 1,
+		},
+		"proposal already notified": {
+			event:               newEvent(proposal),
+			prior:               newEvent(proposal),
+			expectedAttachments: 0,
+		},
+		"no proposal": {
+			event:               newEvent(nil),
+			prior:               nil,
+			expectedAttachments: 0,
+		},
+		"not the organizer": {
+			event: func() *remote.Event {
+				event := newEvent(proposal)
+				event.IsOrganizer = false
+				return event
+			}(),
+			expectedAttachments: 0,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			attachments := processor.proposedTimeSlackAttachments(&remote.Notification{Event: tc.event}, tc.prior, "UTC")
+			require.Len(t, attachments, tc.expectedAttachments)
+			if tc.expectedAttachments == 0 {
+				return
+			}
+
+			sa := attachments[0]
+			require.Equal(t, "Thursday, May 16, 2030 · (2:00PM - 3:00PM)", sa.Fields[1].Value)
+			require.Len(t, sa.Actions, 1)
+			require.Equal(t, "/plugins/com.mattermost.mscalendar/action/accept-proposal", sa.Actions[0].Integration.URL)
+			require.Equal(t, "2030-05-16T14:00:00Z", sa.Actions[0].Integration.Context[config.ProposedStartKey])
+		})
+	}
+}
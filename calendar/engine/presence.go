@@ -0,0 +1,241 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	outOfOfficeCustomStatusEmoji = "palm_tree"
+
+	// The mailbox settings are read by the status sync job every few minutes. They are kept for a few runs, so
+	// that a change of the working hours or of the automatic replies is seen within mailboxSettingsTTL.
+	mailboxSettingsTTL = 3 * StatusSyncJobInterval
+)
+
+// presence is the state of a user according to their mailbox settings.
+type presence struct {
+	outOfOfficeUntil    time.Time
+	location            *time.Location
+	outOfOffice         bool
+	outsideWorkingHours bool
+}
+
+// getPresence reads the mailbox settings of the user, with their own credentials when fetchIndividually is set.
+// The settings fetched by a previous run are used until they expire.
+func (m *mscalendar) getPresence(user *store.User, fetchIndividually bool, now time.Time) (*presence, error) {
+	mailbox, err := m.Store.LoadMailboxSettings(user.MattermostUserID)
+	if err == nil {
+		return newPresence(mailbox, now), nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		m.Logger.Warnf("Error loading the stored mailbox settings of user %s. err=%v", user.MattermostUserID, err)
+	}
+
+	client := m.client
+	if fetchIndividually {
+		engine, err := m.FilterCopy(withActingUser(user.MattermostUserID), withClient)
+		if err != nil {
+			return nil, err
+		}
+		client = engine.client
+	}
+	if client == nil {
+		return nil, errors.New("no client to get the mailbox settings")
+	}
+
+	mailbox, err = client.GetMailboxSettings(user.Remote.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting mailbox settings")
+	}
+
+	err = m.Store.StoreMailboxSettings(user.MattermostUserID, mailbox, mailboxSettingsTTL)
+	if err != nil {
+		m.Logger.Warnf("Error storing the mailbox settings of user %s. err=%v", user.MattermostUserID, err)
+	}
+
+	return newPresence(mailbox, now), nil
+}
+
+func newPresence(mailbox *remote.MailboxSettings, now time.Time) *presence {
+	loc, err := time.LoadLocation(tz.Go(mailbox.TimeZone))
+	if err != nil {
+		loc = time.UTC
+	}
+
+	p := &presence{
+		location:            loc,
+		outsideWorkingHours: !isWithinWorkingHours(now, mailbox.WorkingHours, loc),
+	}
+	p.outOfOffice, p.outOfOfficeUntil = mailbox.AutomaticRepliesSetting.ActiveAt(now)
+
+	return p
+}
+
+// isWithinWorkingHours returns whether the time is in the working hours, which are read in their own time
+// zone when it is set, or in loc.
+func isWithinWorkingHours(now time.Time, workingHours remote.WorkingHours, loc *time.Location) bool {
+	if name := tz.Go(workingHours.TimeZone.Name); name != "" {
+		if l, err := time.LoadLocation(name); err == nil {
+			loc = l
+		}
+	}
+	day := now.In(loc)
+
+	if len(workingHours.DaysOfWeek) > 0 {
+		isWorkday := false
+		for _, d := range workingHours.DaysOfWeek {
+			if strings.EqualFold(d, day.Weekday().String()) {
+				isWorkday = true
+				break
+			}
+		}
+		if !isWorkday {
+			return false
+		}
+	}
+
+	from, to := workingHoursForDay(day, workingHours)
+	return !day.Before(from) && day.Before(to)
+}
+
+// setStatusFromPresence sets the status of the user while their automatic replies are on, and outside their
+// working hours when they are not in a meeting. It returns whether the status was handled, in which case the
+// events are not used to set the status.
+func (m *mscalendar) setStatusFromPresence(user *store.User, status *model.Status, events []*remote.Event, p *presence) (string, bool, bool, error) {
+	inMeeting := user.IsConfiguredForStatusUpdates() && len(events) > 0
+
+	automaticStatus := ""
+	switch {
+	case p.outOfOffice && user.IsConfiguredForOutOfOfficeStatus():
+		automaticStatus = store.OutOfOfficeAutomaticStatus
+	case p.outsideWorkingHours && user.Settings.SetAwayOutsideWorkingHours && !inMeeting:
+		automaticStatus = store.OutsideWorkingHoursAutomaticStatus
+	}
+
+	if automaticStatus == user.AutomaticStatus {
+		if automaticStatus == "" {
+			return "", false, false, nil
+		}
+		return "Status already set from the mailbox settings. No status change.", true, false, nil
+	}
+
+	previous := user.AutomaticStatus
+	if err := m.Store.StoreUserAutomaticStatus(user.MattermostUserID, automaticStatus); err != nil {
+		return "", false, false, errors.Wrapf(err, "error in storing automatic status for user %s", user.MattermostUserID)
+	}
+	user.AutomaticStatus = automaticStatus
+
+	if automaticStatus == "" {
+		// Meetings take over the status, and a status changed by the user is kept
+		if inMeeting || status.Status != automaticStatusValue(user, previous) {
+			return "", false, false, nil
+		}
+		if _, appErr := m.PluginAPI.UpdateMattermostUserStatus(user.MattermostUserID, model.StatusOnline); appErr != nil {
+			return "", false, false, appErr
+		}
+		status.Status = model.StatusOnline
+		return "User is back according to the mailbox settings. Set status to online.", true, true, nil
+	}
+
+	toSet := automaticStatusValue(user, automaticStatus)
+	if status.Status == toSet {
+		return fmt.Sprintf("User is already %s. No status change.", toSet), true, false, nil
+	}
+	if _, appErr := m.PluginAPI.UpdateMattermostUserStatus(user.MattermostUserID, toSet); appErr != nil {
+		return "", true, false, appErr
+	}
+
+	return fmt.Sprintf("User is %s according to the mailbox settings. Set status to %s.", strings.ReplaceAll(automaticStatus, "_", " "), toSet), true, true, nil
+}
+
+// setOutOfOfficeCustomStatus sets a custom status while the automatic replies of the user are on, unless
+// the user set their own custom status.
+func (m *mscalendar) setOutOfOfficeCustomStatus(user *store.User, p *presence, now time.Time) (string, bool, error) {
+	if !p.outOfOffice {
+		if !user.IsOutOfOfficeCustomStatusSet {
+			return "", false, nil
+		}
+
+		if appErr := m.PluginAPI.RemoveMattermostUserCustomStatus(user.MattermostUserID); appErr != nil {
+			m.Logger.Warnf("Error removing user %s custom status. err=%v", user.MattermostUserID, appErr)
+		}
+		if err := m.Store.StoreUserOutOfOfficeCustomStatus(user.MattermostUserID, false); err != nil {
+			return "", false, err
+		}
+		user.IsOutOfOfficeCustomStatusSet = false
+		return "Out of office custom status removed", true, nil
+	}
+
+	if user.IsOutOfOfficeCustomStatusSet {
+		return "Out of office custom status already set", false, nil
+	}
+
+	currentUser, err := m.PluginAPI.GetMattermostUser(user.MattermostUserID)
+	if err != nil {
+		return "", false, err
+	}
+	if currentUser.GetCustomStatus() != nil && !user.IsCustomStatusSet {
+		return "User already has a custom status set, ignoring custom status change", false, nil
+	}
+
+	customStatus := &model.CustomStatus{
+		Emoji: outOfOfficeCustomStatusEmoji,
+		Text:  outOfOfficeCustomStatusText(p, now),
+	}
+	if !p.outOfOfficeUntil.IsZero() {
+		customStatus.ExpiresAt = p.outOfOfficeUntil
+		customStatus.Duration = "date_and_time"
+	}
+	if appErr := m.PluginAPI.UpdateMattermostUserCustomStatus(user.MattermostUserID, customStatus); appErr != nil {
+		return "", false, appErr
+	}
+
+	if err = m.Store.StoreUserOutOfOfficeCustomStatus(user.MattermostUserID, true); err != nil {
+		return "", true, err
+	}
+	user.IsOutOfOfficeCustomStatusSet = true
+
+	// The meeting custom status was replaced
+	if user.IsCustomStatusSet {
+		if err = m.Store.StoreUserCustomStatusUpdates(user.MattermostUserID, false); err != nil {
+			return "", true, err
+		}
+		user.IsCustomStatusSet = false
+	}
+
+	return "", true, nil
+}
+
+// automaticStatusValue returns the status to set for the given automatic status.
+func automaticStatusValue(user *store.User, automaticStatus string) string {
+	if automaticStatus == store.OutOfOfficeAutomaticStatus && user.Settings.OutOfOfficeStatusOption == store.DNDStatusOption {
+		return model.StatusDnd
+	}
+	return model.StatusAway
+}
+
+// outOfOfficeCustomStatusText returns a text like "Out of office until Friday", with the weekday of the end of
+// the automatic replies when it is within a week.
+func outOfOfficeCustomStatusText(p *presence, now time.Time) string {
+	if p.outOfOfficeUntil.IsZero() {
+		return "Out of office"
+	}
+
+	until := p.outOfOfficeUntil.In(p.location)
+	if until.Sub(now) < 7*24*time.Hour {
+		return "Out of office until " + until.Format("Monday")
+	}
+	return "Out of office until " + until.Format("January 2")
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot/mock_bot"
+)
+
+func TestIsWithinWorkingHours(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	workingHours := remote.WorkingHours{
+		StartTime:  "09:00:00.0000000",
+		EndTime:    "17:30:00.0000000",
+		DaysOfWeek: []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+	}
+	workingHours.TimeZone.Name = "Romance Standard Time"
+
+	for name, tc := range map[string]struct {
+		now      time.Time
+		expected bool
+	}{
+		"during the working hours": {
+			now:      time.Date(2024, time.May, 15, 10, 0, 0, 0, paris),
+			expected: true,
+		},
+		"at the start of the working hours": {
+			now:      time.Date(2024, time.May, 15, 9, 0, 0, 0, paris),
+			expected: true,
+		},
+		"at the end of the working hours": {
+			now:      time.Date(2024, time.May, 15, 17, 30, 0, 0, paris),
+			expected: false,
+		},
+		"before the working hours, in the time zone of the working hours": {
+			now:      time.Date(2024, time.May, 15, 6, 30, 0, 0, time.UTC),
+			expected: false,
+		},
+		"on a weekend": {
+			now:      time.Date(2024, time.May, 18, 10, 0, 0, 0, paris),
+			expected: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, isWithinWorkingHours(tc.now, workingHours, time.UTC))
+		})
+	}
+}
+
+func TestOutOfOfficeCustomStatusText(t *testing.T) {
+	now := time.Date(2024, time.May, 15, 10, 0, 0, 0, time.UTC)
+
+	require.Equal(t, "Out of office", outOfOfficeCustomStatusText(&presence{location: time.UTC}, now))
+	require.Equal(t, "Out of office until Friday", outOfOfficeCustomStatusText(&presence{
+		location:         time.UTC,
+		outOfOfficeUntil: time.Date(2024, time.May, 17, 18, 0, 0, 0, time.UTC),
+	}, now))
+	require.Equal(t, "Out of office until June 3", outOfOfficeCustomStatusText(&presence{
+		location:         time.UTC,
+		outOfOfficeUntil: time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC),
+	}, now))
+}
+
+func TestSetStatusFromPresence(t *testing.T) {
+	meeting := &remote.Event{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().UTC(), "UTC")}
+
+	for name, tc := range map[string]struct {
+		settings                store.Settings
+		automaticStatus         string
+		presence                presence
+		events                  []*remote.Event
+		currentStatus           string
+		expectedAutomaticStatus string
+		expectedStatus          string
+		expectedHandled         bool
+	}{
+		"out of office, with the away option": {
+			settings:                store.Settings{OutOfOfficeStatusOption: store.AwayStatusOption},
+			presence:                presence{outOfOffice: true},
+			currentStatus:           model.StatusOnline,
+			expectedAutomaticStatus: store.OutOfOfficeAutomaticStatus,
+			expectedStatus:          model.StatusAway,
+			expectedHandled:         true,
+		},
+		"out of office, with the DND option, during a meeting": {
+			settings:                store.Settings{OutOfOfficeStatusOption: store.DNDStatusOption, UpdateStatusFromOptions: store.AwayStatusOption},
+			presence:                presence{outOfOffice: true},
+			events:                  []*remote.Event{meeting},
+			currentStatus:           model.StatusOnline,
+			expectedAutomaticStatus: store.OutOfOfficeAutomaticStatus,
+			expectedStatus:          model.StatusDnd,
+			expectedHandled:         true,
+		},
+		"out of office, without the option": {
+			settings:      store.Settings{OutOfOfficeStatusOption: store.NotSetStatusOption, SetOutOfOfficeCustomStatus: true},
+			presence:      presence{outOfOffice: true},
+			currentStatus: model.StatusOnline,
+		},
+		"still out of office": {
+			settings:                store.Settings{OutOfOfficeStatusOption: store.AwayStatusOption},
+			automaticStatus:         store.OutOfOfficeAutomaticStatus,
+			presence:                presence{outOfOffice: true},
+			currentStatus:           model.StatusOnline,
+			expectedAutomaticStatus: store.OutOfOfficeAutomaticStatus,
+			expectedHandled:         true,
+		},
+		"outside working hours": {
+			settings:                store.Settings{SetAwayOutsideWorkingHours: true},
+			presence:                presence{outsideWorkingHours: true},
+			currentStatus:           model.StatusOnline,
+			expectedAutomaticStatus: store.OutsideWorkingHoursAutomaticStatus,
+			expectedStatus:          model.StatusAway,
+			expectedHandled:         true,
+		},
+		"outside working hours, during a meeting": {
+			settings:      store.Settings{SetAwayOutsideWorkingHours: true, UpdateStatusFromOptions: store.DNDStatusOption},
+			presence:      presence{outsideWorkingHours: true},
+			events:        []*remote.Event{meeting},
+			currentStatus: model.StatusOnline,
+		},
+		"back in working hours": {
+			settings:        store.Settings{SetAwayOutsideWorkingHours: true},
+			automaticStatus: store.OutsideWorkingHoursAutomaticStatus,
+			currentStatus:   model.StatusAway,
+			expectedStatus:  model.StatusOnline,
+			expectedHandled: true,
+		},
+		"back in working hours, after changing the status": {
+			settings:        store.Settings{SetAwayOutsideWorkingHours: true},
+			automaticStatus: store.OutsideWorkingHoursAutomaticStatus,
+			currentStatus:   model.StatusDnd,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			env, _ := makeStatusSyncTestEnv(ctrl)
+			s := env.Dependencies.Store.(*mock_store.MockStore)
+			papi := env.Dependencies.PluginAPI.(*mock_plugin_api.MockPluginAPI)
+			m := &mscalendar{Env: env}
+
+			user := &store.User{
+				MattermostUserID: "user_mm_id",
+				Settings:         tc.settings,
+				AutomaticStatus:  tc.automaticStatus,
+			}
+			if tc.automaticStatus != tc.expectedAutomaticStatus {
+				s.EXPECT().StoreUserAutomaticStatus("user_mm_id", tc.expectedAutomaticStatus).Return(nil)
+			}
+			if tc.expectedStatus != "" {
+				papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", tc.expectedStatus).Return(&model.Status{}, nil)
+			}
+
+			_, handled, changed, err := m.setStatusFromPresence(user, &model.Status{Status: tc.currentStatus}, tc.events, &tc.presence)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedHandled, handled)
+			require.Equal(t, tc.expectedStatus != "", changed)
+			require.Equal(t, tc.expectedAutomaticStatus, user.AutomaticStatus)
+		})
+	}
+}
+
+func TestGetPresence(t *testing.T) {
+	now := time.Now()
+	mailbox := &remote.MailboxSettings{
+		TimeZone:                "UTC",
+		AutomaticRepliesSetting: remote.AutomaticRepliesSetting{Status: remote.AutomaticRepliesStatusAlwaysEnabled},
+	}
+
+	for name, tc := range map[string]struct {
+		stored      *remote.MailboxSettings
+		loadError   error
+		expectFetch bool
+	}{
+		"stored by a previous run": {
+			stored: mailbox,
+		},
+		"expired": {
+			loadError:   store.ErrNotFound,
+			expectFetch: true,
+		},
+		"store failure": {
+			loadError:   errors.New("unavailable"),
+			expectFetch: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			env, client := makeStatusSyncTestEnv(ctrl)
+			mockClient := client.(*mock_remote.MockClient)
+			s := env.Dependencies.Store.(*mock_store.MockStore)
+			logger := env.Dependencies.Logger.(*mock_bot.MockLogger)
+			logger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+			m := &mscalendar{Env: env, client: client}
+			user := &store.User{MattermostUserID: "user_mm_id", Remote: &remote.User{ID: "user_remote_id"}}
+
+			s.EXPECT().LoadMailboxSettings("user_mm_id").Return(tc.stored, tc.loadError)
+			if tc.expectFetch {
+				mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(mailbox, nil)
+				s.EXPECT().StoreMailboxSettings("user_mm_id", mailbox, mailboxSettingsTTL).Return(nil)
+			}
+
+			p, err := m.getPresence(user, false, now)
+			require.NoError(t, err)
+			require.True(t, p.outOfOffice)
+		})
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const (
+	ReminderSnoozeDuration     = 5 * time.Minute
+	SnoozedReminderJobInterval = time.Minute
+	snoozedReminderMaxDelay    = 15 * time.Minute
+
+	RunningLateOptionChannels         = "channels"
+	RunningLateOptionEmailOrganizer   = "email"
+	RunningLateOptionChannelsAndEmail = "channels_email"
+)
+
+type ReminderActions interface {
+	SnoozeReminder(user *User, eventID string, d time.Duration) (time.Time, error)
+	DeliverSnoozedReminders(now time.Time) error
+	NotifyRunningLate(user *User, eventID, option string) (int, bool, error)
+}
+
+// SnoozeReminder schedules the reminder of the event to be delivered again after d, and returns the time of
+// the new reminder.
+func (m *mscalendar) SnoozeReminder(user *User, eventID string, d time.Duration) (time.Time, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	event, err := m.client.GetEvent(user.Remote.ID, eventID)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "error getting event")
+	}
+	if event.IsCancelled {
+		return time.Time{}, fmt.Errorf("%w: the event was cancelled", ErrInvalidEventPayload)
+	}
+
+	remindAt := time.Now().Add(d)
+	if event.End != nil && !event.End.Time().After(remindAt) {
+		return time.Time{}, fmt.Errorf("%w: the event will be over by then", ErrInvalidEventPayload)
+	}
+
+	err = m.Store.SnoozeReminder(&store.SnoozedReminder{
+		MattermostUserID: user.MattermostUserID,
+		EventID:          eventID,
+		RemindAt:         remindAt,
+	})
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "error snoozing reminder")
+	}
+
+	return remindAt, nil
+}
+
+// DeliverSnoozedReminders sends again the reminders that were snoozed until now. Each reminder is removed from
+// the schedule once delivered. The reminders that failed are tried again by the next jobs, until they are too
+// late to be useful.
+func (m *mscalendar) DeliverSnoozedReminders(now time.Time) error {
+	userIDs, err := m.Store.LoadSnoozedReminderUsers()
+	if err != nil {
+		return err
+	}
+
+	for _, mattermostUserID := range userIDs {
+		reminders, err := m.Store.LoadSnoozedReminders(mattermostUserID)
+		if err != nil {
+			m.Logger.With(bot.LogContext{
+				"mattermostUserID": mattermostUserID,
+				"err":              err.Error(),
+			}).Warnf("Failed to load snoozed reminders.")
+			continue
+		}
+
+		done := []*store.SnoozedReminder{}
+		for _, reminder := range reminders {
+			if reminder.RemindAt.After(now) {
+				continue
+			}
+
+			err = m.deliverSnoozedReminder(reminder, now)
+			if err != nil {
+				giveUp := now.Sub(reminder.RemindAt) >= snoozedReminderMaxDelay
+				m.Logger.With(bot.LogContext{
+					"mattermostUserID": mattermostUserID,
+					"eventID":          reminder.EventID,
+					"giveUp":           giveUp,
+					"err":              err.Error(),
+				}).Warnf("Failed to deliver snoozed reminder.")
+				if !giveUp {
+					continue
+				}
+			}
+			done = append(done, reminder)
+		}
+		if len(done) == 0 && len(reminders) > 0 {
+			continue
+		}
+
+		err = m.Store.DeleteSnoozedReminders(mattermostUserID, done)
+		if err != nil {
+			m.Logger.With(bot.LogContext{
+				"mattermostUserID": mattermostUserID,
+				"err":              err.Error(),
+			}).Warnf("Failed to remove delivered snoozed reminders.")
+		}
+	}
+
+	return nil
+}
+
+func (m *mscalendar) deliverSnoozedReminder(reminder *store.SnoozedReminder, now time.Time) error {
+	engine, err := m.FilterCopy(
+		withActingUser(reminder.MattermostUserID),
+		withActingUserExpanded,
+		withClient,
+	)
+	if err != nil {
+		return err
+	}
+	user := engine.actingUser
+
+	event, err := engine.client.GetEvent(user.Remote.ID, reminder.EventID)
+	if err != nil {
+		return errors.Wrap(err, "error getting event")
+	}
+	if event.IsCancelled || (event.End != nil && !event.End.Time().After(now)) {
+		return nil
+	}
+
+	timezone, err := engine.GetTimezone(user)
+	if err != nil {
+		return errors.Wrap(err, "error getting timezone")
+	}
+
+	return engine.sendEventReminder(user.User, event, timezone, engine.linkedChannelIDs(event))
+}
+
+// NotifyRunningLate posts a note in the channels linked to the event, and, depending on the option, emails
+// its organizer from the mailbox of the user. It returns the number of channels that were notified, and
+// whether the organizer was emailed.
+func (m *mscalendar) NotifyRunningLate(user *User, eventID, option string) (int, bool, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return 0, false, err
+	}
+
+	notifyChannels := option == RunningLateOptionChannels || option == RunningLateOptionChannelsAndEmail
+	emailOrganizer := option == RunningLateOptionEmailOrganizer || option == RunningLateOptionChannelsAndEmail
+	if !notifyChannels && !emailOrganizer {
+		return 0, false, fmt.Errorf("%w: unknown option %q", ErrInvalidEventPayload, option)
+	}
+
+	event, err := m.client.GetEvent(user.Remote.ID, eventID)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "error getting event")
+	}
+
+	notified := 0
+	if notifyChannels {
+		for _, channelID := range m.linkedChannelIDs(event) {
+			post := &model.Post{
+				ChannelId: channelID,
+				Message:   fmt.Sprintf("@%s is running late for **%s**.", user.MattermostUsername, views.EnsureSubject(event.Subject)),
+			}
+			if err = m.Poster.CreatePost(post); err != nil {
+				m.Logger.With(bot.LogContext{"err": err.Error(), "channelID": channelID}).Warnf("Failed to post running late note.")
+				continue
+			}
+			notified++
+		}
+	}
+
+	if !emailOrganizer {
+		return notified, false, nil
+	}
+
+	address := organizerAddress(event)
+	if address == "" || event.IsOrganizer {
+		return notified, false, nil
+	}
+
+	err = m.client.SendMail(user.Remote.ID, &remote.Message{
+		Subject: "Running late: " + views.EnsureSubject(event.Subject),
+		Body: &remote.ItemBody{
+			ContentType: "text",
+			Content:     fmt.Sprintf("I'm running late for %s. I'll join as soon as I can.", views.EnsureSubject(event.Subject)),
+		},
+		ToRecipients: []*remote.Recipient{{
+			EmailAddress: event.Organizer.EmailAddress,
+		}},
+	})
+	if err != nil {
+		return notified, false, errors.Wrap(err, "error emailing the organizer")
+	}
+
+	return notified, true, nil
+}
+
+// sendEventReminder sends the reminder of the event to the user, with the actions available for the event.
+// The reminder is held back during a focus block of the user.
+func (m *mscalendar) sendEventReminder(user *store.User, event *remote.Event, timezone string, linkedChannelIDs []string) error {
+	_, attachment, err := views.RenderUpcomingEventAsAttachment(event, timezone)
+	if err != nil {
+		return errors.Wrap(err, "error rendering reminder")
+	}
+	attachment.Actions = m.reminderActions(event, len(linkedChannelIDs) > 0)
+
+	err = holdOrDM(m.Dependencies, user, attachment)
+	if err != nil {
+		return errors.Wrap(err, "error sending reminder")
+	}
+
+	return nil
+}
+
+func (m *mscalendar) reminderActions(event *remote.Event, hasLinkedChannels bool) []*model.PostAction {
+	url := m.Config.PluginURLPath + config.PathPostAction
+	actions := []*model.PostAction{}
+	if joinURL := event.JoinURL(); joinURL != "" {
+		actions = append(actions, NewPostActionForJoinMeeting(event.ID, joinURL, url+config.PathJoinMeeting))
+	}
+	actions = append(actions, NewPostActionForSnoozeReminder(event.ID, url+config.PathSnoozeReminder))
+
+	canEmailOrganizer := !event.IsOrganizer && organizerAddress(event) != ""
+	if runningLate := NewPostActionForRunningLate(event.ID, hasLinkedChannels, canEmailOrganizer, url+config.PathRunningLate); runningLate != nil {
+		actions = append(actions, runningLate)
+	}
+
+	return actions
+}
+
+func (m *mscalendar) linkedChannelIDs(event *remote.Event) []string {
+	if event.ICalUID == "" {
+		return nil
+	}
+
+	eventMetadata, err := m.Store.LoadEventMetadata(event.ICalUID)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			m.Logger.With(bot.LogContext{
+				"eventID": event.ID,
+				"err":     err.Error(),
+			}).Warnf("Failed to load the channels linked to the event.")
+		}
+		return nil
+	}
+
+	channelIDs := []string{}
+	for channelID := range eventMetadata.LinkedChannelIDs {
+		channelIDs = append(channelIDs, channelID)
+	}
+	return channelIDs
+}
+
+func organizerAddress(event *remote.Event) string {
+	if event.Organizer == nil || event.Organizer.EmailAddress == nil {
+		return ""
+	}
+	return event.Organizer.EmailAddress.Address
+}
+
+func NewPostActionForJoinMeeting(eventID, joinURL, url string) *model.PostAction {
+	return &model.PostAction{
+		Name:  "Join meeting",
+		Type:  model.PostActionTypeButton,
+		Style: "primary",
+		Integration: &model.PostActionIntegration{
+			URL: url,
+			Context: map[string]interface{}{
+				config.EventIDKey: eventID,
+				config.JoinURLKey: joinURL,
+			},
+		},
+	}
+}
+
+func NewPostActionForSnoozeReminder(eventID, url string) *model.PostAction {
+	return &model.PostAction{
+		Name: fmt.Sprintf("Snooze %d min", int(ReminderSnoozeDuration/time.Minute)),
+		Type: model.PostActionTypeButton,
+		Integration: &model.PostActionIntegration{
+			URL: url,
+			Context: map[string]interface{}{
+				config.EventIDKey: eventID,
+			},
+		},
+	}
+}
+
+// NewPostActionForRunningLate returns a menu with the ways to tell that the user is running late, or nil if
+// there is nobody to tell.
+func NewPostActionForRunningLate(eventID string, hasLinkedChannels, canEmailOrganizer bool, url string) *model.PostAction {
+	options := []*model.PostActionOptions{}
+	if hasLinkedChannels {
+		options = append(options, &model.PostActionOptions{Text: "Tell the linked channels", Value: RunningLateOptionChannels})
+	}
+	if canEmailOrganizer {
+		options = append(options, &model.PostActionOptions{Text: "Email the organizer", Value: RunningLateOptionEmailOrganizer})
+	}
+	if hasLinkedChannels && canEmailOrganizer {
+		options = append(options, &model.PostActionOptions{Text: "Tell the channels and email the organizer", Value: RunningLateOptionChannelsAndEmail})
+	}
+	if len(options) == 0 {
+		return nil
+	}
+
+	return &model.PostAction{
+		Name:    "Running late",
+		Type:    model.PostActionTypeSelect,
+		Options: options,
+		Integration: &model.PostActionIntegration{
+			URL: url,
+			Context: map[string]interface{}{
+				config.EventIDKey: eventID,
+			},
+		},
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot/mock_bot"
+)
+
+func TestNewPostActionForRunningLate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		hasLinkedChannels bool
+		canEmailOrganizer bool
+		expectedOptions   []string
+	}{
+		"nobody to tell": {},
+		"linked channels": {
+			hasLinkedChannels: true,
+			expectedOptions:   []string{RunningLateOptionChannels},
+		},
+		"organizer": {
+			canEmailOrganizer: true,
+			expectedOptions:   []string{RunningLateOptionEmailOrganizer},
+		},
+		"linked channels and organizer": {
+			hasLinkedChannels: true,
+			canEmailOrganizer: true,
+			expectedOptions:   []string{RunningLateOptionChannels, RunningLateOptionEmailOrganizer, RunningLateOptionChannelsAndEmail},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			action := NewPostActionForRunningLate("event_id", tc.hasLinkedChannels, tc.canEmailOrganizer, "url")
+			if tc.expectedOptions == nil {
+				require.Nil(t, action)
+				return
+			}
+
+			options := []string{}
+			for _, o := range action.Options {
+				options = append(options, o.Value)
+			}
+			require.Equal(t, tc.expectedOptions, options)
+		})
+	}
+}
+
+func TestReminderActions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	env, _ := makeStatusSyncTestEnv(ctrl)
+	m := &mscalendar{Env: env}
+
+	event := &remote.Event{
+		ID:            "event_id",
+		OnlineMeeting: &remote.OnlineMeetingInfo{JoinURL: "https://example.com/join"},
+		Organizer:     &remote.Attendee{EmailAddress: &remote.EmailAddress{Address: "organizer@example.com"}},
+	}
+	names := []string{}
+	for _, action := range m.reminderActions(event, false) {
+		names = append(names, action.Name)
+	}
+	require.Equal(t, []string{"Join meeting", "Snooze 5 min", "Running late"}, names)
+
+	event.OnlineMeeting = nil
+	event.IsOrganizer = true
+	names = []string{}
+	for _, action := range m.reminderActions(event, false) {
+		names = append(names, action.Name)
+	}
+	require.Equal(t, []string{"Snooze 5 min"}, names)
+}
+
+func TestNotifyRunningLate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		option          string
+		isOrganizer     bool
+		sendMailError   error
+		expectedPosts   int
+		expectedEmailed bool
+		expectedError   string
+	}{
+		"channels": {
+			option:        RunningLateOptionChannels,
+			expectedPosts: 2,
+		},
+		"organizer": {
+			option:          RunningLateOptionEmailOrganizer,
+			expectedEmailed: true,
+		},
+		"channels and organizer": {
+			option:          RunningLateOptionChannelsAndEmail,
+			expectedPosts:   2,
+			expectedEmailed: true,
+		},
+		"unknown option": {
+			option:        "call",
+			expectedError: `invalid event: unknown option "call"`,
+		},
+		"the user is the organizer": {
+			option:      RunningLateOptionEmailOrganizer,
+			isOrganizer: true,
+		},
+		"email failed": {
+			option:        RunningLateOptionEmailOrganizer,
+			sendMailError: errors.New("forbidden"),
+			expectedError: "error emailing the organizer: forbidden",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			env, client := makeStatusSyncTestEnv(ctrl)
+			mockClient := client.(*mock_remote.MockClient)
+			s := env.Dependencies.Store.(*mock_store.MockStore)
+			poster := env.Dependencies.Poster.(*mock_bot.MockPoster)
+
+			user := &User{
+				MattermostUserID: "user_mm_id",
+				User:             &store.User{MattermostUserID: "user_mm_id", MattermostUsername: "alice", Remote: &remote.User{ID: "user_remote_id"}},
+				MattermostUser:   &model.User{Id: "user_mm_id"},
+			}
+			m := &mscalendar{Env: env, client: client}
+
+			event := &remote.Event{
+				ID:          "event_id",
+				ICalUID:     "ical_uid",
+				Subject:     "Retro",
+				IsOrganizer: tc.isOrganizer,
+				Organizer:   &remote.Attendee{EmailAddress: &remote.EmailAddress{Address: "organizer@example.com"}},
+			}
+			if tc.option != "call" {
+				mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(event, nil)
+			}
+			if tc.expectedPosts > 0 {
+				s.EXPECT().LoadEventMetadata("ical_uid").Return(&store.EventMetadata{
+					LinkedChannelIDs: map[string]struct{}{"channel_1": {}, "channel_2": {}},
+				}, nil)
+				poster.EXPECT().CreatePost(gomock.Any()).DoAndReturn(func(post *model.Post) error {
+					require.Equal(t, "@alice is running late for **Retro**.", post.Message)
+					return nil
+				}).Times(tc.expectedPosts)
+			}
+			if tc.expectedEmailed || tc.sendMailError != nil {
+				mockClient.EXPECT().SendMail("user_remote_id", gomock.Any()).DoAndReturn(func(_ string, message *remote.Message) error {
+					require.Equal(t, "Running late: Retro", message.Subject)
+					require.Equal(t, "organizer@example.com", message.ToRecipients[0].EmailAddress.Address)
+					return tc.sendMailError
+				})
+			}
+
+			notified, emailed, err := m.NotifyRunningLate(user, "event_id", tc.option)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPosts, notified)
+			require.Equal(t, tc.expectedEmailed, emailed)
+		})
+	}
+}
+
+func TestSnoozeReminder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	env, client := makeStatusSyncTestEnv(ctrl)
+	mockClient := client.(*mock_remote.MockClient)
+	s := env.Dependencies.Store.(*mock_store.MockStore)
+
+	user := &User{
+		MattermostUserID: "user_mm_id",
+		User:             &store.User{MattermostUserID: "user_mm_id", Remote: &remote.User{ID: "user_remote_id"}},
+		MattermostUser:   &model.User{Id: "user_mm_id"},
+	}
+	m := &mscalendar{Env: env, client: client}
+
+	mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(&remote.Event{
+		ID:  "event_id",
+		End: remote.NewDateTime(time.Now().Add(time.Hour).UTC(), "UTC"),
+	}, nil)
+	s.EXPECT().SnoozeReminder(gomock.Any()).DoAndReturn(func(reminder *store.SnoozedReminder) error {
+		require.Equal(t, "user_mm_id", reminder.MattermostUserID)
+		require.Equal(t, "event_id", reminder.EventID)
+		return nil
+	})
+
+	remindAt, err := m.SnoozeReminder(user, "event_id", ReminderSnoozeDuration)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(ReminderSnoozeDuration), remindAt, time.Second)
+
+	mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(&remote.Event{
+		ID:  "event_id",
+		End: remote.NewDateTime(time.Now().Add(time.Minute).UTC(), "UTC"),
+	}, nil)
+	_, err = m.SnoozeReminder(user, "event_id", ReminderSnoozeDuration)
+	require.EqualError(t, err, "invalid event: the event will be over by then")
+
+	mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(&remote.Event{
+		ID:          "event_id",
+		IsCancelled: true,
+		End:         remote.NewDateTime(time.Now().Add(time.Hour).UTC(), "UTC"),
+	}, nil)
+	_, err = m.SnoozeReminder(user, "event_id", ReminderSnoozeDuration)
+	require.EqualError(t, err, "invalid event: the event was cancelled")
+}
+
+func TestDeliverSnoozedReminders(t *testing.T) {
+	now := time.Now()
+	due := &store.SnoozedReminder{MattermostUserID: "user_mm_id", EventID: "event_id", RemindAt: now.Add(-time.Minute)}
+	later := &store.SnoozedReminder{MattermostUserID: "user_mm_id", EventID: "other_event_id", RemindAt: now.Add(time.Minute)}
+	lateDue := &store.SnoozedReminder{MattermostUserID: "user_mm_id", EventID: "event_id", RemindAt: now.Add(-snoozedReminderMaxDelay)}
+
+	for name, tc := range map[string]struct {
+		reminders       []*store.SnoozedReminder
+		event           *remote.Event
+		getEventError   error
+		expectedDM      bool
+		expectedDeleted []*store.SnoozedReminder
+	}{
+		"delivered": {
+			reminders:       []*store.SnoozedReminder{due, later},
+			event:           &remote.Event{ID: "event_id", Subject: "Retro", Start: remote.NewDateTime(now.Add(time.Minute).UTC(), "UTC"), End: remote.NewDateTime(now.Add(time.Hour).UTC(), "UTC")},
+			expectedDM:      true,
+			expectedDeleted: []*store.SnoozedReminder{due},
+		},
+		"event over": {
+			reminders:       []*store.SnoozedReminder{due},
+			event:           &remote.Event{ID: "event_id", End: remote.NewDateTime(now.Add(-time.Minute).UTC(), "UTC")},
+			expectedDeleted: []*store.SnoozedReminder{due},
+		},
+		"failed, kept for the next job": {
+			reminders:     []*store.SnoozedReminder{due},
+			getEventError: errors.New("unavailable"),
+		},
+		"failed for too long": {
+			reminders:       []*store.SnoozedReminder{lateDue},
+			getEventError:   errors.New("unavailable"),
+			expectedDeleted: []*store.SnoozedReminder{lateDue},
+		},
+		"nothing due": {
+			reminders: []*store.SnoozedReminder{later},
+		},
+		"no reminders left": {
+			reminders:       []*store.SnoozedReminder{},
+			expectedDeleted: []*store.SnoozedReminder{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			env, client := makeStatusSyncTestEnv(ctrl)
+			mockClient := client.(*mock_remote.MockClient)
+			mockRemote := env.Dependencies.Remote.(*mock_remote.MockRemote)
+			s := env.Dependencies.Store.(*mock_store.MockStore)
+			papi := env.Dependencies.PluginAPI.(*mock_plugin_api.MockPluginAPI)
+			poster := env.Dependencies.Poster.(*mock_bot.MockPoster)
+			logger := env.Dependencies.Logger.(*mock_bot.MockLogger)
+			logger.EXPECT().With(gomock.Any()).Return(logger).AnyTimes()
+			logger.EXPECT().Warnf(gomock.Any()).AnyTimes()
+			m := New(env, "")
+
+			s.EXPECT().LoadSnoozedReminderUsers().Return([]string{"user_mm_id"}, nil)
+			s.EXPECT().LoadSnoozedReminders("user_mm_id").Return(tc.reminders, nil)
+			if tc.event != nil || tc.getEventError != nil {
+				s.EXPECT().LoadUser("user_mm_id").Return(&store.User{MattermostUserID: "user_mm_id", Remote: &remote.User{ID: "user_remote_id"}}, nil).AnyTimes()
+				papi.EXPECT().GetMattermostUser("user_mm_id").Return(&model.User{Id: "user_mm_id"}, nil)
+				mockRemote.EXPECT().MakeClient(gomock.Any(), gomock.Any()).Return(client)
+				mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(tc.event, tc.getEventError)
+			}
+			if tc.expectedDM {
+				mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+				s.EXPECT().LoadFocusBlock("user_mm_id").Return(nil, nil)
+				poster.EXPECT().DMWithAttachments("user_mm_id", gomock.Any()).Return("", nil)
+			}
+			if tc.expectedDeleted != nil {
+				s.EXPECT().DeleteSnoozedReminders("user_mm_id", tc.expectedDeleted).Return(nil)
+			}
+
+			require.NoError(t, m.DeliverSnoozedReminders(now))
+		})
+	}
+}
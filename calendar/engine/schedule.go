@@ -0,0 +1,172 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	scheduleInterval      = 30 * time.Minute
+	defaultWorkdayStart   = 8
+	defaultWorkdayEnd     = 18
+	workingHoursLayout    = "15:04:05"
+	workingHoursLayoutLen = len(workingHoursLayout)
+)
+
+// Schedule is the free/busy information of some Mattermost users over the working hours of a day, in slots of
+// Interval minutes. Each character of an availability view is the status of a slot, see remote.AvailabilityView*.
+type Schedule struct {
+	From     time.Time           `json:"from"`
+	To       time.Time           `json:"to"`
+	TimeZone string              `json:"time_zone"`
+	Interval int                 `json:"interval"`
+	Users    []*UserAvailability `json:"users"`
+}
+
+type UserAvailability struct {
+	MattermostUserID string                  `json:"mattermost_user_id"`
+	AvailabilityView remote.AvailabilityView `json:"availability_view"`
+	Error            string                  `json:"error,omitempty"`
+}
+
+// GetSchedule returns the availability of the given Mattermost users during the working hours of the user on
+// the given date, formatted as CreateEventDateFormat. An empty date stands for today.
+func (m *mscalendar) GetSchedule(user *User, mattermostUserIDs []string, date string) (*Schedule, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mattermostUserIDs) == 0 {
+		return nil, fmt.Errorf("%w: please mention at least one user", ErrInvalidEventPayload)
+	}
+
+	mailbox, err := m.client.GetMailboxSettings(user.Remote.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting mailbox settings")
+	}
+
+	loc, err := time.LoadLocation(tz.Go(mailbox.TimeZone))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading mailbox timezone %s", mailbox.TimeZone)
+	}
+
+	day := time.Now().In(loc)
+	if date != "" {
+		day, err = time.ParseInLocation(CreateEventDateFormat, date, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid date %q, please use the format YYYY-MM-DD", ErrInvalidEventPayload, date)
+		}
+	}
+	from, to := workingHoursForDay(day, mailbox.WorkingHours)
+
+	// Reading other people's schedules needs the application permissions when available. Otherwise, the
+	// schedules are read on behalf of the user, which is allowed for free/busy information.
+	client, err := m.MakeSuperuserClient()
+	if err != nil && !errors.Is(err, remote.ErrSuperUserClientNotSupported) {
+		return nil, errors.Wrap(err, "error making the superuser client")
+	}
+	onBehalfOfUser := err != nil
+	if onBehalfOfUser {
+		client = m.client
+	}
+
+	schedule := &Schedule{
+		From:     from,
+		To:       to,
+		TimeZone: loc.String(),
+		Interval: int(scheduleInterval.Minutes()),
+	}
+	requests := []*remote.ScheduleUserInfo{}
+	byMail := map[string]*UserAvailability{}
+	for _, mattermostUserID := range mattermostUserIDs {
+		availability := &UserAvailability{
+			MattermostUserID: mattermostUserID,
+		}
+		schedule.Users = append(schedule.Users, availability)
+
+		storedUser, errLoad := m.Store.LoadUser(mattermostUserID)
+		if errLoad == store.ErrNotFound {
+			availability.Error = fmt.Sprintf("not connected to %s", config.Provider.DisplayName)
+			continue
+		}
+		if errLoad != nil {
+			return nil, errors.Wrap(errLoad, "error loading user")
+		}
+
+		request := &remote.ScheduleUserInfo{
+			RemoteUserID: storedUser.Remote.ID,
+			Mail:         storedUser.Remote.Mail,
+		}
+		if onBehalfOfUser {
+			request.RemoteUserID = user.Remote.ID
+		}
+		requests = append(requests, request)
+		byMail[strings.ToLower(storedUser.Remote.Mail)] = availability
+	}
+
+	if len(requests) == 0 {
+		return schedule, nil
+	}
+
+	infos, err := client.GetSchedule(requests, remote.NewDateTime(from.UTC(), "UTC"), remote.NewDateTime(to.UTC(), "UTC"), schedule.Interval)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting schedules")
+	}
+
+	for _, info := range infos {
+		availability, ok := byMail[strings.ToLower(info.ScheduleID)]
+		if !ok {
+			continue
+		}
+		if info.Error != nil {
+			availability.Error = info.Error.Message
+			continue
+		}
+		availability.AvailabilityView = info.AvailabilityView
+	}
+	for _, availability := range byMail {
+		if availability.AvailabilityView == "" && availability.Error == "" {
+			availability.Error = "availability not found"
+		}
+	}
+
+	return schedule, nil
+}
+
+// workingHoursForDay returns the start and the end of the working hours on the given day, falling back to
+// defaultWorkdayStart and defaultWorkdayEnd when the working hours are not set.
+func workingHoursForDay(day time.Time, workingHours remote.WorkingHours) (time.Time, time.Time) {
+	at := func(value string, defaultHour int) time.Time {
+		if len(value) >= workingHoursLayoutLen {
+			t, err := time.Parse(workingHoursLayout, value[:workingHoursLayoutLen])
+			if err == nil {
+				return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location())
+			}
+		}
+		return time.Date(day.Year(), day.Month(), day.Day(), defaultHour, 0, 0, 0, day.Location())
+	}
+
+	from := at(workingHours.StartTime, defaultWorkdayStart)
+	to := at(workingHours.EndTime, defaultWorkdayEnd)
+	if !to.After(from) {
+		from = time.Date(day.Year(), day.Month(), day.Day(), defaultWorkdayStart, 0, 0, 0, day.Location())
+		to = time.Date(day.Year(), day.Month(), day.Day(), defaultWorkdayEnd, 0, 0, 0, day.Location())
+	}
+
+	return from, to
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestWorkingHoursForDay(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	day := time.Date(2024, time.May, 15, 17, 45, 0, 0, loc)
+
+	for name, tc := range map[string]struct {
+		workingHours remote.WorkingHours
+		expectedFrom time.Time
+		expectedTo   time.Time
+	}{
+		"working hours set": {
+			workingHours: remote.WorkingHours{StartTime: "09:30:00.0000000", EndTime: "17:00:00.0000000"},
+			expectedFrom: time.Date(2024, time.May, 15, 9, 30, 0, 0, loc),
+			expectedTo:   time.Date(2024, time.May, 15, 17, 0, 0, 0, loc),
+		},
+		"working hours not set": {
+			expectedFrom: time.Date(2024, time.May, 15, 8, 0, 0, 0, loc),
+			expectedTo:   time.Date(2024, time.May, 15, 18, 0, 0, 0, loc),
+		},
+		"end before start": {
+			workingHours: remote.WorkingHours{StartTime: "17:00:00", EndTime: "09:00:00"},
+			expectedFrom: time.Date(2024, time.May, 15, 8, 0, 0, 0, loc),
+			expectedTo:   time.Date(2024, time.May, 15, 18, 0, 0, 0, loc),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			from, to := workingHoursForDay(day, tc.workingHours)
+			require.Equal(t, tc.expectedFrom, from)
+			require.Equal(t, tc.expectedTo, to)
+		})
+	}
+}
@@ -25,6 +25,7 @@ func (m *mscalendar) ClearSettingsPosts(userID string) {
 
 func NewSettingsPanel(bot bot.Bot, panelStore settingspanel.PanelStore, settingStore settingspanel.SettingStore, settingsHandler, pluginURL string, getCal func(userID string) Engine, providerFeatures config.ProviderFeatures) settingspanel.Panel {
 	settings := []settingspanel.Setting{}
+	settings = append(settings, NewCalendarsSetting(settingStore, getCal))
 	settings = append(settings, settingspanel.NewOptionSetting(
 		store.UpdateStatusFromOptionsSettingID,
 		"Update Status",
@@ -48,6 +49,29 @@ func NewSettingsPanel(bot bot.Bot, panelStore settingspanel.PanelStore, settingS
 		"",
 		settingStore,
 	))
+	settings = append(settings, settingspanel.NewBoolSetting(
+		store.AwayOutsideWorkingHoursSettingID,
+		"Away Outside Working Hours",
+		"Do you want to set your status to away on Mattermost outside your working hours?",
+		"",
+		settingStore,
+	))
+	settings = append(settings, settingspanel.NewOptionSetting(
+		store.OutOfOfficeStatusSettingID,
+		"Out of Office Status",
+		"Do you want to update your status on Mattermost while your automatic replies are on?",
+		"",
+		store.NotSetStatusOption,
+		[]string{store.AwayStatusOption, store.DNDStatusOption, store.NotSetStatusOption},
+		settingStore,
+	))
+	settings = append(settings, settingspanel.NewBoolSetting(
+		store.OutOfOfficeCustomStatusSettingID,
+		"Out of Office Custom Status",
+		"Do you want to set an out of office custom status on Mattermost while your automatic replies are on?",
+		"",
+		settingStore,
+	))
 	settings = append(settings, settingspanel.NewBoolSetting(
 		store.ReceiveRemindersSettingID,
 		"Receive Reminders",
@@ -55,6 +79,7 @@ func NewSettingsPanel(bot bot.Bot, panelStore settingspanel.PanelStore, settingS
 		"",
 		settingStore,
 	))
+	settings = append(settings, NewReminderLeadTimesSetting(settingStore))
 	if providerFeatures.EventNotifications {
 		settings = append(settings, NewNotificationsSetting(getCal))
 	}
@@ -62,5 +87,15 @@ func NewSettingsPanel(bot bot.Bot, panelStore settingspanel.PanelStore, settingS
 		settingStore,
 		func(userID string) (string, error) { return getCal(userID).GetTimezone(NewUser(userID)) },
 	))
+	settings = append(settings, NewDailySummarySectionsSetting(settingStore))
+	settings = append(settings, NewDailySummaryDaysSetting(settingStore))
+	settings = append(settings, NewDailySummarySkipSetting(settingStore))
+	settings = append(settings, settingspanel.NewBoolSetting(
+		store.DailySummaryInteractiveSettingID,
+		"Interactive Daily Summary",
+		"Do you want to respond to invitations and join meetings from buttons in your daily summary?",
+		"",
+		settingStore,
+	))
 	return settingspanel.NewSettingsPanel(settings, bot, bot, panelStore, settingsHandler, pluginURL)
 }
@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/settingspanel"
+)
+
+type calendarsSetting struct {
+	store       settingspanel.SettingStore
+	getCal      func(string) Engine
+	title       string
+	description string
+	id          string
+	dependsOn   string
+}
+
+func NewCalendarsSetting(inStore settingspanel.SettingStore, getCal func(string) Engine) settingspanel.Setting {
+	return &calendarsSetting{
+		title:       "Calendars",
+		description: "Which calendars do you want to use for your status, reminders, daily summary and calendar views? Click on a calendar to select or unselect it.",
+		id:          store.CalendarsSettingID,
+		dependsOn:   "",
+		store:       inStore,
+		getCal:      getCal,
+	}
+}
+
+// Set toggles the selection of the calendar with the given ID.
+func (s *calendarsSetting) Set(userID string, value interface{}) error {
+	calendarID, ok := value.(string)
+	if !ok || calendarID == "" {
+		return errors.New("trying to set Calendars Setting without a calendar ID")
+	}
+
+	calendars, err := s.getCal(userID).GetCalendars(NewUser(userID))
+	if err != nil {
+		return err
+	}
+
+	selected, err := s.selectedCalendarIDs(userID, calendars)
+	if err != nil {
+		return err
+	}
+
+	updated := []string{}
+	found := false
+	for _, id := range selected {
+		if id == calendarID {
+			found = true
+			continue
+		}
+		updated = append(updated, id)
+	}
+	if !found {
+		updated = append(updated, calendarID)
+	}
+	if len(updated) == 0 {
+		return errors.New("at least one calendar must be selected")
+	}
+
+	return s.store.SetSetting(userID, s.id, updated)
+}
+
+func (s *calendarsSetting) Get(userID string) (interface{}, error) {
+	value, err := s.store.GetSetting(userID, s.id)
+	if err != nil {
+		return nil, err
+	}
+
+	calendarIDs, ok := value.([]string)
+	if !ok {
+		return nil, errors.New("current value is not a list of calendars")
+	}
+
+	return calendarIDs, nil
+}
+
+// selectedCalendarIDs returns the IDs of the selected calendars, which is the default calendar when the user
+// did not select any.
+func (s *calendarsSetting) selectedCalendarIDs(userID string, calendars []*remote.Calendar) ([]string, error) {
+	value, err := s.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendarIDs := value.([]string)
+	if len(calendarIDs) > 0 {
+		return calendarIDs, nil
+	}
+
+	for _, c := range calendars {
+		if c.IsDefaultCalendar {
+			return []string{c.ID}, nil
+		}
+	}
+	if len(calendars) > 0 {
+		return []string{calendars[0].ID}, nil
+	}
+
+	return []string{}, nil
+}
+
+func (s *calendarsSetting) GetID() string {
+	return s.id
+}
+
+func (s *calendarsSetting) GetTitle() string {
+	return s.title
+}
+
+func (s *calendarsSetting) GetDescription() string {
+	return s.description
+}
+
+func (s *calendarsSetting) GetDependency() string {
+	return s.dependsOn
+}
+
+func (s *calendarsSetting) GetSlackAttachments(userID, settingHandler string, disabled bool) (*model.SlackAttachment, error) {
+	title := fmt.Sprintf("Setting: %s", s.title)
+	currentValueMessage := "Disabled"
+
+	actions := []*model.PostAction{}
+	if !disabled {
+		calendars, err := s.getCal(userID).GetCalendars(NewUser(userID))
+		if err != nil {
+			return nil, err
+		}
+
+		selected, err := s.selectedCalendarIDs(userID, calendars)
+		if err != nil {
+			return nil, err
+		}
+		isSelected := map[string]bool{}
+		for _, id := range selected {
+			isSelected[id] = true
+		}
+
+		selectedNames := []string{}
+		for _, c := range calendars {
+			style := "default"
+			if isSelected[c.ID] {
+				style = "primary"
+				selectedNames = append(selectedNames, c.Name)
+			}
+
+			actions = append(actions, &model.PostAction{
+				Name:  c.Name,
+				Style: style,
+				Integration: &model.PostActionIntegration{
+					URL: settingHandler,
+					Context: map[string]interface{}{
+						settingspanel.ContextIDKey:          s.id,
+						settingspanel.ContextButtonValueKey: c.ID,
+					},
+				},
+			})
+		}
+		currentValueMessage = fmt.Sprintf("**Current value:** %s", strings.Join(selectedNames, ", "))
+	}
+
+	text := fmt.Sprintf("%s\n%s", s.description, currentValueMessage)
+	sa := model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Actions:  actions,
+		Fallback: fmt.Sprintf("%s: %s", title, text),
+	}
+
+	return &sa, nil
+}
+
+func (s *calendarsSetting) IsDisabled(foreignValue interface{}) bool {
+	return foreignValue == "false"
+}
@@ -0,0 +1,222 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/settingspanel"
+)
+
+var dailySummarySectionOptions = []string{
+	store.DailySummarySectionToday,
+	store.DailySummarySectionWeekPreview,
+	store.DailySummarySectionMeetingHours,
+	store.DailySummarySectionBackToBack,
+	store.DailySummarySectionAwaitingResponse,
+}
+
+var dailySummarySectionNames = map[string]string{
+	store.DailySummarySectionToday:            "Today's events",
+	store.DailySummarySectionWeekPreview:      "Monday: week ahead",
+	store.DailySummarySectionMeetingHours:     "Friday: meeting hours",
+	store.DailySummarySectionBackToBack:       "Friday: back-to-back meetings",
+	store.DailySummarySectionAwaitingResponse: "Friday: awaiting response",
+}
+
+var dailySummaryDayOptions = store.AllDailySummaryDays
+
+var dailySummaryDayNames = map[string]string{
+	"monday":    "Mon",
+	"tuesday":   "Tue",
+	"wednesday": "Wed",
+	"thursday":  "Thu",
+	"friday":    "Fri",
+	"saturday":  "Sat",
+	"sunday":    "Sun",
+}
+
+var dailySummarySkipOptions = []string{
+	store.DailySummarySkipOutOfOffice,
+	store.DailySummarySkipEmptyDays,
+}
+
+var dailySummarySkipNames = map[string]string{
+	store.DailySummarySkipOutOfOffice: "Out of office days",
+	store.DailySummarySkipEmptyDays:   "Empty days",
+}
+
+// dailySummaryTogglesSetting is a setting of the daily summary made of options selected independently.
+type dailySummaryTogglesSetting struct {
+	store       settingspanel.SettingStore
+	title       string
+	description string
+	id          string
+	dependsOn   string
+	options     []string
+	names       map[string]string
+	// allowNone allows to unselect all the options
+	allowNone bool
+}
+
+func NewDailySummarySectionsSetting(inStore settingspanel.SettingStore) settingspanel.Setting {
+	return &dailySummaryTogglesSetting{
+		title:       "Daily Summary Content",
+		description: "What do you want to see in your daily summary? The week ahead is shown on Mondays, and the recap of the week on Fridays. Click on a section to select or unselect it.",
+		id:          store.DailySummarySectionsSettingID,
+		dependsOn:   store.DailySummarySettingID,
+		store:       inStore,
+		options:     dailySummarySectionOptions,
+		names:       dailySummarySectionNames,
+	}
+}
+
+func NewDailySummaryDaysSetting(inStore settingspanel.SettingStore) settingspanel.Setting {
+	return &dailySummaryTogglesSetting{
+		title:       "Daily Summary Days",
+		description: "On which days do you want to receive the daily summary? Click on a day to select or unselect it.",
+		id:          store.DailySummaryDaysSettingID,
+		dependsOn:   store.DailySummarySettingID,
+		store:       inStore,
+		options:     dailySummaryDayOptions,
+		names:       dailySummaryDayNames,
+	}
+}
+
+func NewDailySummarySkipSetting(inStore settingspanel.SettingStore) settingspanel.Setting {
+	return &dailySummaryTogglesSetting{
+		title:       "Skip Daily Summary",
+		description: "On which days do you want to skip the daily summary? Out of office days have an all-day out of office event or the automatic replies on. On empty days, the summary says that nothing is scheduled unless they are skipped.",
+		id:          store.DailySummarySkipSettingID,
+		dependsOn:   store.DailySummarySettingID,
+		store:       inStore,
+		options:     dailySummarySkipOptions,
+		names:       dailySummarySkipNames,
+		allowNone:   true,
+	}
+}
+
+// Set toggles the selection of the given option.
+func (s *dailySummaryTogglesSetting) Set(userID string, value interface{}) error {
+	option, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("trying to set %s Setting without a value", s.title)
+	}
+	if _, ok = s.names[option]; !ok {
+		return fmt.Errorf("invalid %s option %q", s.title, option)
+	}
+
+	current, err := s.Get(userID)
+	if err != nil {
+		return err
+	}
+	isSelected := map[string]bool{}
+	for _, selected := range current.([]string) {
+		isSelected[selected] = true
+	}
+	isSelected[option] = !isSelected[option]
+
+	// The selection is kept in the order of the options
+	updated := []string{}
+	for _, o := range s.options {
+		if isSelected[o] {
+			updated = append(updated, o)
+		}
+	}
+	if len(updated) == 0 && !s.allowNone {
+		return errors.New("at least one option must be selected")
+	}
+
+	return s.store.SetSetting(userID, s.id, updated)
+}
+
+func (s *dailySummaryTogglesSetting) Get(userID string) (interface{}, error) {
+	value, err := s.store.GetSetting(userID, s.id)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, ok := value.([]string)
+	if !ok {
+		return nil, fmt.Errorf("current value is not a list of %s options", s.title)
+	}
+
+	return selected, nil
+}
+
+func (s *dailySummaryTogglesSetting) GetID() string {
+	return s.id
+}
+
+func (s *dailySummaryTogglesSetting) GetTitle() string {
+	return s.title
+}
+
+func (s *dailySummaryTogglesSetting) GetDescription() string {
+	return s.description
+}
+
+func (s *dailySummaryTogglesSetting) GetDependency() string {
+	return s.dependsOn
+}
+
+func (s *dailySummaryTogglesSetting) GetSlackAttachments(userID, settingHandler string, disabled bool) (*model.SlackAttachment, error) {
+	title := fmt.Sprintf("Setting: %s", s.title)
+	currentValueMessage := "Disabled"
+
+	actions := []*model.PostAction{}
+	if !disabled {
+		value, err := s.Get(userID)
+		if err != nil {
+			return nil, err
+		}
+		isSelected := map[string]bool{}
+		for _, option := range value.([]string) {
+			isSelected[option] = true
+		}
+
+		selectedNames := []string{}
+		for _, option := range s.options {
+			style := "default"
+			if isSelected[option] {
+				style = "primary"
+				selectedNames = append(selectedNames, s.names[option])
+			}
+
+			actions = append(actions, &model.PostAction{
+				Name:  s.names[option],
+				Style: style,
+				Integration: &model.PostActionIntegration{
+					URL: settingHandler,
+					Context: map[string]interface{}{
+						settingspanel.ContextIDKey:          s.id,
+						settingspanel.ContextButtonValueKey: option,
+					},
+				},
+			})
+		}
+		if len(selectedNames) == 0 {
+			selectedNames = append(selectedNames, "None")
+		}
+		currentValueMessage = fmt.Sprintf("**Current value:** %s", strings.Join(selectedNames, ", "))
+	}
+
+	text := fmt.Sprintf("%s\n%s", s.description, currentValueMessage)
+	sa := model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Actions:  actions,
+		Fallback: fmt.Sprintf("%s: %s", title, text),
+	}
+
+	return &sa, nil
+}
+
+// IsDisabled returns whether the daily summary is off.
+func (s *dailySummaryTogglesSetting) IsDisabled(foreignValue interface{}) bool {
+	dsum, ok := foreignValue.(*store.DailySummaryUserSettings)
+	return !ok || dsum == nil || !dsum.Enable
+}
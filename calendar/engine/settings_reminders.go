@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/settingspanel"
+)
+
+const outlookReminderOption = "outlook"
+
+var reminderLeadTimeOptions = []int{1, 5, 10, 30, store.OutlookReminderLeadTime}
+
+type reminderLeadTimesSetting struct {
+	store       settingspanel.SettingStore
+	title       string
+	description string
+	id          string
+	dependsOn   string
+}
+
+func NewReminderLeadTimesSetting(inStore settingspanel.SettingStore) settingspanel.Setting {
+	return &reminderLeadTimesSetting{
+		title:       "Reminder Times",
+		description: "When do you want to be reminded of your upcoming events? Click on a time to select or unselect it.",
+		id:          store.ReminderLeadTimesSettingID,
+		dependsOn:   store.ReceiveRemindersSettingID,
+		store:       inStore,
+	}
+}
+
+// Set toggles the selection of the lead time of the given option.
+func (s *reminderLeadTimesSetting) Set(userID string, value interface{}) error {
+	option, ok := value.(string)
+	if !ok {
+		return errors.New("trying to set Reminder Times Setting without a value")
+	}
+	leadTime, err := parseReminderLeadTimeOption(option)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.Get(userID)
+	if err != nil {
+		return err
+	}
+
+	updated := []int{}
+	found := false
+	for _, t := range current.([]int) {
+		if t == leadTime {
+			found = true
+			continue
+		}
+		updated = append(updated, t)
+	}
+	if !found {
+		updated = append(updated, leadTime)
+	}
+	if len(updated) == 0 {
+		return errors.New("at least one reminder time must be selected")
+	}
+	sort.Ints(updated)
+
+	return s.store.SetSetting(userID, s.id, updated)
+}
+
+func (s *reminderLeadTimesSetting) Get(userID string) (interface{}, error) {
+	value, err := s.store.GetSetting(userID, s.id)
+	if err != nil {
+		return nil, err
+	}
+
+	leadTimes, ok := value.([]int)
+	if !ok {
+		return nil, errors.New("current value is not a list of reminder times")
+	}
+
+	return leadTimes, nil
+}
+
+func (s *reminderLeadTimesSetting) GetID() string {
+	return s.id
+}
+
+func (s *reminderLeadTimesSetting) GetTitle() string {
+	return s.title
+}
+
+func (s *reminderLeadTimesSetting) GetDescription() string {
+	return s.description
+}
+
+func (s *reminderLeadTimesSetting) GetDependency() string {
+	return s.dependsOn
+}
+
+func (s *reminderLeadTimesSetting) GetSlackAttachments(userID, settingHandler string, disabled bool) (*model.SlackAttachment, error) {
+	title := fmt.Sprintf("Setting: %s", s.title)
+	currentValueMessage := "Disabled"
+
+	actions := []*model.PostAction{}
+	if !disabled {
+		value, err := s.Get(userID)
+		if err != nil {
+			return nil, err
+		}
+		isSelected := map[int]bool{}
+		for _, t := range value.([]int) {
+			isSelected[t] = true
+		}
+
+		selectedNames := []string{}
+		for _, leadTime := range reminderLeadTimeOptions {
+			style := "default"
+			if isSelected[leadTime] {
+				style = "primary"
+				selectedNames = append(selectedNames, reminderLeadTimeName(leadTime))
+			}
+
+			actions = append(actions, &model.PostAction{
+				Name:  reminderLeadTimeName(leadTime),
+				Style: style,
+				Integration: &model.PostActionIntegration{
+					URL: settingHandler,
+					Context: map[string]interface{}{
+						settingspanel.ContextIDKey:          s.id,
+						settingspanel.ContextButtonValueKey: reminderLeadTimeOption(leadTime),
+					},
+				},
+			})
+		}
+		currentValueMessage = fmt.Sprintf("**Current value:** %s", strings.Join(selectedNames, ", "))
+	}
+
+	text := fmt.Sprintf("%s\n%s", s.description, currentValueMessage)
+	sa := model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Actions:  actions,
+		Fallback: fmt.Sprintf("%s: %s", title, text),
+	}
+
+	return &sa, nil
+}
+
+func (s *reminderLeadTimesSetting) IsDisabled(foreignValue interface{}) bool {
+	return foreignValue == "false"
+}
+
+func reminderLeadTimeName(leadTime int) string {
+	if leadTime == store.OutlookReminderLeadTime {
+		return "Outlook reminder"
+	}
+	if leadTime == 1 {
+		return "1 minute before"
+	}
+	return fmt.Sprintf("%d minutes before", leadTime)
+}
+
+func reminderLeadTimeOption(leadTime int) string {
+	if leadTime == store.OutlookReminderLeadTime {
+		return outlookReminderOption
+	}
+	return strconv.Itoa(leadTime)
+}
+
+func parseReminderLeadTimeOption(option string) (int, error) {
+	for _, leadTime := range reminderLeadTimeOptions {
+		if reminderLeadTimeOption(leadTime) == option {
+			return leadTime, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid reminder time %q", option)
+}
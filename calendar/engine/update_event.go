@@ -0,0 +1,187 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+// UpdateEventPayload describes the changes to apply to an existing event. Empty fields are left untouched.
+// Date, StartTime and EndTime are interpreted in the timezone of the user mailbox, and Shift moves the
+// resulting start and end times by a duration like "30m" or "-1h".
+type UpdateEventPayload struct {
+	Subject     string `json:"subject,omitempty"`
+	Location    string `json:"location,omitempty"`
+	Description string `json:"description,omitempty"`
+	Date        string `json:"date,omitempty"`
+	StartTime   string `json:"start_time,omitempty"`
+	EndTime     string `json:"end_time,omitempty"`
+	Shift       string `json:"shift,omitempty"`
+}
+
+func (uep UpdateEventPayload) changesTime() bool {
+	return uep.Date != "" || uep.StartTime != "" || uep.EndTime != "" || uep.Shift != ""
+}
+
+// ToRemoteEvent returns the event fields to patch on the original event.
+func (uep UpdateEventPayload) ToRemoteEvent(original *remote.Event, loc *time.Location, now time.Time) (*remote.Event, error) {
+	evt := &remote.Event{
+		Subject: uep.Subject,
+	}
+	if uep.Location != "" {
+		evt.Location = &remote.Location{
+			DisplayName: uep.Location,
+		}
+	}
+	if uep.Description != "" {
+		evt.Body = &remote.ItemBody{
+			Content:     uep.Description,
+			ContentType: "text/plain",
+		}
+	}
+
+	if !uep.changesTime() {
+		if evt.Subject == "" && evt.Location == nil && evt.Body == nil {
+			return nil, errors.New("nothing to update")
+		}
+		return evt, nil
+	}
+
+	if original.Start == nil || original.End == nil {
+		return nil, errors.New("the event has no start or end time")
+	}
+	if original.IsAllDay && (uep.StartTime != "" || uep.EndTime != "") {
+		return nil, errors.New("the time of all day events can't be changed")
+	}
+
+	start := original.Start.Time().In(loc)
+	end := original.End.Time().In(loc)
+	duration := end.Sub(start)
+
+	if uep.Date != "" {
+		date, err := time.ParseInLocation(CreateEventDateFormat, uep.Date, loc)
+		if err != nil {
+			return nil, errors.New("invalid date")
+		}
+		start = time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+		end = start.Add(duration)
+	}
+
+	if uep.StartTime != "" {
+		t, err := time.ParseInLocation(CreateEventTimeFormat, uep.StartTime, loc)
+		if err != nil {
+			return nil, errors.New("please use a valid start time")
+		}
+		start = time.Date(start.Year(), start.Month(), start.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+		end = start.Add(duration)
+	}
+
+	if uep.EndTime != "" {
+		t, err := time.ParseInLocation(CreateEventTimeFormat, uep.EndTime, loc)
+		if err != nil {
+			return nil, errors.New("please use a valid end time")
+		}
+		end = time.Date(start.Year(), start.Month(), start.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+	}
+
+	if uep.Shift != "" {
+		shift, err := time.ParseDuration(uep.Shift)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shift %q", uep.Shift)
+		}
+		start = start.Add(shift)
+		end = end.Add(shift)
+	}
+
+	if start.Before(now) {
+		return nil, errors.New("please select a start date and time that is not prior to the current time")
+	}
+	if !end.After(start) {
+		return nil, errors.New("end date must be later than start date")
+	}
+
+	evt.Start = &remote.DateTime{
+		DateTime: start.Format(remote.RFC3339NanoNoTimezone),
+		TimeZone: loc.String(),
+	}
+	evt.End = &remote.DateTime{
+		DateTime: end.Format(remote.RFC3339NanoNoTimezone),
+		TimeZone: loc.String(),
+	}
+
+	return evt, nil
+}
+
+func (m *mscalendar) UpdateEvent(user *User, eventID string, payload UpdateEventPayload) (*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := m.client.GetEvent(user.Remote.ID, eventID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting event")
+	}
+	if !original.IsOrganizer {
+		return nil, fmt.Errorf("%w: only the organizer can update the event", ErrInvalidEventPayload)
+	}
+
+	mailbox, err := m.client.GetMailboxSettings(user.Remote.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting mailbox settings")
+	}
+
+	loc, err := time.LoadLocation(tz.Go(mailbox.TimeZone))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading mailbox timezone %s", mailbox.TimeZone)
+	}
+
+	event, err := payload.ToRemoteEvent(original, loc, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEventPayload, err.Error())
+	}
+
+	return m.client.UpdateEvent(user.Remote.ID, eventID, event)
+}
+
+func (m *mscalendar) CancelEvent(user *User, eventID, comment string) error {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return err
+	}
+
+	event, err := m.client.GetEvent(user.Remote.ID, eventID)
+	if err != nil {
+		return errors.Wrap(err, "error getting event")
+	}
+	if !event.IsOrganizer {
+		return fmt.Errorf("%w: only the organizer can cancel the event", ErrInvalidEventPayload)
+	}
+
+	return m.client.CancelEvent(user.Remote.ID, eventID, comment)
+}
+
+func (m *mscalendar) DeleteEvent(user *User, eventID string) error {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return err
+	}
+
+	return m.client.DeleteEvent(user.Remote.ID, eventID)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestUpdateEventPayloadToRemoteEvent(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	now := time.Date(2024, time.May, 15, 8, 0, 0, 0, loc)
+	original := &remote.Event{
+		Start: &remote.DateTime{DateTime: "2024-05-15T14:00:00", TimeZone: "UTC"},
+		End:   &remote.DateTime{DateTime: "2024-05-15T15:00:00", TimeZone: "UTC"},
+	}
+
+	for name, tc := range map[string]struct {
+		payload       UpdateEventPayload
+		expectedStart string
+		expectedEnd   string
+		expectedError string
+	}{
+		"shift by 30 minutes": {
+			payload:       UpdateEventPayload{Shift: "30m"},
+			expectedStart: "2024-05-15T10:30:00",
+			expectedEnd:   "2024-05-15T11:30:00",
+		},
+		"new date keeps the time": {
+			payload:       UpdateEventPayload{Date: "2024-05-17"},
+			expectedStart: "2024-05-17T10:00:00",
+			expectedEnd:   "2024-05-17T11:00:00",
+		},
+		"new start time keeps the duration": {
+			payload:       UpdateEventPayload{StartTime: "13:00"},
+			expectedStart: "2024-05-15T13:00:00",
+			expectedEnd:   "2024-05-15T14:00:00",
+		},
+		"new start and end time": {
+			payload:       UpdateEventPayload{StartTime: "13:00", EndTime: "13:15"},
+			expectedStart: "2024-05-15T13:00:00",
+			expectedEnd:   "2024-05-15T13:15:00",
+		},
+		"moved to the past": {
+			payload:       UpdateEventPayload{Shift: "-3h"},
+			expectedError: "please select a start date and time that is not prior to the current time",
+		},
+		"end before start": {
+			payload:       UpdateEventPayload{EndTime: "09:00"},
+			expectedError: "end date must be later than start date",
+		},
+		"nothing to update": {
+			payload:       UpdateEventPayload{},
+			expectedError: "nothing to update",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			evt, err := tc.payload.ToRemoteEvent(original, loc, now)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStart, evt.Start.DateTime)
+			require.Equal(t, tc.expectedEnd, evt.End.DateTime)
+			require.Equal(t, "America/New_York", evt.Start.TimeZone)
+		})
+	}
+}
@@ -71,7 +71,30 @@ func RenderCalendarView(events []*remote.Event, timeZone string) (string, error)
 	return resp, nil
 }
 
-func RenderDaySummary(events []*remote.Event, timezone string) (string, []*model.SlackAttachment, error) {
+// RenderEventList renders the events as a numbered list, so they can be referred to by their position.
+func RenderEventList(events []*remote.Event, timeZone string) (string, error) {
+	if len(events) == 0 {
+		return "You have no upcoming events.", nil
+	}
+
+	resp := "Times are shown in " + events[0].Start.In(timeZone).TimeZone + "\n"
+	for i, e := range events {
+		eventString, err := renderEvent(e, false, timeZone)
+		if err != nil {
+			return "", err
+		}
+		resp += fmt.Sprintf("\n%d. %s %s", i+1, e.Start.In(timeZone).Time().Format("Mon Jan 02"), eventString)
+		if e.IsOrganizer {
+			resp += " (organizer)"
+		}
+	}
+
+	return resp, nil
+}
+
+// RenderDaySummary renders the events as one attachment each, with the actions returned by eventActions when it
+// is not nil. When the events span several days, the date is shown with the time of each event.
+func RenderDaySummary(events []*remote.Event, timezone string, eventActions func(event *remote.Event) []*model.PostAction) (string, []*model.SlackAttachment, error) {
 	if len(events) == 0 {
 		return "You have no events for that day", nil, nil
 	}
@@ -83,11 +106,25 @@ func RenderDaySummary(events []*remote.Event, timezone string) (string, []*model
 		}
 	}
 
-	message := fmt.Sprintf("Agenda for %s.\nTimes are shown in %s", events[0].Start.Time().Format("Monday, 02 January"), events[0].Start.TimeZone)
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Start.Time().Before(events[j].Start.Time())
+	})
+
+	first := events[0].Start.Time()
+	last := events[len(events)-1].Start.Time()
+	multipleDays := first.Format("2006-01-02") != last.Format("2006-01-02")
+
+	message := fmt.Sprintf("Agenda for %s.\nTimes are shown in %s", first.Format("Monday, 02 January"), events[0].Start.TimeZone)
+	if multipleDays {
+		message = fmt.Sprintf("Agenda from %s to %s.\nTimes are shown in %s", first.Format("Monday, 02 January"), last.Format("Monday, 02 January"), events[0].Start.TimeZone)
+	}
 
 	var attachments []*model.SlackAttachment
 	for _, event := range events {
 		var actions []*model.PostAction
+		if eventActions != nil {
+			actions = eventActions(event)
+		}
 
 		fields := []*model.SlackAttachmentField{}
 		if event.Location != nil && event.Location.DisplayName != "" {
@@ -97,11 +134,20 @@ func RenderDaySummary(events []*remote.Event, timezone string) (string, []*model
 				Short: true,
 			})
 		}
+		if field := recurrenceAttachmentField(event); field != nil {
+			fields = append(fields, field)
+		}
+
+		text := fmt.Sprintf("(%s - %s)", event.Start.In(timezone).Time().Format(time.Kitchen), event.End.In(timezone).Time().Format(time.Kitchen))
+		if multipleDays {
+			text = event.Start.In(timezone).Time().Format("Mon Jan 02") + " " + text
+		}
 
 		attachments = append(attachments, &model.SlackAttachment{
-			Title: event.Subject,
+			Title:     event.Subject,
+			TitleLink: event.Weblink,
 			// Text:    event.BodyPreview,
-			Text:    fmt.Sprintf("(%s - %s)", event.Start.In(timezone).Time().Format(time.Kitchen), event.End.In(timezone).Time().Format(time.Kitchen)),
+			Text:    text,
 			Fields:  fields,
 			Actions: actions,
 		})
@@ -154,9 +200,9 @@ func renderEvent(event *remote.Event, asRow bool, timeZone string) (string, erro
 	start := event.Start.In(timeZone).Time().Format(time.Kitchen)
 	end := event.End.In(timeZone).Time().Format(time.Kitchen)
 
-	format := "(%s - %s) [%s](%s)"
+	format := "(%s - %s) [%s](%s)%s"
 	if asRow {
-		format = "| %s - %s | [%s](%s) |"
+		format = "| %s - %s | [%s](%s)%s |"
 	}
 
 	link, err := url.QueryUnescape(event.Weblink)
@@ -166,7 +212,45 @@ func renderEvent(event *remote.Event, asRow bool, timeZone string) (string, erro
 
 	subject := EnsureSubject(event.Subject)
 
-	return fmt.Sprintf(format, start, end, MarkdownToHTMLEntities(subject), link), nil
+	return fmt.Sprintf(format, start, end, MarkdownToHTMLEntities(subject), link, recurrenceMarker(event)), nil
+}
+
+func recurrenceMarker(event *remote.Event) string {
+	switch {
+	case event.Type == remote.EventTypeException:
+		return " _(recurring, modified)_"
+	case event.IsRecurring():
+		return " _(recurring)_"
+	}
+	return ""
+}
+
+// RenderRecurrence describes how the event relates to a series. It returns an empty string for single events.
+func RenderRecurrence(event *remote.Event) string {
+	switch {
+	case event.Recurrence != nil && event.Recurrence.Pattern != nil:
+		return event.Recurrence.String()
+	case event.Type == remote.EventTypeException:
+		return "Modified occurrence of a recurring event"
+	case event.Type == remote.EventTypeOccurrence:
+		return "Occurrence of a recurring event"
+	case event.Type == remote.EventTypeSeriesMaster:
+		return "Recurring event"
+	}
+	return ""
+}
+
+func recurrenceAttachmentField(event *remote.Event) *model.SlackAttachmentField {
+	recurrence := RenderRecurrence(event)
+	if recurrence == "" {
+		return nil
+	}
+
+	return &model.SlackAttachmentField{
+		Title: "Recurrence",
+		Value: recurrence,
+		Short: true,
+	}
 }
 
 func RenderEventAsAttachment(event *remote.Event, timezone string, options ...Option) (*model.SlackAttachment, error) {
@@ -198,6 +282,10 @@ func RenderEventAsAttachment(event *remote.Event, timezone string, options ...Op
 		})
 	}
 
+	if field := recurrenceAttachmentField(event); field != nil {
+		fields = append(fields, field)
+	}
+
 	attachment := &model.SlackAttachment{
 		Title:     event.Subject,
 		TitleLink: titleLink,
@@ -0,0 +1,110 @@
+package views
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+const (
+	CalendarViewTable   = "table"
+	CalendarViewCompact = "compact"
+	CalendarViewAgenda  = "agenda"
+)
+
+// RenderCalendarCompact renders the events with one line per event, prefixed by their date.
+func RenderCalendarCompact(events []*remote.Event, timeZone string) (string, error) {
+	if len(events) == 0 {
+		return "You have no upcoming events.", nil
+	}
+
+	events = inTimeZone(events, timeZone)
+
+	resp := "Times are shown in " + events[0].Start.TimeZone + "\n"
+	for _, e := range events {
+		var eventString string
+		var err error
+		if e.IsAllDay {
+			eventString, err = renderAllDayEvent(e)
+		} else {
+			eventString, err = renderEvent(e, false, timeZone)
+		}
+		if err != nil {
+			return "", err
+		}
+		resp += fmt.Sprintf("\n%s %s", e.Start.Time().Format("Mon Jan 02"), eventString)
+	}
+
+	return resp, nil
+}
+
+// RenderCalendarAgenda renders the events grouped by day, with their location and attendees count.
+func RenderCalendarAgenda(events []*remote.Event, timeZone string) (string, error) {
+	if len(events) == 0 {
+		return "You have no upcoming events.", nil
+	}
+
+	events = inTimeZone(events, timeZone)
+
+	resp := "Times are shown in " + events[0].Start.TimeZone
+	for _, group := range groupEventsByDate(events) {
+		resp += "\n\n#### " + group[0].Start.Time().Format("Monday January 02, 2006") + "\n"
+		for _, e := range group {
+			link, err := url.QueryUnescape(e.Weblink)
+			if err != nil {
+				return "", err
+			}
+
+			when := fmt.Sprintf("%s - %s", e.Start.Time().Format(time.Kitchen), e.End.Time().Format(time.Kitchen))
+			if e.IsAllDay {
+				when = "All day"
+			}
+			resp += fmt.Sprintf("\n* **%s** [%s](%s)%s", when, MarkdownToHTMLEntities(EnsureSubject(e.Subject)), link, recurrenceMarker(e))
+
+			details := []string{}
+			if e.Location != nil && e.Location.DisplayName != "" {
+				details = append(details, "Location: "+e.Location.DisplayName)
+			}
+			if e.Conference != nil && e.Conference.URL != "" {
+				details = append(details, fmt.Sprintf("[Join meeting](%s)", e.Conference.URL))
+			}
+			if len(e.Attendees) > 0 {
+				details = append(details, fmt.Sprintf("%d attendees", len(e.Attendees)))
+			}
+			if len(details) > 0 {
+				resp += "\n  " + strings.Join(details, " · ")
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+func renderAllDayEvent(event *remote.Event) (string, error) {
+	link, err := url.QueryUnescape(event.Weblink)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("(All day) [%s](%s)%s", MarkdownToHTMLEntities(EnsureSubject(event.Subject)), link, recurrenceMarker(event)), nil
+}
+
+// inTimeZone converts the times of the events to the given timezone, and sorts them by start time.
+func inTimeZone(events []*remote.Event, timeZone string) []*remote.Event {
+	if timeZone != "" {
+		for _, e := range events {
+			e.Start = e.Start.In(timeZone)
+			e.End = e.End.In(timeZone)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Start.Time().Before(events[j].Start.Time())
+	})
+
+	return events
+}
@@ -0,0 +1,65 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func testCalendarEvents() []*remote.Event {
+	return []*remote.Event{
+		{
+			Subject:  "Retro",
+			Weblink:  "https://example.com/retro",
+			Start:    remote.NewDateTime(time.Date(2024, time.May, 16, 15, 0, 0, 0, time.UTC), "UTC"),
+			End:      remote.NewDateTime(time.Date(2024, time.May, 16, 16, 0, 0, 0, time.UTC), "UTC"),
+			Location: &remote.Location{DisplayName: "Room 1"},
+			Attendees: []*remote.Attendee{
+				{EmailAddress: &remote.EmailAddress{Address: "alice@example.com"}},
+				{EmailAddress: &remote.EmailAddress{Address: "bob@example.com"}},
+			},
+		},
+		{
+			Subject: "Standup",
+			Weblink: "https://example.com/standup",
+			Start:   remote.NewDateTime(time.Date(2024, time.May, 16, 9, 0, 0, 0, time.UTC), "UTC"),
+			End:     remote.NewDateTime(time.Date(2024, time.May, 16, 9, 15, 0, 0, time.UTC), "UTC"),
+			Type:    remote.EventTypeOccurrence,
+		},
+		{
+			Subject:  "Holiday",
+			Weblink:  "https://example.com/holiday",
+			IsAllDay: true,
+			Start:    remote.NewDateTime(time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC), "UTC"),
+			End:      remote.NewDateTime(time.Date(2024, time.May, 18, 0, 0, 0, 0, time.UTC), "UTC"),
+		},
+	}
+}
+
+func TestRenderCalendarCompact(t *testing.T) {
+	out, err := RenderCalendarCompact(testCalendarEvents(), "UTC")
+	require.NoError(t, err)
+	require.Equal(t, "Times are shown in UTC\n"+
+		"\nThu May 16 (9:00AM - 9:15AM) [Standup](https://example.com/standup) _(recurring)_"+
+		"\nThu May 16 (3:00PM - 4:00PM) [Retro](https://example.com/retro)"+
+		"\nFri May 17 (All day) [Holiday](https://example.com/holiday)", out)
+
+	out, err = RenderCalendarCompact(nil, "UTC")
+	require.NoError(t, err)
+	require.Equal(t, "You have no upcoming events.", out)
+}
+
+func TestRenderCalendarAgenda(t *testing.T) {
+	out, err := RenderCalendarAgenda(testCalendarEvents(), "UTC")
+	require.NoError(t, err)
+	require.Equal(t, "Times are shown in UTC"+
+		"\n\n#### Thursday May 16, 2024\n"+
+		"\n* **9:00AM - 9:15AM** [Standup](https://example.com/standup) _(recurring)_"+
+		"\n* **3:00PM - 4:00PM** [Retro](https://example.com/retro)"+
+		"\n  Location: Room 1 · 2 attendees"+
+		"\n\n#### Friday May 17, 2024\n"+
+		"\n* **All day** [Holiday](https://example.com/holiday)", out)
+}
@@ -0,0 +1,216 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	ICalendarContentType = "text/calendar"
+	ICalendarExtension   = ".ics"
+
+	// ICalendarDateTimeFormat is the format of local date-times, UTC date-times end with a "Z".
+	ICalendarDateTimeFormat = "20060102T150405"
+	ICalendarDateFormat     = "20060102"
+
+	icalUTCDateTimeFormat = ICalendarDateTimeFormat + "Z"
+	icalLineLength        = 75
+	icalLineBreak         = "\r\n"
+)
+
+// RenderICalendar serializes the events as an iCalendar (RFC 5545) object. Timed events are written in UTC,
+// all-day events as dates in the time zone of the event.
+func RenderICalendar(events []*remote.Event, now time.Time) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Mattermost//Calendar Plugin//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	for _, event := range events {
+		if event == nil || event.Start == nil || event.End == nil {
+			continue
+		}
+		lines = append(lines, renderICalendarEvent(event, now)...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICalendarLine(line))
+		b.WriteString(icalLineBreak)
+	}
+	return b.String()
+}
+
+func renderICalendarEvent(event *remote.Event, now time.Time) []string {
+	uid := event.ID
+	if event.ICalUID != "" && event.SeriesMasterID == "" {
+		// Occurrences of a series share the iCalUId of the series, so they keep their own ID.
+		uid = event.ICalUID
+	}
+
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + escapeICalendarText(uid),
+		"DTSTAMP:" + now.UTC().Format(icalUTCDateTimeFormat),
+	}
+
+	if event.IsAllDay {
+		lines = append(lines,
+			"DTSTART;VALUE=DATE:"+icalDate(event.Start),
+			"DTEND;VALUE=DATE:"+icalDate(event.End),
+		)
+	} else {
+		lines = append(lines,
+			"DTSTART:"+event.Start.Time().UTC().Format(icalUTCDateTimeFormat),
+			"DTEND:"+event.End.Time().UTC().Format(icalUTCDateTimeFormat),
+		)
+	}
+
+	lines = append(lines, "SUMMARY:"+escapeICalendarText(event.Subject))
+
+	description := event.BodyPreview
+	if event.Conference != nil && event.Conference.URL != "" {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += "Join meeting: " + event.Conference.URL
+	}
+	if description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeICalendarText(description))
+	}
+
+	if event.Location != nil && event.Location.DisplayName != "" {
+		lines = append(lines, "LOCATION:"+escapeICalendarText(event.Location.DisplayName))
+	}
+
+	if event.Conference != nil && event.Conference.URL != "" {
+		lines = append(lines, "URL:"+event.Conference.URL)
+	} else if event.Weblink != "" {
+		lines = append(lines, "URL:"+event.Weblink)
+	}
+
+	if event.Organizer != nil && event.Organizer.EmailAddress != nil && event.Organizer.EmailAddress.Address != "" {
+		lines = append(lines, "ORGANIZER"+icalCommonName(event.Organizer.EmailAddress)+":mailto:"+event.Organizer.EmailAddress.Address)
+	}
+
+	for _, attendee := range event.Attendees {
+		if attendee == nil || attendee.EmailAddress == nil || attendee.EmailAddress.Address == "" {
+			continue
+		}
+		role := "REQ-PARTICIPANT"
+		if strings.EqualFold(attendee.Type, "optional") {
+			role = "OPT-PARTICIPANT"
+		}
+		partStat := "NEEDS-ACTION"
+		if attendee.Status != nil {
+			partStat = icalParticipationStatus(attendee.Status.Response)
+		}
+		lines = append(lines, fmt.Sprintf("ATTENDEE%s;ROLE=%s;PARTSTAT=%s:mailto:%s",
+			icalCommonName(attendee.EmailAddress), role, partStat, attendee.EmailAddress.Address))
+	}
+
+	status := "CONFIRMED"
+	if event.IsCancelled {
+		status = "CANCELLED"
+	}
+	lines = append(lines, "STATUS:"+status)
+
+	transparency := "OPAQUE"
+	if event.ShowAs == "free" {
+		transparency = "TRANSPARENT"
+	}
+	lines = append(lines, "TRANSP:"+transparency)
+
+	return append(lines, "END:VEVENT")
+}
+
+// icalDate returns the date of an all-day boundary, which is midnight in the time zone of the event.
+func icalDate(dt *remote.DateTime) string {
+	t := dt.Time()
+	if loc, err := time.LoadLocation(tz.Go(dt.TimeZone)); err == nil {
+		t = t.In(loc)
+	}
+	return t.Format(ICalendarDateFormat)
+}
+
+func icalCommonName(address *remote.EmailAddress) string {
+	if address.Name == "" {
+		return ""
+	}
+	return ";CN=" + quoteICalendarParam(address.Name)
+}
+
+func icalParticipationStatus(response string) string {
+	switch response {
+	case remote.EventResponseStatusAccepted, "organizer":
+		return "ACCEPTED"
+	case remote.EventResponseStatusDeclined:
+		return "DECLINED"
+	case remote.EventResponseStatusTentative, "tentativelyAccepted":
+		return "TENTATIVE"
+	}
+	return "NEEDS-ACTION"
+}
+
+func escapeICalendarText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(value)
+}
+
+// UnescapeICalendarText reverses escapeICalendarText, for the text values of parsed iCalendar objects.
+func UnescapeICalendarText(value string) string {
+	return strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	).Replace(value)
+}
+
+// quoteICalendarParam quotes a parameter value, which cannot contain double quotes.
+func quoteICalendarParam(value string) string {
+	value = strings.ReplaceAll(value, `"`, "'")
+	if strings.ContainsAny(value, ":;,") {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// foldICalendarLine splits lines longer than 75 octets, without splitting UTF-8 characters. Continuation
+// lines start with a space, which counts towards their length.
+func foldICalendarLine(line string) string {
+	if len(line) <= icalLineLength {
+		return line
+	}
+
+	var b strings.Builder
+	limit := icalLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString(icalLineBreak + " ")
+		line = line[cut:]
+		limit = icalLineLength - 1
+	}
+	b.WriteString(line)
+
+	return b.String()
+}
@@ -0,0 +1,121 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestRenderICalendar(t *testing.T) {
+	now := time.Date(2024, time.May, 15, 12, 0, 0, 0, time.UTC)
+	events := []*remote.Event{
+		{
+			ID:          "event_id",
+			ICalUID:     "ical_uid",
+			Subject:     "Retro; sprint 12, part 1",
+			BodyPreview: "Agenda:\nwins",
+			Start:       &remote.DateTime{DateTime: "2024-05-16T17:00:00", TimeZone: "Europe/Paris"},
+			End:         &remote.DateTime{DateTime: "2024-05-16T18:00:00", TimeZone: "Europe/Paris"},
+			Location:    &remote.Location{DisplayName: "Room 1"},
+			Conference:  &remote.Conference{Application: "Teams", URL: "https://example.com/join"},
+			Organizer: &remote.Attendee{
+				EmailAddress: &remote.EmailAddress{Name: "Alice", Address: "alice@example.com"},
+			},
+			Attendees: []*remote.Attendee{
+				{
+					Type:         "required",
+					EmailAddress: &remote.EmailAddress{Name: "Bob, Jr.", Address: "bob@example.com"},
+					Status:       &remote.EventResponseStatus{Response: "tentativelyAccepted"},
+				},
+				{
+					Type:         "optional",
+					EmailAddress: &remote.EmailAddress{Address: "carol@example.com"},
+				},
+			},
+		},
+		{
+			ID:             "occurrence_id",
+			ICalUID:        "series_uid",
+			SeriesMasterID: "series_id",
+			Subject:        "Holiday",
+			IsAllDay:       true,
+			ShowAs:         "free",
+			IsCancelled:    true,
+			Start:          &remote.DateTime{DateTime: "2024-05-17T00:00:00", TimeZone: "Pacific Standard Time"},
+			End:            &remote.DateTime{DateTime: "2024-05-18T00:00:00", TimeZone: "Pacific Standard Time"},
+		},
+		{
+			ID:      "no_dates",
+			Subject: "Skipped",
+		},
+	}
+
+	out := RenderICalendar(events, now)
+	require.Equal(t, strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Mattermost//Calendar Plugin//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:ical_uid",
+		"DTSTAMP:20240515T120000Z",
+		"DTSTART:20240516T150000Z",
+		"DTEND:20240516T160000Z",
+		`SUMMARY:Retro\; sprint 12\, part 1`,
+		`DESCRIPTION:Agenda:\nwins\n\nJoin meeting: https://example.com/join`,
+		"LOCATION:Room 1",
+		"URL:https://example.com/join",
+		"ORGANIZER;CN=Alice:mailto:alice@example.com",
+		`ATTENDEE;CN="Bob, Jr.";ROLE=REQ-PARTICIPANT;PARTSTAT=TENTATIVE:mailto:bob@e`,
+		" xample.com",
+		"ATTENDEE;ROLE=OPT-PARTICIPANT;PARTSTAT=NEEDS-ACTION:mailto:carol@example.co",
+		" m",
+		"STATUS:CONFIRMED",
+		"TRANSP:OPAQUE",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:occurrence_id",
+		"DTSTAMP:20240515T120000Z",
+		"DTSTART;VALUE=DATE:20240517",
+		"DTEND;VALUE=DATE:20240518",
+		"SUMMARY:Holiday",
+		"STATUS:CANCELLED",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"), out)
+}
+
+func TestFoldICalendarLine(t *testing.T) {
+	for name, tc := range map[string]struct {
+		line     string
+		expected string
+	}{
+		"short line": {
+			line:     "SUMMARY:Standup",
+			expected: "SUMMARY:Standup",
+		},
+		"exactly 75 octets": {
+			line:     strings.Repeat("a", 75),
+			expected: strings.Repeat("a", 75),
+		},
+		"long line": {
+			line:     strings.Repeat("a", 160),
+			expected: strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n " + strings.Repeat("a", 11),
+		},
+		"multi-byte characters are not split": {
+			line:     strings.Repeat("a", 74) + "éé",
+			expected: strings.Repeat("a", 74) + "\r\n éé",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, foldICalendarLine(tc.line))
+		})
+	}
+}
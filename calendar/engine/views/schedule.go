@@ -0,0 +1,78 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+// ScheduleRow is a line of the schedule grid, either with the availability view of the user or an error.
+type ScheduleRow struct {
+	Name             string
+	AvailabilityView remote.AvailabilityView
+	Error            string
+}
+
+var scheduleSlotSymbols = map[rune]string{
+	remote.AvailabilityViewFree:             "·",
+	remote.AvailabilityViewTentative:        "?",
+	remote.AvailabilityViewBusy:             "#",
+	remote.AvailabilityViewOutOfOffice:      "O",
+	remote.AvailabilityViewWorkingElsewhere: "W",
+}
+
+// RenderScheduleGrid renders the availability of users as a grid, one column per slot of the given interval.
+func RenderScheduleGrid(rows []ScheduleRow, from time.Time, numberOfSlots int, interval time.Duration) string {
+	nameWidth := 0
+	for _, row := range rows {
+		if len(row.Name) > nameWidth {
+			nameWidth = len(row.Name)
+		}
+	}
+
+	header := strings.Repeat(" ", nameWidth+1)
+	for i := 0; i < numberOfSlots; {
+		slot := from.Add(time.Duration(i) * interval)
+		if slot.Minute() != 0 {
+			header += " "
+			i++
+			continue
+		}
+
+		label := slot.Format("15")
+		header += label
+		i += len(label)
+	}
+
+	lines := []string{strings.TrimRight(header, " ")}
+	for _, row := range rows {
+		line := row.Name + strings.Repeat(" ", nameWidth-len(row.Name)+1)
+		if row.Error != "" {
+			lines = append(lines, line+"("+row.Error+")")
+			continue
+		}
+
+		for i, status := range string(row.AvailabilityView) {
+			if i >= numberOfSlots {
+				break
+			}
+			symbol, ok := scheduleSlotSymbols[status]
+			if !ok {
+				symbol = " "
+			}
+			line += symbol
+		}
+		lines = append(lines, line)
+	}
+
+	return fmt.Sprintf("Availability on %s, %s - %s (%s)\n```\n%s\n```\n%s",
+		from.Format("Monday, January 2"),
+		from.Format(time.Kitchen),
+		from.Add(time.Duration(numberOfSlots)*interval).Format(time.Kitchen),
+		from.Location().String(),
+		strings.Join(lines, "\n"),
+		fmt.Sprintf("Each column is %d minutes. `·` free, `?` tentative, `#` busy, `O` out of office, `W` working elsewhere.", int(interval.Minutes())),
+	)
+}
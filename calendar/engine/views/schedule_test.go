@@ -0,0 +1,25 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderScheduleGrid(t *testing.T) {
+	from := time.Date(2024, time.May, 15, 9, 0, 0, 0, time.UTC)
+	rows := []ScheduleRow{
+		{Name: "@alice", AvailabilityView: "00122340"},
+		{Name: "@bob", Error: "not connected to Microsoft Outlook Calendar"},
+	}
+
+	expected := "Availability on Wednesday, May 15, 9:00AM - 1:00PM (UTC)\n" +
+		"```\n" +
+		"       09101112\n" +
+		"@alice ··?##OW·\n" +
+		"@bob   (not connected to Microsoft Outlook Calendar)\n" +
+		"```\n" +
+		"Each column is 30 minutes. `·` free, `?` tentative, `#` busy, `O` out of office, `W` working elsewhere."
+	require.Equal(t, expected, RenderScheduleGrid(rows, from, 8, 30*time.Minute))
+}
@@ -0,0 +1,87 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+// DayMeetingTime is the time spent in meetings during a day, overlapping meetings being counted once.
+type DayMeetingTime struct {
+	Day      time.Time
+	Duration time.Duration
+	Count    int
+}
+
+// BackToBackStreak is a series of meetings without a break between them.
+type BackToBackStreak struct {
+	Start time.Time
+	End   time.Time
+	Count int
+}
+
+func RenderMeetingTime(days []DayMeetingTime) string {
+	resp := "##### Meeting hours\n"
+	total := time.Duration(0)
+	for _, day := range days {
+		total += day.Duration
+	}
+	if total == 0 {
+		return resp + "You had no meetings this week."
+	}
+
+	resp += "| Day | Meetings | Hours |\n| :-- | :-- | :-- |"
+	for _, day := range days {
+		resp += fmt.Sprintf("\n| %s | %d | %s |", day.Day.Format("Monday"), day.Count, formatHours(day.Duration))
+	}
+	resp += fmt.Sprintf("\n| **Total** | | **%s** |", formatHours(total))
+
+	return resp
+}
+
+func RenderBackToBackStreaks(streaks []BackToBackStreak) string {
+	resp := "##### Back-to-back meetings\n"
+	if len(streaks) == 0 {
+		return resp + "You had no back-to-back meetings this week."
+	}
+
+	for _, streak := range streaks {
+		resp += fmt.Sprintf("\n- %s %s - %s: %d meetings in a row",
+			streak.Start.Format("Monday"),
+			streak.Start.Format(time.Kitchen),
+			streak.End.Format(time.Kitchen),
+			streak.Count,
+		)
+	}
+
+	return resp
+}
+
+func RenderAwaitingResponse(events []*remote.Event, timeZone string) (string, error) {
+	resp := "##### Awaiting your response\n"
+	if len(events) == 0 {
+		return resp + "You responded to all your upcoming invitations.", nil
+	}
+
+	for _, e := range events {
+		eventString, err := renderEvent(e, false, timeZone)
+		if err != nil {
+			return "", err
+		}
+		resp += fmt.Sprintf("\n- %s %s", e.Start.In(timeZone).Time().Format("Mon Jan 02"), eventString)
+	}
+
+	return resp, nil
+}
+
+// formatHours formats a duration like "2h30".
+func formatHours(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	if minutes == 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh%02d", hours, minutes)
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+)
+
+// Unique id for the channel agenda job
+const channelAgendaJobID = "channel_agenda"
+
+// NewChannelAgendaJob creates a RegisteredJob with the parameters specific to the ChannelAgendaJob
+func NewChannelAgendaJob() RegisteredJob {
+	return RegisteredJob{
+		id:       channelAgendaJobID,
+		interval: engine.ChannelAgendaJobInterval,
+		work:     runChannelAgendaJob,
+	}
+}
+
+// runChannelAgendaJob posts the agenda of the day in the channels subscribed to a calendar
+func runChannelAgendaJob(env engine.Env) {
+	env.Logger.Debugf("Channel agenda job beginning")
+
+	err := engine.New(env, "").ProcessAllChannelAgendas(time.Now())
+	if err != nil {
+		env.Logger.Errorf("Error during channel agenda job. err=%v", err)
+	}
+
+	env.Logger.Debugf("Channel agenda job finished")
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+)
+
+// Unique id for the focus job
+const focusJobID = "focus"
+
+// NewFocusJob creates a RegisteredJob with the parameters specific to the FocusJob
+func NewFocusJob() RegisteredJob {
+	return RegisteredJob{
+		id:       focusJobID,
+		interval: engine.FocusJobInterval,
+		work:     runFocusJob,
+	}
+}
+
+// runFocusJob ends the focus blocks that are over, delivering the notifications held back during them
+func runFocusJob(env engine.Env) {
+	env.Logger.Debugf("Focus job beginning")
+
+	err := engine.New(env, "").EndFocusBlocks(time.Now())
+	if err != nil {
+		env.Logger.Errorf("Error during focus job. err=%v", err)
+	}
+
+	env.Logger.Debugf("Focus job finished")
+}
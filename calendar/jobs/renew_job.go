@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
 )
 
 const ditherRenew = 50 * time.Millisecond
@@ -19,25 +20,34 @@ func NewRenewJob() RegisteredJob {
 	}
 }
 
-// runRenewJob calls renews the event subscription for each connected user
+// runRenewJob calls renews the event subscription for each connected user, and the subscriptions of the
+// channels subscribed to a calendar
 func runRenewJob(env engine.Env) {
-	uindex, err := env.Store.LoadUserIndex()
+	count := 0
+	err := env.Store.ForEachUserIndexPage(func(page store.UserIndex) error {
+		for _, u := range page {
+			asUser := engine.New(env, u.MattermostUserID)
+
+			env.Logger.Debugf("Renewing for user: %s", u.MattermostUserID)
+			_, err := asUser.RenewMyEventSubscription()
+			if err != nil {
+				env.Logger.Errorf("Error renewing subscription. err=%v", err)
+			}
+
+			time.Sleep(ditherRenew)
+		}
+		count += len(page)
+		return nil
+	})
 	if err != nil {
-		env.Logger.Errorf("Renew job failed to load user index. err=%v", err)
-		return
+		// The subscriptions of the users of the shards failing to load are renewed by the next job
+		env.Logger.Errorf("Renew job failed to load some users from the user index. err=%v", err)
 	}
-	env.Logger.Debugf("Renew job: %v users", len(uindex))
-
-	for _, u := range uindex {
-		asUser := engine.New(env, u.MattermostUserID)
+	env.Logger.Debugf("Renew job: %v users", count)
 
-		env.Logger.Debugf("Renewing for user: %s", u.MattermostUserID)
-		_, err = asUser.RenewMyEventSubscription()
-		if err != nil {
-			env.Logger.Errorf("Error renewing subscription. err=%v", err)
-		}
-
-		time.Sleep(ditherRenew)
+	err = engine.New(env, "").RenewChannelCalendarSubscriptions()
+	if err != nil {
+		env.Logger.Errorf("Error renewing channel subscriptions. err=%v", err)
 	}
 
 	env.Logger.Debugf("Renew job finished")
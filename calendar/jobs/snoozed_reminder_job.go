@@ -0,0 +1,34 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+)
+
+// Unique id for the snoozed reminder job
+const snoozedReminderJobID = "snoozed_reminder"
+
+// NewSnoozedReminderJob creates a RegisteredJob with the parameters specific to the SnoozedReminderJob
+func NewSnoozedReminderJob() RegisteredJob {
+	return RegisteredJob{
+		id:       snoozedReminderJobID,
+		interval: engine.SnoozedReminderJobInterval,
+		work:     runSnoozedReminderJob,
+	}
+}
+
+// runSnoozedReminderJob delivers again the reminders that users snoozed until now
+func runSnoozedReminderJob(env engine.Env) {
+	env.Logger.Debugf("Snoozed reminder job beginning")
+
+	err := engine.New(env, "").DeliverSnoozedReminders(time.Now())
+	if err != nil {
+		env.Logger.Errorf("Error during snoozed reminder job. err=%v", err)
+	}
+
+	env.Logger.Debugf("Snoozed reminder job finished")
+}
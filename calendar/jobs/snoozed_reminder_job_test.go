@@ -0,0 +1,59 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package jobs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot/mock_bot"
+)
+
+func TestRunSnoozedReminderJob(t *testing.T) {
+	for name, tc := range map[string]struct {
+		loadError     error
+		expectedError bool
+	}{
+		"no snoozed reminders": {},
+		"store failure": {
+			loadError:     errors.New("unavailable"),
+			expectedError: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mock_store.NewMockStore(ctrl)
+			logger := mock_bot.NewMockLogger(ctrl)
+			env := engine.Env{
+				Config: &config.Config{},
+				Dependencies: &engine.Dependencies{
+					Store:  s,
+					Logger: logger,
+				},
+			}
+
+			logger.EXPECT().Debugf(gomock.Any()).Times(2)
+			if tc.loadError != nil {
+				s.EXPECT().LoadSnoozedReminderUsers().Return(nil, tc.loadError)
+			} else {
+				s.EXPECT().LoadSnoozedReminderUsers().Return([]string{"user_mm_id"}, nil)
+				s.EXPECT().LoadSnoozedReminders("user_mm_id").Return([]*store.SnoozedReminder{}, nil)
+				s.EXPECT().DeleteSnoozedReminders("user_mm_id", []*store.SnoozedReminder{}).Return(nil)
+			}
+			if tc.expectedError {
+				logger.EXPECT().Errorf("Error during snoozed reminder job. err=%v", tc.loadError)
+			}
+
+			runSnoozedReminderJob(env)
+		})
+	}
+}
@@ -26,5 +26,14 @@ func runSyncJob(env engine.Env) {
 		env.Logger.Errorf("Error during user status sync job. err=%v", err)
 	}
 
-	env.Logger.Debugf("User status sync job finished.\nSummary\nNumber of users processed:- %d\nNumber of users had their status changed:- %d\nNumber of users had errors:- %d", syncJobSummary.NumberOfUsersProcessed, syncJobSummary.NumberOfUsersStatusChanged, syncJobSummary.NumberOfUsersFailedStatusChanged)
+	env.Logger.Debugf("User status sync job finished in %v.\nSummary\nNumber of users processed:- %d\nNumber of users had their status changed:- %d\nNumber of users had errors:- %d\nNumber of users skipped:- %d\nTime loading users:- %v\nTime fetching calendars:- %v\nTime updating users:- %v",
+		syncJobSummary.Duration,
+		syncJobSummary.NumberOfUsersProcessed,
+		syncJobSummary.NumberOfUsersStatusChanged,
+		syncJobSummary.NumberOfUsersFailedStatusChanged,
+		syncJobSummary.NumberOfUsersSkipped,
+		syncJobSummary.LoadUsersDuration,
+		syncJobSummary.FetchCalendarsDuration,
+		syncJobSummary.UpdateUsersDuration,
+	)
 }
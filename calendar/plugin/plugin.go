@@ -119,6 +119,13 @@ func (p *Plugin) OnActivate() error {
 		e.Dependencies.Store = store.NewPluginStore(p.API, e.bot, e.Dependencies.Tracker, e.Provider.Features.EncryptedStore, []byte(e.EncryptionKey))
 	})
 
+	// Move the users of the index stored under a single key, before the index was sharded. The jobs would miss
+	// the users left in the legacy index, so the activation fails and the migration runs again on the next one.
+	err = p.getEnv().Store.MigrateUserIndex()
+	if err != nil {
+		return errors.Wrap(err, "failed to migrate the user index")
+	}
+
 	return nil
 }
 
@@ -162,6 +169,16 @@ func (p *Plugin) OnConfigurationChange() (err error) {
 	if err != nil {
 		return err
 	}
+
+	remoteKind := stored.RemoteKind
+	if remoteKind == "" {
+		remoteKind = config.Provider.Name
+	}
+	makeRemote, ok := remote.Makers[remoteKind]
+	if !ok {
+		return errors.Errorf("calendar provider %q is not available", remoteKind)
+	}
+
 	pluginURLPath := "/plugins/" + url.PathEscape(env.Config.PluginID)
 	pluginURL := strings.TrimRight(*mattermostSiteURL, "/") + pluginURLPath
 
@@ -175,7 +192,7 @@ func (p *Plugin) OnConfigurationChange() (err error) {
 		e.Config.PluginURLPath = pluginURLPath
 
 		e.bot = e.bot.WithConfig(stored.Config)
-		e.Dependencies.Remote = remote.Makers[config.Provider.Name](e.Config, e.bot)
+		e.Dependencies.Remote = makeRemote(e.Config, e.bot)
 
 		mscalendarBot := engine.NewMSCalendarBot(e.bot, e.Env, pluginURL)
 
@@ -236,6 +253,9 @@ func (p *Plugin) OnConfigurationChange() (err error) {
 			e.jobManager.AddJob(jobs.NewStatusSyncJob())
 			e.jobManager.AddJob(jobs.NewDailySummaryJob())
 			e.jobManager.AddJob(jobs.NewRenewJob())
+			e.jobManager.AddJob(jobs.NewSnoozedReminderJob())
+			e.jobManager.AddJob(jobs.NewFocusJob())
+			e.jobManager.AddJob(jobs.NewChannelAgendaJob())
 		}
 	})
 
@@ -282,6 +302,34 @@ func (p *Plugin) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*mo
 	return response, nil
 }
 
+// MessageHasBeenPosted offers to add the events of the iCalendar files attached to posts to the calendars of
+// the users. The bot doesn't reply in direct and group messages between users, which it isn't part of, only in
+// the direct messages of the users with the bot.
+func (p *Plugin) MessageHasBeenPosted(_ *plugin.Context, post *model.Post) {
+	if len(post.FileIds) == 0 {
+		return
+	}
+
+	env := p.getEnv()
+	if env.configError != nil || env.bot == nil || post.UserId == env.bot.MattermostUserID() {
+		return
+	}
+
+	channel, appErr := p.API.GetChannel(post.ChannelId)
+	if appErr != nil {
+		p.API.LogWarn("Error occurred while getting the channel of a post", "post_id", post.Id, "err", appErr.Error())
+		return
+	}
+	if channel.IsGroupOrDirect() && channel.Name != model.GetDMNameFromIds(post.UserId, env.bot.MattermostUserID()) {
+		return
+	}
+
+	err := engine.New(env.Env, post.UserId).OfferEventImport(post)
+	if err != nil {
+		p.API.LogWarn("Error occurred while offering to import calendar files", "post_id", post.Id, "err", err.Error())
+	}
+}
+
 func (p *Plugin) ServeHTTP(_ *plugin.Context, w http.ResponseWriter, req *http.Request) {
 	env := p.getEnv()
 	if env.configError != nil {
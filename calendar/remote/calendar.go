@@ -8,21 +8,25 @@ import (
 )
 
 type Calendar struct {
-	Owner        *User   `json:"owner,omitempty"`
-	ID           string  `json:"id"`
-	Name         string  `json:"name,omitempty"`
-	Events       []Event `json:"events,omitempty"`
-	CalendarView []Event `json:"calendarView,omitempty"`
+	Owner             *User   `json:"owner,omitempty"`
+	ID                string  `json:"id"`
+	Name              string  `json:"name,omitempty"`
+	IsDefaultCalendar bool    `json:"isDefaultCalendar,omitempty"`
+	Events            []Event `json:"events,omitempty"`
+	CalendarView      []Event `json:"calendarView,omitempty"`
 }
 
+// ViewCalendarParams describes a calendar view to fetch. An empty CalendarID stands for the default calendar.
 type ViewCalendarParams struct {
 	StartTime    time.Time
 	EndTime      time.Time
 	RemoteUserID string
+	CalendarID   string
 }
 
 type ViewCalendarResponse struct {
 	Error        *APIError
 	RemoteUserID string
+	CalendarID   string
 	Events       []*Event
 }
@@ -12,6 +12,7 @@ type Client interface {
 	Core
 	Calendars
 	Events
+	Mail
 	Subscriptions
 	Utils
 	Unsupported
@@ -27,18 +28,29 @@ type Calendars interface {
 	GetDefaultCalendarView(remoteUserID string, startTime, endTime time.Time) ([]*Event, error)
 	DoBatchViewCalendarRequests([]*ViewCalendarParams) ([]*ViewCalendarResponse, error)
 	GetMailboxSettings(remoteUserID string) (*MailboxSettings, error)
+	GetSchedule(requests []*ScheduleUserInfo, startTime, endTime *DateTime, availabilityViewInterval int) ([]*ScheduleInformation, error)
+	GetSharedCalendarView(calendarAddress string, startTime, endTime time.Time) ([]*Event, error)
 }
 
 type Events interface {
 	CreateEvent(remoteUserID string, calendarEvent *Event) (*Event, error)
+	UpdateEvent(remoteUserID, eventID string, calendarEvent *Event) (*Event, error)
+	CancelEvent(remoteUserID, eventID, comment string) error
+	DeleteEvent(remoteUserID, eventID string) error
 	AcceptEvent(remoteUserID, eventID string) error
 	DeclineEvent(remoteUserID, eventID string) error
 	TentativelyAcceptEvent(remoteUserID, eventID string) error
+	ProposeNewTime(remoteUserID, eventID, response string, proposedTime *TimeSlot, comment string) error
 	GetEventsBetweenDates(remoteUserID string, start, end time.Time) ([]*Event, error)
 }
 
+type Mail interface {
+	SendMail(remoteUserID string, message *Message) error
+}
+
 type Subscriptions interface {
 	CreateMySubscription(notificationURL, remoteUserID string) (*Subscription, error)
+	CreateSharedCalendarSubscription(notificationURL, calendarAddress string) (*Subscription, error)
 	DeleteSubscription(sub *Subscription) error
 	GetNotificationData(*Notification) (*Notification, error)
 	ListSubscriptions() ([]*Subscription, error)
@@ -14,6 +14,7 @@ type Event struct {
 	Start                      *DateTime            `json:"start,omitempty"`
 	Location                   *Location            `json:"location,omitempty"`
 	Conference                 *Conference          `json:"conference,omitempty"`
+	OnlineMeeting              *OnlineMeetingInfo   `json:"onlineMeeting,omitempty"`
 	End                        *DateTime            `json:"end,omitempty"`
 	Organizer                  *Attendee            `json:"organizer,omitempty"`
 	Body                       *ItemBody            `json:"Body,omitempty"`
@@ -26,10 +27,17 @@ type Event struct {
 	Weblink                    string               `json:"weblink,omitempty"`
 	ID                         string               `json:"id,omitempty"`
 	Attendees                  []*Attendee          `json:"attendees,omitempty"`
+	Categories                 []string             `json:"categories,omitempty"`
+	Sensitivity                string               `json:"sensitivity,omitempty"`
+	OnlineMeetingProvider      string               `json:"onlineMeetingProvider,omitempty"`
+	Recurrence                 *PatternedRecurrence `json:"recurrence,omitempty"`
+	Type                       string               `json:"type,omitempty"`
+	SeriesMasterID             string               `json:"seriesMasterId,omitempty"`
 	ReminderMinutesBeforeStart int                  `json:"reminderMinutesBeforeStart,omitempty"`
 	IsOrganizer                bool                 `json:"isOrganizer,omitempty"`
 	IsCancelled                bool                 `json:"isCancelled,omitempty"`
 	IsAllDay                   bool                 `json:"isAllDay,omitempty"`
+	IsReminderOn               bool                 `json:"isReminderOn,omitempty"`
 	ResponseRequested          bool                 `json:"responseRequested,omitempty"`
 }
 
@@ -68,9 +76,25 @@ type Conference struct {
 	URL         string `json:"url"`
 }
 
+type OnlineMeetingInfo struct {
+	JoinURL string `json:"joinUrl,omitempty"`
+}
+
+// JoinURL returns the URL to join the online meeting of the event, if any.
+func (e Event) JoinURL() string {
+	if e.Conference != nil && e.Conference.URL != "" {
+		return e.Conference.URL
+	}
+	if e.OnlineMeeting != nil {
+		return e.OnlineMeeting.JoinURL
+	}
+	return ""
+}
+
 type Attendee struct {
-	RemoteID     string               `json:"remoteId,omitempty"`
-	Status       *EventResponseStatus `json:"status,omitempty"`
-	EmailAddress *EmailAddress        `json:"emailAddress,omitempty"`
-	Type         string               `json:"type,omitempty"`
+	RemoteID        string               `json:"remoteId,omitempty"`
+	Status          *EventResponseStatus `json:"status,omitempty"`
+	EmailAddress    *EmailAddress        `json:"emailAddress,omitempty"`
+	Type            string               `json:"type,omitempty"`
+	ProposedNewTime *TimeSlot            `json:"proposedNewTime,omitempty"`
 }
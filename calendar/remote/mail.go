@@ -0,0 +1,14 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package remote
+
+type Message struct {
+	Body         *ItemBody    `json:"body,omitempty"`
+	Subject      string       `json:"subject,omitempty"`
+	ToRecipients []*Recipient `json:"toRecipients,omitempty"`
+}
+
+type Recipient struct {
+	EmailAddress *EmailAddress `json:"emailAddress,omitempty"`
+}
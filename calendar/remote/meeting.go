@@ -3,19 +3,29 @@
 
 package remote
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
+// FindMeetingTimesParameters are the parameters of a meeting time search. MeetingDuration is an
+// ISO 8601 duration, see FormatMeetingDuration.
 type FindMeetingTimesParameters struct {
 	ReturnSuggestionReasons   *bool               `json:"returnSuggestionReasons,omitempty"`
 	LocationConstraint        *LocationConstraint `json:"locationConstraint,omitempty"`
 	TimeConstraint            *TimeConstraint     `json:"timeConstraint,omitempty"`
-	MeetingDuration           *time.Duration      `json:"meetingDuration,omitempty"`
+	MeetingDuration           string              `json:"meetingDuration,omitempty"`
 	MaxCandidates             *int                `json:"maxCandidates,omitempty"`
 	IsOrganizerOptional       *bool               `json:"isOrganizerOptional,omitempty"`
 	MinimumAttendeePercentage *float64            `json:"minimumAttendeePercentage,omitempty"`
 	Attendees                 []Attendee          `json:"attendees,omitempty"`
 }
 
+// FormatMeetingDuration formats a duration in the ISO 8601 format expected by FindMeetingTimes, like "PT1H30M".
+func FormatMeetingDuration(d time.Duration) string {
+	return fmt.Sprintf("PT%dH%dM", int(d.Hours()), int(d.Minutes())%60)
+}
+
 type TimeConstraint struct {
 	ActivityDomain string     `json:"activityDomain,omitempty"`
 	TimeSlots      []TimeSlot `json:"timeSlots,omitempty"`
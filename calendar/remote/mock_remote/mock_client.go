@@ -80,6 +80,20 @@ func (mr *MockClientMockRecorder) CallJSON(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallJSON", reflect.TypeOf((*MockClient)(nil).CallJSON), arg0, arg1, arg2, arg3)
 }
 
+// CancelEvent mocks base method.
+func (m *MockClient) CancelEvent(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelEvent indicates an expected call of CancelEvent.
+func (mr *MockClientMockRecorder) CancelEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEvent", reflect.TypeOf((*MockClient)(nil).CancelEvent), arg0, arg1, arg2)
+}
+
 // CreateCalendar mocks base method.
 func (m *MockClient) CreateCalendar(arg0 string, arg1 *remote.Calendar) (*remote.Calendar, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +139,21 @@ func (mr *MockClientMockRecorder) CreateMySubscription(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMySubscription", reflect.TypeOf((*MockClient)(nil).CreateMySubscription), arg0, arg1)
 }
 
+// CreateSharedCalendarSubscription mocks base method.
+func (m *MockClient) CreateSharedCalendarSubscription(arg0, arg1 string) (*remote.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSharedCalendarSubscription", arg0, arg1)
+	ret0, _ := ret[0].(*remote.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSharedCalendarSubscription indicates an expected call of CreateSharedCalendarSubscription.
+func (mr *MockClientMockRecorder) CreateSharedCalendarSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSharedCalendarSubscription", reflect.TypeOf((*MockClient)(nil).CreateSharedCalendarSubscription), arg0, arg1)
+}
+
 // DeclineEvent mocks base method.
 func (m *MockClient) DeclineEvent(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -153,6 +182,20 @@ func (mr *MockClientMockRecorder) DeleteCalendar(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendar", reflect.TypeOf((*MockClient)(nil).DeleteCalendar), arg0, arg1)
 }
 
+// DeleteEvent mocks base method.
+func (m *MockClient) DeleteEvent(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEvent indicates an expected call of DeleteEvent.
+func (mr *MockClientMockRecorder) DeleteEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockClient)(nil).DeleteEvent), arg0, arg1)
+}
+
 // DeleteSubscription mocks base method.
 func (m *MockClient) DeleteSubscription(arg0 *remote.Subscription) error {
 	m.ctrl.T.Helper()
@@ -302,6 +345,36 @@ func (mr *MockClientMockRecorder) GetNotificationData(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationData", reflect.TypeOf((*MockClient)(nil).GetNotificationData), arg0)
 }
 
+// GetSchedule mocks base method.
+func (m *MockClient) GetSchedule(arg0 []*remote.ScheduleUserInfo, arg1, arg2 *remote.DateTime, arg3 int) ([]*remote.ScheduleInformation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*remote.ScheduleInformation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockClientMockRecorder) GetSchedule(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockClient)(nil).GetSchedule), arg0, arg1, arg2, arg3)
+}
+
+// GetSharedCalendarView mocks base method.
+func (m *MockClient) GetSharedCalendarView(arg0 string, arg1, arg2 time.Time) ([]*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedCalendarView", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedCalendarView indicates an expected call of GetSharedCalendarView.
+func (mr *MockClientMockRecorder) GetSharedCalendarView(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedCalendarView", reflect.TypeOf((*MockClient)(nil).GetSharedCalendarView), arg0, arg1, arg2)
+}
+
 // GetSuperuserToken mocks base method.
 func (m *MockClient) GetSuperuserToken() (string, error) {
 	m.ctrl.T.Helper()
@@ -332,6 +405,20 @@ func (mr *MockClientMockRecorder) ListSubscriptions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockClient)(nil).ListSubscriptions))
 }
 
+// ProposeNewTime mocks base method.
+func (m *MockClient) ProposeNewTime(arg0, arg1, arg2 string, arg3 *remote.TimeSlot, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeNewTime", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProposeNewTime indicates an expected call of ProposeNewTime.
+func (mr *MockClientMockRecorder) ProposeNewTime(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeNewTime", reflect.TypeOf((*MockClient)(nil).ProposeNewTime), arg0, arg1, arg2, arg3, arg4)
+}
+
 // RenewSubscription mocks base method.
 func (m *MockClient) RenewSubscription(arg0, arg1 string, arg2 *remote.Subscription) (*remote.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -347,6 +434,20 @@ func (mr *MockClientMockRecorder) RenewSubscription(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewSubscription", reflect.TypeOf((*MockClient)(nil).RenewSubscription), arg0, arg1, arg2)
 }
 
+// SendMail mocks base method.
+func (m *MockClient) SendMail(arg0 string, arg1 *remote.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMail indicates an expected call of SendMail.
+func (mr *MockClientMockRecorder) SendMail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMail", reflect.TypeOf((*MockClient)(nil).SendMail), arg0, arg1)
+}
+
 // TentativelyAcceptEvent mocks base method.
 func (m *MockClient) TentativelyAcceptEvent(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -360,3 +461,18 @@ func (mr *MockClientMockRecorder) TentativelyAcceptEvent(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TentativelyAcceptEvent", reflect.TypeOf((*MockClient)(nil).TentativelyAcceptEvent), arg0, arg1)
 }
+
+// UpdateEvent mocks base method.
+func (m *MockClient) UpdateEvent(arg0, arg1 string, arg2 *remote.Event) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEvent indicates an expected call of UpdateEvent.
+func (mr *MockClientMockRecorder) UpdateEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockClient)(nil).UpdateEvent), arg0, arg1, arg2)
+}
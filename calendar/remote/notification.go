@@ -3,6 +3,12 @@
 
 package remote
 
+const (
+	ChangeTypeCreated = "created"
+	ChangeTypeUpdated = "updated"
+	ChangeTypeDeleted = "deleted"
+)
+
 type Notification struct {
 	Webhook interface{}
 
@@ -15,7 +21,7 @@ type Notification struct {
 	// persistent secret.
 	ClientState string
 
-	// Notification type
+	// Notification type, one of the ChangeType values
 	ChangeType string
 
 	// The (remote) subscription ID the notification is for
@@ -0,0 +1,122 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package remote
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	EventTypeSingleInstance = "singleInstance"
+	EventTypeOccurrence     = "occurrence"
+	EventTypeException      = "exception"
+	EventTypeSeriesMaster   = "seriesMaster"
+)
+
+const (
+	RecurrencePatternDaily           = "daily"
+	RecurrencePatternWeekly          = "weekly"
+	RecurrencePatternAbsoluteMonthly = "absoluteMonthly"
+	RecurrencePatternRelativeMonthly = "relativeMonthly"
+	RecurrencePatternAbsoluteYearly  = "absoluteYearly"
+	RecurrencePatternRelativeYearly  = "relativeYearly"
+)
+
+const (
+	RecurrenceRangeEndDate  = "endDate"
+	RecurrenceRangeNoEnd    = "noEnd"
+	RecurrenceRangeNumbered = "numbered"
+)
+
+type PatternedRecurrence struct {
+	Pattern *RecurrencePattern `json:"pattern,omitempty"`
+	Range   *RecurrenceRange   `json:"range,omitempty"`
+}
+
+type RecurrencePattern struct {
+	Type           string   `json:"type"`
+	Interval       int      `json:"interval"`
+	Month          int      `json:"month,omitempty"`
+	DayOfMonth     int      `json:"dayOfMonth,omitempty"`
+	DaysOfWeek     []string `json:"daysOfWeek,omitempty"`
+	FirstDayOfWeek string   `json:"firstDayOfWeek,omitempty"`
+	Index          string   `json:"index,omitempty"`
+}
+
+type RecurrenceRange struct {
+	Type                string `json:"type"`
+	StartDate           string `json:"startDate,omitempty"`
+	EndDate             string `json:"endDate,omitempty"`
+	RecurrenceTimeZone  string `json:"recurrenceTimeZone,omitempty"`
+	NumberOfOccurrences int    `json:"numberOfOccurrences,omitempty"`
+}
+
+// IsRecurring returns true if the event is a series, or an occurrence of a series.
+func (e Event) IsRecurring() bool {
+	return e.Recurrence != nil || e.Type == EventTypeSeriesMaster || e.Type == EventTypeOccurrence || e.Type == EventTypeException
+}
+
+// String returns a human readable description of the recurrence, like "Every 2 weeks on Monday, until 2024-06-30".
+func (r PatternedRecurrence) String() string {
+	if r.Pattern == nil {
+		return ""
+	}
+
+	desc := r.Pattern.String()
+	if r.Range == nil {
+		return desc
+	}
+
+	switch r.Range.Type {
+	case RecurrenceRangeEndDate:
+		desc += ", until " + r.Range.EndDate
+	case RecurrenceRangeNumbered:
+		desc += fmt.Sprintf(", %d times", r.Range.NumberOfOccurrences)
+	}
+
+	return desc
+}
+
+func (p RecurrencePattern) String() string {
+	every := func(unit string) string {
+		if p.Interval > 1 {
+			return fmt.Sprintf("Every %d %ss", p.Interval, unit)
+		}
+		return "Every " + unit
+	}
+
+	relativeDay := func() string {
+		return fmt.Sprintf("the %s %s", p.Index, formatDaysOfWeek(p.DaysOfWeek))
+	}
+
+	switch p.Type {
+	case RecurrencePatternDaily:
+		return every("day")
+	case RecurrencePatternWeekly:
+		return every("week") + " on " + formatDaysOfWeek(p.DaysOfWeek)
+	case RecurrencePatternAbsoluteMonthly:
+		return every("month") + fmt.Sprintf(" on day %d", p.DayOfMonth)
+	case RecurrencePatternRelativeMonthly:
+		return every("month") + " on " + relativeDay()
+	case RecurrencePatternAbsoluteYearly:
+		return every("year") + fmt.Sprintf(" on %s %d", time.Month(p.Month), p.DayOfMonth)
+	case RecurrencePatternRelativeYearly:
+		return every("year") + fmt.Sprintf(" on %s of %s", relativeDay(), time.Month(p.Month))
+	}
+
+	return "Recurring"
+}
+
+func formatDaysOfWeek(days []string) string {
+	formatted := make([]string, 0, len(days))
+	for _, d := range days {
+		if d == "" {
+			continue
+		}
+		formatted = append(formatted, strings.ToUpper(d[:1])+d[1:])
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -3,6 +3,14 @@
 
 package remote
 
+import "time"
+
+const (
+	AutomaticRepliesStatusDisabled      = "disabled"
+	AutomaticRepliesStatusAlwaysEnabled = "alwaysEnabled"
+	AutomaticRepliesStatusScheduled     = "scheduled"
+)
+
 type User struct {
 	ID                string `json:"id"`
 	DisplayName       string `json:"displayName,omitempty"`
@@ -19,7 +27,34 @@ type WorkingHours struct {
 	DaysOfWeek []string `json:"daysOfWeek"`
 }
 
+type AutomaticRepliesSetting struct {
+	ScheduledStartDateTime *DateTime `json:"scheduledStartDateTime,omitempty"`
+	ScheduledEndDateTime   *DateTime `json:"scheduledEndDateTime,omitempty"`
+	Status                 string    `json:"status"`
+}
+
 type MailboxSettings struct {
-	TimeZone     string       `json:"timeZone"`
-	WorkingHours WorkingHours `json:"workingHours"`
+	TimeZone                string                  `json:"timeZone"`
+	WorkingHours            WorkingHours            `json:"workingHours"`
+	AutomaticRepliesSetting AutomaticRepliesSetting `json:"automaticRepliesSetting"`
+}
+
+// ActiveAt returns whether the automatic replies are on at the given time, and the time they are scheduled
+// to be turned off, which is zero when they are not scheduled.
+func (s AutomaticRepliesSetting) ActiveAt(t time.Time) (bool, time.Time) {
+	switch s.Status {
+	case AutomaticRepliesStatusAlwaysEnabled:
+		return true, time.Time{}
+	case AutomaticRepliesStatusScheduled:
+		if s.ScheduledStartDateTime == nil || s.ScheduledEndDateTime == nil {
+			return false, time.Time{}
+		}
+		start := s.ScheduledStartDateTime.Time()
+		end := s.ScheduledEndDateTime.Time()
+		if t.Before(start) || !t.Before(end) {
+			return false, time.Time{}
+		}
+		return true, end
+	}
+	return false, time.Time{}
 }
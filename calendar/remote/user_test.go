@@ -0,0 +1,58 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomaticRepliesSetting_ActiveAt(t *testing.T) {
+	start := time.Date(2024, time.May, 13, 8, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.May, 17, 18, 0, 0, 0, time.UTC)
+	scheduled := AutomaticRepliesSetting{
+		Status:                 AutomaticRepliesStatusScheduled,
+		ScheduledStartDateTime: NewDateTime(start, "UTC"),
+		ScheduledEndDateTime:   NewDateTime(end, "UTC"),
+	}
+
+	for name, tc := range map[string]struct {
+		setting       AutomaticRepliesSetting
+		at            time.Time
+		expected      bool
+		expectedUntil time.Time
+	}{
+		"disabled": {
+			setting: AutomaticRepliesSetting{Status: AutomaticRepliesStatusDisabled},
+			at:      start,
+		},
+		"always enabled": {
+			setting:  AutomaticRepliesSetting{Status: AutomaticRepliesStatusAlwaysEnabled},
+			at:       start,
+			expected: true,
+		},
+		"scheduled, before the start": {
+			setting: scheduled,
+			at:      start.Add(-time.Minute),
+		},
+		"scheduled, during the schedule": {
+			setting:       scheduled,
+			at:            start,
+			expected:      true,
+			expectedUntil: end,
+		},
+		"scheduled, at the end": {
+			setting: scheduled,
+			at:      end,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			active, until := tc.setting.ActiveAt(tc.at)
+			require.Equal(t, tc.expected, active)
+			require.True(t, tc.expectedUntil.Equal(until))
+		})
+	}
+}
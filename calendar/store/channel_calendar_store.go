@@ -0,0 +1,160 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+const channelCalendarIndexKey = "index"
+
+// ChannelCalendarStore keeps the shared calendars and group mailboxes whose events are posted in channels.
+type ChannelCalendarStore interface {
+	LoadChannelCalendar(channelID string) (*ChannelCalendar, error)
+	// StoreChannelCalendar stores the calendar of the channel, and adds the channel to the index.
+	StoreChannelCalendar(channelCalendar *ChannelCalendar) error
+	// DeleteChannelCalendar deletes the calendar of the channel, and removes the channel from the index.
+	DeleteChannelCalendar(channelID string) error
+	// LoadChannelCalendarIndex returns the IDs of the channels subscribed to a calendar.
+	LoadChannelCalendarIndex() ([]string, error)
+	// StoreChannelSubscription stores the subscription to the calendar of the channel, replacing the previous one.
+	StoreChannelSubscription(channelCalendar *ChannelCalendar, subscription *Subscription) error
+
+	LoadChannelEvent(channelID, eventID string) (*Event, error)
+	StoreChannelEvent(channelID string, event *Event) error
+	DeleteChannelEvent(channelID, eventID string) error
+}
+
+// ChannelCalendar is a shared calendar or group mailbox whose new, changed and cancelled events are posted in a
+// channel, with a daily agenda. The calendar is read with the credentials of the user who subscribed the channel.
+type ChannelCalendar struct {
+	ChannelID           string `json:"channel_id"`
+	CalendarAddress     string `json:"calendar_address"`
+	MattermostCreatorID string `json:"mm_creator_id"`
+	SubscriptionID      string `json:"subscription_id"`
+	// AgendaTime is the time of the daily agenda in Kitchen format, i.e. 8:30AM, empty when there is no agenda.
+	AgendaTime         string `json:"agenda_time,omitempty"`
+	Timezone           string `json:"tz"`
+	LastAgendaPostTime string `json:"last_agenda_post_time,omitempty"`
+}
+
+func channelEventKey(channelID, eventID string) string { return "channel_" + channelID + "_" + eventID }
+
+func (s *pluginStore) LoadChannelCalendar(channelID string) (*ChannelCalendar, error) {
+	channelCalendar := ChannelCalendar{}
+	err := kvstore.LoadJSON(s.channelCalendarKV, channelID, &channelCalendar)
+	if err != nil {
+		return nil, err
+	}
+	return &channelCalendar, nil
+}
+
+func (s *pluginStore) StoreChannelCalendar(channelCalendar *ChannelCalendar) error {
+	err := kvstore.StoreJSON(s.channelCalendarKV, channelCalendar.ChannelID, channelCalendar)
+	if err != nil {
+		return err
+	}
+
+	return s.modifyChannelCalendarIndex(func(channelIDs []string) []string {
+		for _, id := range channelIDs {
+			if id == channelCalendar.ChannelID {
+				return channelIDs
+			}
+		}
+		return append(channelIDs, channelCalendar.ChannelID)
+	})
+}
+
+func (s *pluginStore) DeleteChannelCalendar(channelID string) error {
+	err := s.channelCalendarKV.Delete(channelID)
+	if err != nil {
+		return err
+	}
+
+	return s.modifyChannelCalendarIndex(func(channelIDs []string) []string {
+		result := []string{}
+		for _, id := range channelIDs {
+			if id != channelID {
+				result = append(result, id)
+			}
+		}
+		return result
+	})
+}
+
+func (s *pluginStore) LoadChannelCalendarIndex() ([]string, error) {
+	channelIDs := []string{}
+	err := kvstore.LoadJSON(s.channelCalendarKV, channelCalendarIndexKey, &channelIDs)
+	if err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	return channelIDs, nil
+}
+
+func (s *pluginStore) StoreChannelSubscription(channelCalendar *ChannelCalendar, subscription *Subscription) error {
+	if subscription.ChannelID != channelCalendar.ChannelID {
+		return errors.Errorf("channel %q does not match the subscription channel %q",
+			channelCalendar.ChannelID, subscription.ChannelID)
+	}
+	err := kvstore.StoreJSON(s.subscriptionKV, subscription.Remote.ID, subscription)
+	if err != nil {
+		return err
+	}
+	channelCalendar.SubscriptionID = subscription.Remote.ID
+	err = s.StoreChannelCalendar(channelCalendar)
+	if err != nil {
+		return err
+	}
+
+	s.Logger.With(bot.LogContext{
+		"channelID":      channelCalendar.ChannelID,
+		"subscriptionID": subscription.Remote.ID,
+	}).Debugf("store: stored channel subscription.")
+	return nil
+}
+
+func (s *pluginStore) LoadChannelEvent(channelID, eventID string) (*Event, error) {
+	event := Event{}
+	err := kvstore.LoadJSON(s.eventKV, channelEventKey(channelID, eventID), &event)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (s *pluginStore) StoreChannelEvent(channelID string, event *Event) error {
+	_, err := s.storeEvent(channelEventKey(channelID, event.Remote.ID), event)
+	return err
+}
+
+func (s *pluginStore) DeleteChannelEvent(channelID, eventID string) error {
+	return s.eventKV.Delete(channelEventKey(channelID, eventID))
+}
+
+func (s *pluginStore) modifyChannelCalendarIndex(modify func(channelIDs []string) []string) error {
+	err := kvstore.AtomicModify(s.channelCalendarKV, channelCalendarIndexKey, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		var channelIDs []string
+		if len(initial) > 0 {
+			err := json.Unmarshal(initial, &channelIDs)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return json.Marshal(modify(channelIDs))
+	})
+	if err != nil {
+		return errors.Wrap(err, "error modifying channel calendar index")
+	}
+	return nil
+}
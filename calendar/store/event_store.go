@@ -73,7 +73,10 @@ func (s *pluginStore) AddLinkedChannelToEvent(eventID, channelID string) error {
 
 func (s *pluginStore) DeleteLinkedChannelFromEvent(eventID, channelID string) error {
 	eventMeta, err := s.LoadEventMetadata(eventID)
-	if err != nil && !errors.Is(err, ErrNotFound) {
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
 		return err
 	}
 
@@ -104,33 +107,43 @@ func (s *pluginStore) DeleteEventMetadata(eventID string) error {
 }
 
 func (s *pluginStore) StoreUserEvent(mattermostUserID string, event *Event) error {
+	end, err := s.storeEvent(eventKey(mattermostUserID, event.Remote.ICalUID), event)
+	if err != nil || end.IsZero() {
+		return err
+	}
+
+	s.Logger.With(bot.LogContext{
+		"mattermostUserID": mattermostUserID,
+		"eventID":          event.Remote.ID,
+		"expires":          end.String(),
+	}).Debugf("store: stored user event.")
+
+	return nil
+}
+
+// storeEvent stores the event under the key until ttlAfterEventEnd after its end, and returns the expiration
+// time. Events that are already expired are not stored, and the returned time is zero.
+func (s *pluginStore) storeEvent(key string, event *Event) (time.Time, error) {
 	now := time.Now()
 	end := now.Add(defaultEventTTL)
 	if event.Remote.End != nil {
 		end = event.Remote.End.Time().Add(ttlAfterEventEnd)
 		if end.Before(now) {
 			// no point storing expired keys
-			return nil
+			return time.Time{}, nil
 		}
 	}
 
 	ttl := int64(end.Sub(now).Seconds())
 	data, err := json.Marshal(event)
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
-	err = s.eventKV.StoreTTL(eventKey(mattermostUserID, event.Remote.ICalUID), data, ttl)
+	err = s.eventKV.StoreTTL(key, data, ttl)
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
-
-	s.Logger.With(bot.LogContext{
-		"mattermostUserID": mattermostUserID,
-		"eventID":          event.Remote.ID,
-		"expires":          end.String(),
-	}).Debugf("store: stored user event.")
-
-	return nil
+	return end, nil
 }
 
 func (s *pluginStore) DeleteUserEvent(mattermostUserID, eventID string) error {
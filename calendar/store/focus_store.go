@@ -0,0 +1,265 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+const (
+	focusBlockKeyPrefix = "block_"
+	focusBlockUsersKey  = "block_users"
+)
+
+// FocusStore keeps the focus blocks of the users, during which the notifications of the plugin are held back
+// to be delivered at the end of the block. The block of each user is stored under its own key, with an index of
+// the users in a block.
+type FocusStore interface {
+	// StoreFocusBlock starts the focus block of the user, replacing any previous one.
+	StoreFocusBlock(block *FocusBlock) error
+	// LoadFocusBlock returns the focus block of the user, or nil if there is none.
+	LoadFocusBlock(mattermostUserID string) (*FocusBlock, error)
+	// LoadFocusBlockUsers returns the users with a focus block.
+	LoadFocusBlockUsers() ([]string, error)
+	// PopEndedFocusBlocks removes the focus blocks ended at the given time, and returns them.
+	PopEndedFocusBlocks(now time.Time) ([]*FocusBlock, error)
+	// PopFocusBlock removes the focus block of the user, and returns it, or nil if there is none.
+	PopFocusBlock(mattermostUserID string) (*FocusBlock, error)
+	// HoldNotification keeps a notification to be delivered at the end of the focus block of the user.
+	HoldNotification(mattermostUserID string, notification *HeldNotification) error
+	// PopHeldNotifications removes the notifications held for the user, and returns them.
+	PopHeldNotifications(mattermostUserID string) ([]*HeldNotification, error)
+}
+
+// FocusBlock is a period of time during which the user is busy in their calendar, with the DND status.
+type FocusBlock struct {
+	End              time.Time `json:"end"`
+	MattermostUserID string    `json:"mm_id"`
+	EventID          string    `json:"event_id"`
+	Subject          string    `json:"subject"`
+	// PreviousStatus is the status to restore at the end of the block, empty if the status was not changed.
+	PreviousStatus string `json:"previous_status,omitempty"`
+}
+
+// IsActive returns whether the focus block is not over at the given time.
+func (block *FocusBlock) IsActive(now time.Time) bool {
+	return block != nil && block.End.After(now)
+}
+
+// HeldNotification is a direct message of the plugin held back during a focus block.
+type HeldNotification struct {
+	Time        time.Time                `json:"time"`
+	Attachments []*model.SlackAttachment `json:"attachments"`
+}
+
+type HeldNotifications []*HeldNotification
+
+func focusBlockKey(mattermostUserID string) string {
+	return focusBlockKeyPrefix + mattermostUserID
+}
+
+func (s *pluginStore) StoreFocusBlock(block *FocusBlock) error {
+	err := kvstore.StoreJSON(s.focusKV, focusBlockKey(block.MattermostUserID), block)
+	if err != nil {
+		return errors.Wrap(err, "error storing focus block")
+	}
+
+	// The user is added to the index after their block, so that a concurrent cleanup of the index sees it
+	return s.addFocusBlockUser(block.MattermostUserID)
+}
+
+func (s *pluginStore) LoadFocusBlock(mattermostUserID string) (*FocusBlock, error) {
+	block := &FocusBlock{}
+	err := kvstore.LoadJSON(s.focusKV, focusBlockKey(mattermostUserID), block)
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading focus block")
+	}
+	return block, nil
+}
+
+func (s *pluginStore) LoadFocusBlockUsers() ([]string, error) {
+	userIDs := []string{}
+	err := kvstore.LoadJSON(s.focusKV, focusBlockUsersKey, &userIDs)
+	if err != nil && err != ErrNotFound {
+		return nil, errors.Wrap(err, "error loading the users with a focus block")
+	}
+	return userIDs, nil
+}
+
+func (s *pluginStore) PopEndedFocusBlocks(now time.Time) ([]*FocusBlock, error) {
+	userIDs, err := s.LoadFocusBlockUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	ended := []*FocusBlock{}
+	for _, mattermostUserID := range userIDs {
+		block, err := s.popFocusBlock(mattermostUserID, func(block *FocusBlock) bool {
+			return !block.IsActive(now)
+		})
+		if err != nil {
+			s.Logger.Warnf("Error removing the focus block of user %s. err=%v", mattermostUserID, err)
+			continue
+		}
+		if block != nil {
+			ended = append(ended, block)
+		}
+	}
+	return ended, nil
+}
+
+func (s *pluginStore) PopFocusBlock(mattermostUserID string) (*FocusBlock, error) {
+	return s.popFocusBlock(mattermostUserID, func(*FocusBlock) bool {
+		return true
+	})
+}
+
+// popFocusBlock removes the focus block of the user if it matches, and returns it. The user is removed from the
+// index once they have no block.
+func (s *pluginStore) popFocusBlock(mattermostUserID string, match func(block *FocusBlock) bool) (*FocusBlock, error) {
+	var popped *FocusBlock
+	left := false
+	err := kvstore.AtomicModify(s.focusKV, focusBlockKey(mattermostUserID), func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		popped, left = nil, false
+		if len(initial) == 0 {
+			return nil, nil
+		}
+		block := &FocusBlock{}
+		err := json.Unmarshal(initial, block)
+		if err != nil {
+			return nil, err
+		}
+		if !match(block) {
+			left = true
+			return initial, nil
+		}
+
+		popped = block
+		return nil, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error removing focus block")
+	}
+	if left {
+		return nil, nil
+	}
+
+	err = s.modifyFocusBlockUsers(func(userIDs []string) []string {
+		result := []string{}
+		for _, id := range userIDs {
+			if id != mattermostUserID {
+				result = append(result, id)
+			}
+		}
+		return result
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A block started meanwhile may have found the user in the index before their removal
+	block, err := s.LoadFocusBlock(mattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+	if block != nil {
+		err = s.addFocusBlockUser(mattermostUserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return popped, nil
+}
+
+func (s *pluginStore) addFocusBlockUser(mattermostUserID string) error {
+	return s.modifyFocusBlockUsers(func(userIDs []string) []string {
+		for _, id := range userIDs {
+			if id == mattermostUserID {
+				return userIDs
+			}
+		}
+		return append(userIDs, mattermostUserID)
+	})
+}
+
+func (s *pluginStore) HoldNotification(mattermostUserID string, notification *HeldNotification) error {
+	err := kvstore.AtomicModify(s.focusKV, mattermostUserID, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		var notifications HeldNotifications
+		if len(initial) > 0 {
+			err := json.Unmarshal(initial, &notifications)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return json.Marshal(append(notifications, notification))
+	})
+	if err != nil {
+		return errors.Wrap(err, "error holding notification")
+	}
+	return nil
+}
+
+func (s *pluginStore) PopHeldNotifications(mattermostUserID string) ([]*HeldNotification, error) {
+	var notifications HeldNotifications
+	err := kvstore.AtomicModify(s.focusKV, mattermostUserID, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		notifications = nil
+		if len(initial) > 0 {
+			err := json.Unmarshal(initial, &notifications)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting held notifications")
+	}
+	return notifications, nil
+}
+
+func (s *pluginStore) modifyFocusBlockUsers(modify func(userIDs []string) []string) error {
+	err := kvstore.AtomicModify(s.focusKV, focusBlockUsersKey, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		var userIDs []string
+		if len(initial) > 0 {
+			err := json.Unmarshal(initial, &userIDs)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return json.Marshal(modify(userIDs))
+	})
+	if err != nil {
+		return errors.Wrap(err, "error modifying the users with a focus block")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFocusBlocks(t *testing.T) {
+	now := time.Date(2024, time.May, 16, 10, 0, 0, 0, time.UTC)
+	s := newTestStore(newTestKV())
+
+	require.NoError(t, s.StoreFocusBlock(&FocusBlock{MattermostUserID: "user_1", EventID: "event_1", End: now.Add(-time.Minute)}))
+	require.NoError(t, s.StoreFocusBlock(&FocusBlock{MattermostUserID: "user_2", EventID: "event_2", End: now.Add(time.Hour)}))
+
+	t.Run("the blocks are kept per user", func(t *testing.T) {
+		users, err := s.LoadFocusBlockUsers()
+		require.NoError(t, err)
+		require.Equal(t, []string{"user_1", "user_2"}, users)
+
+		block, err := s.LoadFocusBlock("user_2")
+		require.NoError(t, err)
+		require.Equal(t, "event_2", block.EventID)
+		require.True(t, block.IsActive(now))
+
+		block, err = s.LoadFocusBlock("user_3")
+		require.NoError(t, err)
+		require.Nil(t, block)
+		require.False(t, block.IsActive(now))
+	})
+
+	t.Run("only the ended blocks are popped", func(t *testing.T) {
+		ended, err := s.PopEndedFocusBlocks(now)
+		require.NoError(t, err)
+		require.Len(t, ended, 1)
+		require.Equal(t, "user_1", ended[0].MattermostUserID)
+
+		users, err := s.LoadFocusBlockUsers()
+		require.NoError(t, err)
+		require.Equal(t, []string{"user_2"}, users)
+	})
+
+	t.Run("popping the block of the user forgets them", func(t *testing.T) {
+		block, err := s.PopFocusBlock("user_2")
+		require.NoError(t, err)
+		require.Equal(t, "event_2", block.EventID)
+
+		block, err = s.PopFocusBlock("user_2")
+		require.NoError(t, err)
+		require.Nil(t, block)
+
+		users, err := s.LoadFocusBlockUsers()
+		require.NoError(t, err)
+		require.Empty(t, users)
+	})
+}
+
+func TestHeldNotifications(t *testing.T) {
+	now := time.Date(2024, time.May, 16, 10, 0, 0, 0, time.UTC)
+	s := newTestStore(newTestKV())
+
+	require.NoError(t, s.HoldNotification("user_1", &HeldNotification{Time: now}))
+	require.NoError(t, s.HoldNotification("user_1", &HeldNotification{Time: now.Add(time.Minute)}))
+
+	notifications, err := s.PopHeldNotifications("user_1")
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+
+	notifications, err = s.PopHeldNotifications("user_1")
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+// MailboxStore keeps the mailbox settings of the users for a while, so that the jobs running every few minutes
+// don't fetch them from the remote each time.
+type MailboxStore interface {
+	// LoadMailboxSettings returns the mailbox settings of the user, or ErrNotFound once they expired.
+	LoadMailboxSettings(mattermostUserID string) (*remote.MailboxSettings, error)
+	StoreMailboxSettings(mattermostUserID string, settings *remote.MailboxSettings, ttl time.Duration) error
+}
+
+func (s *pluginStore) LoadMailboxSettings(mattermostUserID string) (*remote.MailboxSettings, error) {
+	settings := &remote.MailboxSettings{}
+	err := kvstore.LoadJSON(s.mailboxKV, mattermostUserID, settings)
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (s *pluginStore) StoreMailboxSettings(mattermostUserID string, settings *remote.MailboxSettings, ttl time.Duration) error {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	err = s.mailboxKV.StoreTTL(mattermostUserID, data, int64(ttl/time.Second))
+	if err != nil {
+		return errors.Wrap(err, "error storing mailbox settings")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestMailboxSettings(t *testing.T) {
+	s := newTestStore(newTestKV())
+
+	_, err := s.LoadMailboxSettings("user_1")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	settings := &remote.MailboxSettings{
+		TimeZone: "Pacific Standard Time",
+		WorkingHours: remote.WorkingHours{
+			DaysOfWeek: []string{"monday", "tuesday"},
+			StartTime:  "09:00:00.0000000",
+			EndTime:    "17:00:00.0000000",
+		},
+		AutomaticRepliesSetting: remote.AutomaticRepliesSetting{Status: remote.AutomaticRepliesStatusAlwaysEnabled},
+	}
+	require.NoError(t, s.StoreMailboxSettings("user_1", settings, 15*time.Minute))
+
+	loaded, err := s.LoadMailboxSettings("user_1")
+	require.NoError(t, err)
+	require.Equal(t, settings, loaded)
+}
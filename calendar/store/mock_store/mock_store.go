@@ -6,8 +6,10 @@ package mock_store
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	remote "github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 	store "github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
 )
 
@@ -48,6 +50,49 @@ func (mr *MockStoreMockRecorder) AddLinkedChannelToEvent(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLinkedChannelToEvent", reflect.TypeOf((*MockStore)(nil).AddLinkedChannelToEvent), arg0, arg1)
 }
 
+// ClaimReminder mocks base method.
+func (m *MockStore) ClaimReminder(arg0, arg1 string, arg2 time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReminder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimReminder indicates an expected call of ClaimReminder.
+func (mr *MockStoreMockRecorder) ClaimReminder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReminder", reflect.TypeOf((*MockStore)(nil).ClaimReminder), arg0, arg1, arg2)
+}
+
+// DeleteChannelCalendar mocks base method.
+func (m *MockStore) DeleteChannelCalendar(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannelCalendar", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChannelCalendar indicates an expected call of DeleteChannelCalendar.
+func (mr *MockStoreMockRecorder) DeleteChannelCalendar(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannelCalendar", reflect.TypeOf((*MockStore)(nil).DeleteChannelCalendar), arg0)
+}
+
+// DeleteChannelEvent mocks base method.
+func (m *MockStore) DeleteChannelEvent(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannelEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChannelEvent indicates an expected call of DeleteChannelEvent.
+func (mr *MockStoreMockRecorder) DeleteChannelEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannelEvent", reflect.TypeOf((*MockStore)(nil).DeleteChannelEvent), arg0, arg1)
+}
+
 // DeleteCurrentStep mocks base method.
 func (m *MockStore) DeleteCurrentStep(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -104,6 +149,20 @@ func (mr *MockStoreMockRecorder) DeletePanelPostID(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePanelPostID", reflect.TypeOf((*MockStore)(nil).DeletePanelPostID), arg0)
 }
 
+// DeleteSnoozedReminders mocks base method.
+func (m *MockStore) DeleteSnoozedReminders(arg0 string, arg1 []*store.SnoozedReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSnoozedReminders", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSnoozedReminders indicates an expected call of DeleteSnoozedReminders.
+func (mr *MockStoreMockRecorder) DeleteSnoozedReminders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSnoozedReminders", reflect.TypeOf((*MockStore)(nil).DeleteSnoozedReminders), arg0, arg1)
+}
+
 // DeleteUser mocks base method.
 func (m *MockStore) DeleteUser(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -146,6 +205,20 @@ func (mr *MockStoreMockRecorder) DeleteUserFromIndex(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserFromIndex", reflect.TypeOf((*MockStore)(nil).DeleteUserFromIndex), arg0)
 }
 
+// DeleteUserLinkedEvent mocks base method.
+func (m *MockStore) DeleteUserLinkedEvent(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserLinkedEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserLinkedEvent indicates an expected call of DeleteUserLinkedEvent.
+func (mr *MockStoreMockRecorder) DeleteUserLinkedEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserLinkedEvent", reflect.TypeOf((*MockStore)(nil).DeleteUserLinkedEvent), arg0, arg1, arg2)
+}
+
 // DeleteUserSubscription mocks base method.
 func (m *MockStore) DeleteUserSubscription(arg0 *store.User, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -175,6 +248,20 @@ func (mr *MockStoreMockRecorder) DeleteUserWelcomePost(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserWelcomePost", reflect.TypeOf((*MockStore)(nil).DeleteUserWelcomePost), arg0)
 }
 
+// ForEachUserIndexPage mocks base method.
+func (m *MockStore) ForEachUserIndexPage(arg0 func(store.UserIndex) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachUserIndexPage", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachUserIndexPage indicates an expected call of ForEachUserIndexPage.
+func (mr *MockStoreMockRecorder) ForEachUserIndexPage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachUserIndexPage", reflect.TypeOf((*MockStore)(nil).ForEachUserIndexPage), arg0)
+}
+
 // GetCurrentStep mocks base method.
 func (m *MockStore) GetCurrentStep(arg0 string) (int, error) {
 	m.ctrl.T.Helper()
@@ -235,6 +322,65 @@ func (mr *MockStoreMockRecorder) GetSetting(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetting", reflect.TypeOf((*MockStore)(nil).GetSetting), arg0, arg1)
 }
 
+// HoldNotification mocks base method.
+func (m *MockStore) HoldNotification(arg0 string, arg1 *store.HeldNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldNotification", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HoldNotification indicates an expected call of HoldNotification.
+func (mr *MockStoreMockRecorder) HoldNotification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldNotification", reflect.TypeOf((*MockStore)(nil).HoldNotification), arg0, arg1)
+}
+
+// LoadChannelCalendar mocks base method.
+func (m *MockStore) LoadChannelCalendar(arg0 string) (*store.ChannelCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadChannelCalendar", arg0)
+	ret0, _ := ret[0].(*store.ChannelCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadChannelCalendar indicates an expected call of LoadChannelCalendar.
+func (mr *MockStoreMockRecorder) LoadChannelCalendar(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadChannelCalendar", reflect.TypeOf((*MockStore)(nil).LoadChannelCalendar), arg0)
+}
+
+// LoadChannelCalendarIndex mocks base method.
+func (m *MockStore) LoadChannelCalendarIndex() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadChannelCalendarIndex")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadChannelCalendarIndex indicates an expected call of LoadChannelCalendarIndex.
+func (mr *MockStoreMockRecorder) LoadChannelCalendarIndex() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadChannelCalendarIndex", reflect.TypeOf((*MockStore)(nil).LoadChannelCalendarIndex))
+}
+
+// LoadChannelEvent mocks base method.
+func (m *MockStore) LoadChannelEvent(arg0, arg1 string) (*store.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadChannelEvent", arg0, arg1)
+	ret0, _ := ret[0].(*store.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadChannelEvent indicates an expected call of LoadChannelEvent.
+func (mr *MockStoreMockRecorder) LoadChannelEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadChannelEvent", reflect.TypeOf((*MockStore)(nil).LoadChannelEvent), arg0, arg1)
+}
+
 // LoadEventMetadata mocks base method.
 func (m *MockStore) LoadEventMetadata(arg0 string) (*store.EventMetadata, error) {
 	m.ctrl.T.Helper()
@@ -250,6 +396,51 @@ func (mr *MockStoreMockRecorder) LoadEventMetadata(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventMetadata", reflect.TypeOf((*MockStore)(nil).LoadEventMetadata), arg0)
 }
 
+// LoadFocusBlock mocks base method.
+func (m *MockStore) LoadFocusBlock(arg0 string) (*store.FocusBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFocusBlock", arg0)
+	ret0, _ := ret[0].(*store.FocusBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFocusBlock indicates an expected call of LoadFocusBlock.
+func (mr *MockStoreMockRecorder) LoadFocusBlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFocusBlock", reflect.TypeOf((*MockStore)(nil).LoadFocusBlock), arg0)
+}
+
+// LoadFocusBlockUsers mocks base method.
+func (m *MockStore) LoadFocusBlockUsers() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFocusBlockUsers")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFocusBlockUsers indicates an expected call of LoadFocusBlockUsers.
+func (mr *MockStoreMockRecorder) LoadFocusBlockUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFocusBlockUsers", reflect.TypeOf((*MockStore)(nil).LoadFocusBlockUsers))
+}
+
+// LoadMailboxSettings mocks base method.
+func (m *MockStore) LoadMailboxSettings(arg0 string) (*remote.MailboxSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadMailboxSettings", arg0)
+	ret0, _ := ret[0].(*remote.MailboxSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadMailboxSettings indicates an expected call of LoadMailboxSettings.
+func (mr *MockStoreMockRecorder) LoadMailboxSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadMailboxSettings", reflect.TypeOf((*MockStore)(nil).LoadMailboxSettings), arg0)
+}
+
 // LoadMattermostUserID mocks base method.
 func (m *MockStore) LoadMattermostUserID(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -265,6 +456,51 @@ func (mr *MockStoreMockRecorder) LoadMattermostUserID(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadMattermostUserID", reflect.TypeOf((*MockStore)(nil).LoadMattermostUserID), arg0)
 }
 
+// LoadSkippedStatusSyncUsers mocks base method.
+func (m *MockStore) LoadSkippedStatusSyncUsers() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSkippedStatusSyncUsers")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSkippedStatusSyncUsers indicates an expected call of LoadSkippedStatusSyncUsers.
+func (mr *MockStoreMockRecorder) LoadSkippedStatusSyncUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSkippedStatusSyncUsers", reflect.TypeOf((*MockStore)(nil).LoadSkippedStatusSyncUsers))
+}
+
+// LoadSnoozedReminderUsers mocks base method.
+func (m *MockStore) LoadSnoozedReminderUsers() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSnoozedReminderUsers")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSnoozedReminderUsers indicates an expected call of LoadSnoozedReminderUsers.
+func (mr *MockStoreMockRecorder) LoadSnoozedReminderUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSnoozedReminderUsers", reflect.TypeOf((*MockStore)(nil).LoadSnoozedReminderUsers))
+}
+
+// LoadSnoozedReminders mocks base method.
+func (m *MockStore) LoadSnoozedReminders(arg0 string) ([]*store.SnoozedReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSnoozedReminders", arg0)
+	ret0, _ := ret[0].([]*store.SnoozedReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSnoozedReminders indicates an expected call of LoadSnoozedReminders.
+func (mr *MockStoreMockRecorder) LoadSnoozedReminders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSnoozedReminders", reflect.TypeOf((*MockStore)(nil).LoadSnoozedReminders), arg0)
+}
+
 // LoadSubscription mocks base method.
 func (m *MockStore) LoadSubscription(arg0 string) (*store.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -355,18 +591,77 @@ func (mr *MockStoreMockRecorder) LoadUserWelcomePost(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserWelcomePost", reflect.TypeOf((*MockStore)(nil).LoadUserWelcomePost), arg0)
 }
 
-// ModifyUserIndex mocks base method.
-func (m *MockStore) ModifyUserIndex(arg0 func(store.UserIndex) (store.UserIndex, error)) error {
+// MigrateUserIndex mocks base method.
+func (m *MockStore) MigrateUserIndex() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateUserIndex")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MigrateUserIndex indicates an expected call of MigrateUserIndex.
+func (mr *MockStoreMockRecorder) MigrateUserIndex() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateUserIndex", reflect.TypeOf((*MockStore)(nil).MigrateUserIndex))
+}
+
+// PopEndedFocusBlocks mocks base method.
+func (m *MockStore) PopEndedFocusBlocks(arg0 time.Time) ([]*store.FocusBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopEndedFocusBlocks", arg0)
+	ret0, _ := ret[0].([]*store.FocusBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopEndedFocusBlocks indicates an expected call of PopEndedFocusBlocks.
+func (mr *MockStoreMockRecorder) PopEndedFocusBlocks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopEndedFocusBlocks", reflect.TypeOf((*MockStore)(nil).PopEndedFocusBlocks), arg0)
+}
+
+// PopFocusBlock mocks base method.
+func (m *MockStore) PopFocusBlock(arg0 string) (*store.FocusBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopFocusBlock", arg0)
+	ret0, _ := ret[0].(*store.FocusBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopFocusBlock indicates an expected call of PopFocusBlock.
+func (mr *MockStoreMockRecorder) PopFocusBlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopFocusBlock", reflect.TypeOf((*MockStore)(nil).PopFocusBlock), arg0)
+}
+
+// PopHeldNotifications mocks base method.
+func (m *MockStore) PopHeldNotifications(arg0 string) ([]*store.HeldNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopHeldNotifications", arg0)
+	ret0, _ := ret[0].([]*store.HeldNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopHeldNotifications indicates an expected call of PopHeldNotifications.
+func (mr *MockStoreMockRecorder) PopHeldNotifications(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopHeldNotifications", reflect.TypeOf((*MockStore)(nil).PopHeldNotifications), arg0)
+}
+
+// ReleaseReminder mocks base method.
+func (m *MockStore) ReleaseReminder(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ModifyUserIndex", arg0)
+	ret := m.ctrl.Call(m, "ReleaseReminder", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ModifyUserIndex indicates an expected call of ModifyUserIndex.
-func (mr *MockStoreMockRecorder) ModifyUserIndex(arg0 interface{}) *gomock.Call {
+// ReleaseReminder indicates an expected call of ReleaseReminder.
+func (mr *MockStoreMockRecorder) ReleaseReminder(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyUserIndex", reflect.TypeOf((*MockStore)(nil).ModifyUserIndex), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReminder", reflect.TypeOf((*MockStore)(nil).ReleaseReminder), arg0, arg1)
 }
 
 // RemovePostID mocks base method.
@@ -468,6 +763,62 @@ func (mr *MockStoreMockRecorder) SetSetting(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSetting", reflect.TypeOf((*MockStore)(nil).SetSetting), arg0, arg1, arg2)
 }
 
+// SnoozeReminder mocks base method.
+func (m *MockStore) SnoozeReminder(arg0 *store.SnoozedReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnoozeReminder", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SnoozeReminder indicates an expected call of SnoozeReminder.
+func (mr *MockStoreMockRecorder) SnoozeReminder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeReminder", reflect.TypeOf((*MockStore)(nil).SnoozeReminder), arg0)
+}
+
+// StoreChannelCalendar mocks base method.
+func (m *MockStore) StoreChannelCalendar(arg0 *store.ChannelCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreChannelCalendar", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreChannelCalendar indicates an expected call of StoreChannelCalendar.
+func (mr *MockStoreMockRecorder) StoreChannelCalendar(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreChannelCalendar", reflect.TypeOf((*MockStore)(nil).StoreChannelCalendar), arg0)
+}
+
+// StoreChannelEvent mocks base method.
+func (m *MockStore) StoreChannelEvent(arg0 string, arg1 *store.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreChannelEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreChannelEvent indicates an expected call of StoreChannelEvent.
+func (mr *MockStoreMockRecorder) StoreChannelEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreChannelEvent", reflect.TypeOf((*MockStore)(nil).StoreChannelEvent), arg0, arg1)
+}
+
+// StoreChannelSubscription mocks base method.
+func (m *MockStore) StoreChannelSubscription(arg0 *store.ChannelCalendar, arg1 *store.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreChannelSubscription", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreChannelSubscription indicates an expected call of StoreChannelSubscription.
+func (mr *MockStoreMockRecorder) StoreChannelSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreChannelSubscription", reflect.TypeOf((*MockStore)(nil).StoreChannelSubscription), arg0, arg1)
+}
+
 // StoreEventMetadata mocks base method.
 func (m *MockStore) StoreEventMetadata(arg0 string, arg1 *store.EventMetadata) error {
 	m.ctrl.T.Helper()
@@ -482,6 +833,34 @@ func (mr *MockStoreMockRecorder) StoreEventMetadata(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreEventMetadata", reflect.TypeOf((*MockStore)(nil).StoreEventMetadata), arg0, arg1)
 }
 
+// StoreFocusBlock mocks base method.
+func (m *MockStore) StoreFocusBlock(arg0 *store.FocusBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreFocusBlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreFocusBlock indicates an expected call of StoreFocusBlock.
+func (mr *MockStoreMockRecorder) StoreFocusBlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreFocusBlock", reflect.TypeOf((*MockStore)(nil).StoreFocusBlock), arg0)
+}
+
+// StoreMailboxSettings mocks base method.
+func (m *MockStore) StoreMailboxSettings(arg0 string, arg1 *remote.MailboxSettings, arg2 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreMailboxSettings", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreMailboxSettings indicates an expected call of StoreMailboxSettings.
+func (mr *MockStoreMockRecorder) StoreMailboxSettings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreMailboxSettings", reflect.TypeOf((*MockStore)(nil).StoreMailboxSettings), arg0, arg1, arg2)
+}
+
 // StoreOAuth2State mocks base method.
 func (m *MockStore) StoreOAuth2State(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -496,6 +875,20 @@ func (mr *MockStoreMockRecorder) StoreOAuth2State(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreOAuth2State", reflect.TypeOf((*MockStore)(nil).StoreOAuth2State), arg0)
 }
 
+// StoreSkippedStatusSyncUsers mocks base method.
+func (m *MockStore) StoreSkippedStatusSyncUsers(arg0 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSkippedStatusSyncUsers", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSkippedStatusSyncUsers indicates an expected call of StoreSkippedStatusSyncUsers.
+func (mr *MockStoreMockRecorder) StoreSkippedStatusSyncUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSkippedStatusSyncUsers", reflect.TypeOf((*MockStore)(nil).StoreSkippedStatusSyncUsers), arg0)
+}
+
 // StoreUser mocks base method.
 func (m *MockStore) StoreUser(arg0 *store.User) error {
 	m.ctrl.T.Helper()
@@ -524,6 +917,20 @@ func (mr *MockStoreMockRecorder) StoreUserActiveEvents(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserActiveEvents", reflect.TypeOf((*MockStore)(nil).StoreUserActiveEvents), arg0, arg1)
 }
 
+// StoreUserAutomaticStatus mocks base method.
+func (m *MockStore) StoreUserAutomaticStatus(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreUserAutomaticStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreUserAutomaticStatus indicates an expected call of StoreUserAutomaticStatus.
+func (mr *MockStoreMockRecorder) StoreUserAutomaticStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserAutomaticStatus", reflect.TypeOf((*MockStore)(nil).StoreUserAutomaticStatus), arg0, arg1)
+}
+
 // StoreUserCustomStatusUpdates mocks base method.
 func (m *MockStore) StoreUserCustomStatusUpdates(arg0 string, arg1 bool) error {
 	m.ctrl.T.Helper()
@@ -580,6 +987,20 @@ func (mr *MockStoreMockRecorder) StoreUserLinkedEvent(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserLinkedEvent", reflect.TypeOf((*MockStore)(nil).StoreUserLinkedEvent), arg0, arg1, arg2)
 }
 
+// StoreUserOutOfOfficeCustomStatus mocks base method.
+func (m *MockStore) StoreUserOutOfOfficeCustomStatus(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreUserOutOfOfficeCustomStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreUserOutOfOfficeCustomStatus indicates an expected call of StoreUserOutOfOfficeCustomStatus.
+func (mr *MockStoreMockRecorder) StoreUserOutOfOfficeCustomStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserOutOfOfficeCustomStatus", reflect.TypeOf((*MockStore)(nil).StoreUserOutOfOfficeCustomStatus), arg0, arg1)
+}
+
 // StoreUserSubscription mocks base method.
 func (m *MockStore) StoreUserSubscription(arg0 *store.User, arg1 *store.Subscription) error {
 	m.ctrl.T.Helper()
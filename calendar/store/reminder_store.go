@@ -0,0 +1,220 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+const (
+	snoozedReminderKeyPrefix = "snoozed_"
+	snoozedReminderUsersKey  = "snoozed_users"
+)
+
+// ReminderStore keeps track of the reminders already delivered, so that each of them is delivered once even
+// when several status sync jobs see the same upcoming event.
+type ReminderStore interface {
+	// ClaimReminder records the reminder as delivered for the given time, and returns false if it already was.
+	ClaimReminder(mattermostUserID, reminderID string, ttl time.Duration) (bool, error)
+	// ReleaseReminder forgets about a claimed reminder, so that it can be delivered again.
+	ReleaseReminder(mattermostUserID, reminderID string) error
+	// SnoozeReminder schedules the reminder of an event to be delivered again, replacing any previous schedule
+	// of the user for the same event.
+	SnoozeReminder(reminder *SnoozedReminder) error
+	// LoadSnoozedReminderUsers returns the users with snoozed reminders.
+	LoadSnoozedReminderUsers() ([]string, error)
+	// LoadSnoozedReminders returns the snoozed reminders of the user.
+	LoadSnoozedReminders(mattermostUserID string) ([]*SnoozedReminder, error)
+	// DeleteSnoozedReminders removes the delivered reminders of the user from the schedule. A reminder snoozed
+	// again since it was loaded is kept. The user is forgotten once they have no snoozed reminders left.
+	DeleteSnoozedReminders(mattermostUserID string, delivered []*SnoozedReminder) error
+}
+
+// SnoozedReminder is the reminder of an event to deliver again at a later time.
+type SnoozedReminder struct {
+	RemindAt         time.Time `json:"remind_at"`
+	MattermostUserID string    `json:"mm_id"`
+	EventID          string    `json:"event_id"`
+}
+
+type SnoozedReminders []*SnoozedReminder
+
+func reminderKey(mattermostUserID, reminderID string) string {
+	return mattermostUserID + "_" + reminderID
+}
+
+func (s *pluginStore) ClaimReminder(mattermostUserID, reminderID string, ttl time.Duration) (bool, error) {
+	// An atomic write with no old value only succeeds if the key does not exist yet
+	claimed, err := s.reminderKV.StoreWithOptions(reminderKey(mattermostUserID, reminderID), []byte("1"), model.PluginKVSetOptions{
+		Atomic:          true,
+		OldValue:        nil,
+		ExpireInSeconds: int64(ttl / time.Second),
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "error claiming reminder")
+	}
+	return claimed, nil
+}
+
+func (s *pluginStore) ReleaseReminder(mattermostUserID, reminderID string) error {
+	return s.reminderKV.Delete(reminderKey(mattermostUserID, reminderID))
+}
+
+func snoozedReminderKey(mattermostUserID string) string {
+	return snoozedReminderKeyPrefix + mattermostUserID
+}
+
+func (s *pluginStore) SnoozeReminder(reminder *SnoozedReminder) error {
+	err := s.modifySnoozedReminders(reminder.MattermostUserID, func(reminders SnoozedReminders) SnoozedReminders {
+		result := SnoozedReminders{}
+		for _, r := range reminders {
+			if r.EventID != reminder.EventID {
+				result = append(result, r)
+			}
+		}
+		return append(result, reminder)
+	})
+	if err != nil {
+		return err
+	}
+
+	// The user is added to the index after the reminder, so that a concurrent cleanup of the index sees it
+	return s.addSnoozedReminderUser(reminder.MattermostUserID)
+}
+
+func (s *pluginStore) LoadSnoozedReminderUsers() ([]string, error) {
+	userIDs := []string{}
+	err := kvstore.LoadJSON(s.reminderKV, snoozedReminderUsersKey, &userIDs)
+	if err != nil && err != ErrNotFound {
+		return nil, errors.Wrap(err, "error loading the users with snoozed reminders")
+	}
+	return userIDs, nil
+}
+
+func (s *pluginStore) LoadSnoozedReminders(mattermostUserID string) ([]*SnoozedReminder, error) {
+	reminders := SnoozedReminders{}
+	err := kvstore.LoadJSON(s.reminderKV, snoozedReminderKey(mattermostUserID), &reminders)
+	if err != nil && err != ErrNotFound {
+		return nil, errors.Wrap(err, "error loading snoozed reminders")
+	}
+	return reminders, nil
+}
+
+func (s *pluginStore) DeleteSnoozedReminders(mattermostUserID string, delivered []*SnoozedReminder) error {
+	left := 0
+	err := s.modifySnoozedReminders(mattermostUserID, func(reminders SnoozedReminders) SnoozedReminders {
+		result := SnoozedReminders{}
+		for _, r := range reminders {
+			if !containsSnoozedReminder(delivered, r) {
+				result = append(result, r)
+			}
+		}
+		left = len(result)
+		if left == 0 {
+			return nil
+		}
+		return result
+	})
+	if err != nil || left > 0 {
+		return err
+	}
+
+	err = s.removeSnoozedReminderUser(mattermostUserID)
+	if err != nil {
+		return err
+	}
+
+	// A reminder snoozed meanwhile may have found the user in the index before their removal
+	reminders, err := s.LoadSnoozedReminders(mattermostUserID)
+	if err != nil || len(reminders) == 0 {
+		return err
+	}
+	return s.addSnoozedReminderUser(mattermostUserID)
+}
+
+func (s *pluginStore) addSnoozedReminderUser(mattermostUserID string) error {
+	return s.modifySnoozedReminderUsers(func(userIDs []string) []string {
+		for _, id := range userIDs {
+			if id == mattermostUserID {
+				return userIDs
+			}
+		}
+		return append(userIDs, mattermostUserID)
+	})
+}
+
+func (s *pluginStore) removeSnoozedReminderUser(mattermostUserID string) error {
+	return s.modifySnoozedReminderUsers(func(userIDs []string) []string {
+		result := []string{}
+		for _, id := range userIDs {
+			if id != mattermostUserID {
+				result = append(result, id)
+			}
+		}
+		return result
+	})
+}
+
+func containsSnoozedReminder(reminders []*SnoozedReminder, reminder *SnoozedReminder) bool {
+	for _, r := range reminders {
+		if r.EventID == reminder.EventID && r.RemindAt.Equal(reminder.RemindAt) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *pluginStore) modifySnoozedReminders(mattermostUserID string, modify func(reminders SnoozedReminders) SnoozedReminders) error {
+	err := kvstore.AtomicModify(s.reminderKV, snoozedReminderKey(mattermostUserID), func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		var reminders SnoozedReminders
+		if len(initial) > 0 {
+			err := json.Unmarshal(initial, &reminders)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		modified := modify(reminders)
+		if modified == nil {
+			return nil, nil
+		}
+		return json.Marshal(modified)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error modifying snoozed reminders")
+	}
+	return nil
+}
+
+func (s *pluginStore) modifySnoozedReminderUsers(modify func(userIDs []string) []string) error {
+	err := kvstore.AtomicModify(s.reminderKV, snoozedReminderUsersKey, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		var userIDs []string
+		if len(initial) > 0 {
+			err := json.Unmarshal(initial, &userIDs)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return json.Marshal(modify(userIDs))
+	})
+	if err != nil {
+		return errors.Wrap(err, "error modifying the users with snoozed reminders")
+	}
+	return nil
+}
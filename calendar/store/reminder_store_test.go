@@ -0,0 +1,103 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimReminder(t *testing.T) {
+	s := newTestStore(newTestKV())
+
+	claimed, err := s.ClaimReminder("user_1", "reminder_id", time.Hour)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = s.ClaimReminder("user_1", "reminder_id", time.Hour)
+	require.NoError(t, err)
+	require.False(t, claimed)
+
+	claimed, err = s.ClaimReminder("user_2", "reminder_id", time.Hour)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	require.NoError(t, s.ReleaseReminder("user_1", "reminder_id"))
+	claimed, err = s.ClaimReminder("user_1", "reminder_id", time.Hour)
+	require.NoError(t, err)
+	require.True(t, claimed)
+}
+
+func TestSnoozedReminders(t *testing.T) {
+	now := time.Date(2024, time.May, 16, 10, 0, 0, 0, time.UTC)
+	s := newTestStore(newTestKV())
+
+	users, err := s.LoadSnoozedReminderUsers()
+	require.NoError(t, err)
+	require.Empty(t, users)
+
+	require.NoError(t, s.SnoozeReminder(&SnoozedReminder{MattermostUserID: "user_1", EventID: "event_1", RemindAt: now}))
+	require.NoError(t, s.SnoozeReminder(&SnoozedReminder{MattermostUserID: "user_1", EventID: "event_2", RemindAt: now}))
+	require.NoError(t, s.SnoozeReminder(&SnoozedReminder{MattermostUserID: "user_2", EventID: "event_1", RemindAt: now}))
+
+	t.Run("the reminders are kept per user", func(t *testing.T) {
+		users, err := s.LoadSnoozedReminderUsers()
+		require.NoError(t, err)
+		require.Equal(t, []string{"user_1", "user_2"}, users)
+
+		reminders, err := s.LoadSnoozedReminders("user_1")
+		require.NoError(t, err)
+		require.Len(t, reminders, 2)
+
+		reminders, err = s.LoadSnoozedReminders("user_3")
+		require.NoError(t, err)
+		require.Empty(t, reminders)
+	})
+
+	t.Run("snoozing again replaces the reminder of the event", func(t *testing.T) {
+		require.NoError(t, s.SnoozeReminder(&SnoozedReminder{MattermostUserID: "user_2", EventID: "event_1", RemindAt: now.Add(5 * time.Minute)}))
+
+		reminders, err := s.LoadSnoozedReminders("user_2")
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		require.True(t, now.Add(5*time.Minute).Equal(reminders[0].RemindAt))
+	})
+
+	t.Run("a reminder snoozed again after being loaded is kept", func(t *testing.T) {
+		delivered := &SnoozedReminder{MattermostUserID: "user_2", EventID: "event_1", RemindAt: now}
+		require.NoError(t, s.DeleteSnoozedReminders("user_2", []*SnoozedReminder{delivered}))
+
+		reminders, err := s.LoadSnoozedReminders("user_2")
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+	})
+
+	t.Run("the users without reminders are forgotten", func(t *testing.T) {
+		reminders, err := s.LoadSnoozedReminders("user_2")
+		require.NoError(t, err)
+		require.NoError(t, s.DeleteSnoozedReminders("user_2", reminders))
+
+		users, err := s.LoadSnoozedReminderUsers()
+		require.NoError(t, err)
+		require.Equal(t, []string{"user_1"}, users)
+
+		reminders, err = s.LoadSnoozedReminders("user_1")
+		require.NoError(t, err)
+		require.NoError(t, s.DeleteSnoozedReminders("user_1", reminders[:1]))
+		users, err = s.LoadSnoozedReminderUsers()
+		require.NoError(t, err)
+		require.Equal(t, []string{"user_1"}, users)
+	})
+
+	t.Run("an index left behind is cleaned", func(t *testing.T) {
+		require.NoError(t, s.addSnoozedReminderUser("user_3"))
+		require.NoError(t, s.DeleteSnoozedReminders("user_3", nil))
+
+		users, err := s.LoadSnoozedReminderUsers()
+		require.NoError(t, err)
+		require.Equal(t, []string{"user_1"}, users)
+	})
+}
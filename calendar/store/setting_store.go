@@ -11,6 +11,16 @@ const (
 	SetCustomStatusSettingID         = "set_custom_status"
 	ReceiveRemindersSettingID        = "get_reminders"
 	DailySummarySettingID            = "summary_setting"
+	CalendarsSettingID               = "calendars"
+	ReminderLeadTimesSettingID       = "reminder_lead_times"
+	AwayOutsideWorkingHoursSettingID = "away_outside_working_hours"
+	OutOfOfficeStatusSettingID       = "out_of_office_status"
+	OutOfOfficeCustomStatusSettingID = "out_of_office_custom_status"
+	CustomStatusRulesSettingID       = "custom_status_rules"
+	DailySummarySectionsSettingID    = "summary_sections"
+	DailySummaryDaysSettingID        = "summary_days"
+	DailySummarySkipSettingID        = "summary_skip"
+	DailySummaryInteractiveSettingID = "summary_interactive"
 )
 
 func (s *pluginStore) SetSetting(userID, settingID string, value interface{}) error {
@@ -50,6 +60,78 @@ func (s *pluginStore) SetSetting(userID, settingID string, value interface{}) er
 		user.Settings.ReceiveReminders = storableValue
 	case DailySummarySettingID:
 		s.updateDailySummarySettingForUser(user, value)
+	case CalendarsSettingID:
+		storableValue, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting []string)", value, settingID)
+		}
+		user.Settings.CalendarIDs = storableValue
+	case ReminderLeadTimesSettingID:
+		storableValue, ok := value.([]int)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting []int)", value, settingID)
+		}
+		user.Settings.ReminderLeadTimes = storableValue
+	case AwayOutsideWorkingHoursSettingID:
+		storableValue, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting bool)", value, settingID)
+		}
+		user.Settings.SetAwayOutsideWorkingHours = storableValue
+	case OutOfOfficeStatusSettingID:
+		storableValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting string)", value, settingID)
+		}
+		user.Settings.OutOfOfficeStatusOption = storableValue
+	case OutOfOfficeCustomStatusSettingID:
+		storableValue, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting bool)", value, settingID)
+		}
+		user.Settings.SetOutOfOfficeCustomStatus = storableValue
+	case CustomStatusRulesSettingID:
+		storableValue, ok := value.([]*CustomStatusRule)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting []*CustomStatusRule)", value, settingID)
+		}
+		user.Settings.CustomStatusRules = storableValue
+	case DailySummarySectionsSettingID:
+		storableValue, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting []string)", value, settingID)
+		}
+		if user.Settings.DailySummary == nil {
+			user.Settings.DailySummary = DefaultDailySummaryUserSettings()
+		}
+		user.Settings.DailySummary.Sections = storableValue
+	case DailySummaryDaysSettingID:
+		storableValue, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting []string)", value, settingID)
+		}
+		if user.Settings.DailySummary == nil {
+			user.Settings.DailySummary = DefaultDailySummaryUserSettings()
+		}
+		user.Settings.DailySummary.Days = storableValue
+	case DailySummarySkipSettingID:
+		storableValue, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting []string)", value, settingID)
+		}
+		if user.Settings.DailySummary == nil {
+			user.Settings.DailySummary = DefaultDailySummaryUserSettings()
+		}
+		user.Settings.DailySummary.Skip = storableValue
+	case DailySummaryInteractiveSettingID:
+		storableValue, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting bool)", value, settingID)
+		}
+		if user.Settings.DailySummary == nil {
+			user.Settings.DailySummary = DefaultDailySummaryUserSettings()
+		}
+		user.Settings.DailySummary.Interactive = storableValue
 	default:
 		return fmt.Errorf("setting %s not found", settingID)
 	}
@@ -80,6 +162,41 @@ func (s *pluginStore) GetSetting(userID, settingID string) (interface{}, error)
 	case DailySummarySettingID:
 		dsum := user.Settings.DailySummary
 		return dsum, nil
+	case CalendarsSettingID:
+		return user.Settings.CalendarIDs, nil
+	case ReminderLeadTimesSettingID:
+		return user.Settings.ReminderLeadTimeMinutes(), nil
+	case AwayOutsideWorkingHoursSettingID:
+		return user.Settings.SetAwayOutsideWorkingHours, nil
+	case OutOfOfficeStatusSettingID:
+		if user.Settings.OutOfOfficeStatusOption == "" {
+			return NotSetStatusOption, nil
+		}
+		return user.Settings.OutOfOfficeStatusOption, nil
+	case OutOfOfficeCustomStatusSettingID:
+		return user.Settings.SetOutOfOfficeCustomStatus, nil
+	case CustomStatusRulesSettingID:
+		return user.Settings.CustomStatusRules, nil
+	case DailySummarySectionsSettingID:
+		if user.Settings.DailySummary == nil || len(user.Settings.DailySummary.Sections) == 0 {
+			return DefaultDailySummarySections, nil
+		}
+		return user.Settings.DailySummary.Sections, nil
+	case DailySummaryDaysSettingID:
+		if user.Settings.DailySummary == nil {
+			return DefaultDailySummaryDays, nil
+		}
+		if len(user.Settings.DailySummary.Days) == 0 {
+			return AllDailySummaryDays, nil
+		}
+		return user.Settings.DailySummary.Days, nil
+	case DailySummarySkipSettingID:
+		if user.Settings.DailySummary == nil || user.Settings.DailySummary.Skip == nil {
+			return []string{}, nil
+		}
+		return user.Settings.DailySummary.Skip, nil
+	case DailySummaryInteractiveSettingID:
+		return user.Settings.DailySummary != nil && user.Settings.DailySummary.Interactive, nil
 	default:
 		return nil, fmt.Errorf("setting %s not found", settingID)
 	}
@@ -91,6 +208,7 @@ func DefaultDailySummaryUserSettings() *DailySummaryUserSettings {
 
 		Timezone: "Eastern Standard Time",
 		Enable:   false,
+		Days:     append([]string{}, DefaultDailySummaryDays...),
 	}
 }
 func (s *pluginStore) updateDailySummarySettingForUser(user *User, value interface{}) error {
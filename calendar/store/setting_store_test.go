@@ -0,0 +1,54 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestDailySummaryDays(t *testing.T) {
+	s := newTestStore(newTestKV())
+
+	user := &User{MattermostUserID: "user_1", Remote: &remote.User{ID: "remote_1"}}
+	require.NoError(t, s.StoreUser(user))
+
+	t.Run("new settings select the weekdays", func(t *testing.T) {
+		days, err := s.GetSetting("user_1", DailySummaryDaysSettingID)
+		require.NoError(t, err)
+		require.Equal(t, DefaultDailySummaryDays, days)
+
+		dsum := DefaultDailySummaryUserSettings()
+		require.Equal(t, DefaultDailySummaryDays, dsum.Days)
+		require.False(t, dsum.PostsOn(time.Saturday))
+	})
+
+	t.Run("settings without days post every day", func(t *testing.T) {
+		user.Settings.DailySummary = &DailySummaryUserSettings{PostTime: "8:00AM", Enable: true}
+		require.NoError(t, s.StoreUser(user))
+
+		days, err := s.GetSetting("user_1", DailySummaryDaysSettingID)
+		require.NoError(t, err)
+		require.Equal(t, AllDailySummaryDays, days)
+		require.True(t, user.Settings.DailySummary.PostsOn(time.Saturday))
+		require.True(t, user.Settings.DailySummary.PostsOn(time.Monday))
+	})
+
+	t.Run("the selected days are kept", func(t *testing.T) {
+		require.NoError(t, s.SetSetting("user_1", DailySummaryDaysSettingID, []string{"saturday"}))
+
+		days, err := s.GetSetting("user_1", DailySummaryDaysSettingID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"saturday"}, days)
+
+		loaded, err := s.LoadUser("user_1")
+		require.NoError(t, err)
+		require.True(t, loaded.Settings.DailySummary.PostsOn(time.Saturday))
+		require.False(t, loaded.Settings.DailySummary.PostsOn(time.Monday))
+	})
+}
@@ -24,6 +24,10 @@ const (
 	EventKeyPrefix            = "ev_"
 	WelcomeKeyPrefix          = "welcome_"
 	SettingsPanelPrefix       = "settings_panel_"
+	ReminderKeyPrefix         = "reminder_"
+	FocusKeyPrefix            = "focus_"
+	ChannelCalendarKeyPrefix  = "chcal_"
+	MailboxKeyPrefix          = "mailbox_"
 )
 
 const OAuth2KeyExpiration = 15 * time.Minute
@@ -36,6 +40,10 @@ type Store interface {
 	SubscriptionStore
 	EventStore
 	WelcomeStore
+	ReminderStore
+	FocusStore
+	ChannelCalendarStore
+	MailboxStore
 	flow.Store
 	settingspanel.SettingStore
 	settingspanel.PanelStore
@@ -51,6 +59,10 @@ type pluginStore struct {
 	eventKV            kvstore.KVStore
 	welcomeIndexKV     kvstore.KVStore
 	settingsPanelKV    kvstore.KVStore
+	reminderKV         kvstore.KVStore
+	focusKV            kvstore.KVStore
+	channelCalendarKV  kvstore.KVStore
+	mailboxKV          kvstore.KVStore
 	Logger             bot.Logger
 	Tracker            tracker.Tracker
 }
@@ -75,6 +87,10 @@ func NewPluginStore(api plugin.API, logger bot.Logger, tracker tracker.Tracker,
 		oauth2KV:           oauth2KV,
 		welcomeIndexKV:     kvstore.NewHashedKeyStore(basicKV, WelcomeKeyPrefix),
 		settingsPanelKV:    kvstore.NewHashedKeyStore(basicKV, SettingsPanelPrefix),
+		reminderKV:         kvstore.NewHashedKeyStore(basicKV, ReminderKeyPrefix),
+		focusKV:            kvstore.NewHashedKeyStore(basicKV, FocusKeyPrefix),
+		channelCalendarKV:  kvstore.NewHashedKeyStore(basicKV, ChannelCalendarKeyPrefix),
+		mailboxKV:          kvstore.NewHashedKeyStore(basicKV, MailboxKeyPrefix),
 		Logger:             logger,
 		Tracker:            tracker,
 	}
@@ -0,0 +1,91 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+// testKV is an in-memory KV store with the semantics of the plugin KV store of the server.
+type testKV struct {
+	sync.Mutex
+	data map[string][]byte
+}
+
+var _ kvstore.KVStore = (*testKV)(nil)
+
+func newTestKV() *testKV {
+	return &testKV{data: map[string][]byte{}}
+}
+
+func (kv *testKV) Load(key string) ([]byte, error) {
+	kv.Lock()
+	defer kv.Unlock()
+
+	data, ok := kv.data[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return data, nil
+}
+
+func (kv *testKV) Store(key string, data []byte) error {
+	_, err := kv.StoreWithOptions(key, data, model.PluginKVSetOptions{})
+	return err
+}
+
+func (kv *testKV) StoreTTL(key string, data []byte, _ int64) error {
+	return kv.Store(key, data)
+}
+
+func (kv *testKV) StoreWithOptions(key string, value []byte, opts model.PluginKVSetOptions) (bool, error) {
+	kv.Lock()
+	defer kv.Unlock()
+
+	if opts.Atomic {
+		current, ok := kv.data[key]
+		if ok != (opts.OldValue != nil) || !bytes.Equal(current, opts.OldValue) {
+			return false, nil
+		}
+	}
+	if value == nil {
+		delete(kv.data, key)
+		return true, nil
+	}
+	kv.data[key] = value
+	return true, nil
+}
+
+func (kv *testKV) Delete(key string) error {
+	kv.Lock()
+	defer kv.Unlock()
+
+	delete(kv.data, key)
+	return nil
+}
+
+// newTestStore returns a store keeping all its data in the given KV store.
+func newTestStore(kv kvstore.KVStore) *pluginStore {
+	return &pluginStore{
+		basicKV:            kv,
+		userKV:             kvstore.NewHashedKeyStore(kv, UserKeyPrefix),
+		userIndexKV:        kvstore.NewHashedKeyStore(kv, UserIndexKeyPrefix),
+		mattermostUserIDKV: kvstore.NewHashedKeyStore(kv, MattermostUserIDKeyPrefix),
+		subscriptionKV:     kvstore.NewHashedKeyStore(kv, SubscriptionKeyPrefix),
+		eventKV:            kvstore.NewHashedKeyStore(kv, EventKeyPrefix),
+		welcomeIndexKV:     kvstore.NewHashedKeyStore(kv, WelcomeKeyPrefix),
+		settingsPanelKV:    kvstore.NewHashedKeyStore(kv, SettingsPanelPrefix),
+		reminderKV:         kvstore.NewHashedKeyStore(kv, ReminderKeyPrefix),
+		focusKV:            kvstore.NewHashedKeyStore(kv, FocusKeyPrefix),
+		channelCalendarKV:  kvstore.NewHashedKeyStore(kv, ChannelCalendarKeyPrefix),
+		mailboxKV:          kvstore.NewHashedKeyStore(kv, MailboxKeyPrefix),
+		Logger:             &bot.NilLogger{},
+	}
+}
@@ -21,6 +21,8 @@ type Subscription struct {
 	PluginVersion       string
 	Remote              *remote.Subscription
 	MattermostCreatorID string
+	// ChannelID is set for the subscriptions to the calendar of a channel, see ChannelCalendar.
+	ChannelID string `json:",omitempty"`
 }
 
 func (s *pluginStore) LoadSubscription(subscriptionID string) (*Subscription, error) {
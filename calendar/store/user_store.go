@@ -6,7 +6,10 @@ package store
 import (
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
@@ -21,18 +24,34 @@ type UserStore interface {
 	LoadUser(mattermostUserID string) (*User, error)
 	LoadMattermostUserID(remoteUserID string) (string, error)
 	LoadUserIndex() (UserIndex, error)
+	ForEachUserIndexPage(f func(page UserIndex) error) error
+	MigrateUserIndex() error
+	LoadSkippedStatusSyncUsers() ([]string, error)
+	StoreSkippedStatusSyncUsers(mattermostUserIDs []string) error
 	SearchInUserIndex(term string, limit int) (UserIndex, error)
 	StoreUser(user *User) error
 	LoadUserFromIndex(mattermostUserID string) (*UserShort, error)
 	DeleteUser(mattermostUserID string) error
-	ModifyUserIndex(modify func(userIndex UserIndex) (UserIndex, error)) error
 	StoreUserInIndex(user *User) error
 	DeleteUserFromIndex(mattermostUserID string) error
 	StoreUserActiveEvents(mattermostUserID string, events []string) error
 	StoreUserLinkedEvent(mattermostUserID, eventID, channelID string) error
+	DeleteUserLinkedEvent(mattermostUserID, eventID, channelID string) error
 	StoreUserCustomStatusUpdates(mattermostUserID string, values bool) error
+	StoreUserAutomaticStatus(mattermostUserID, automaticStatus string) error
+	StoreUserOutOfOfficeCustomStatus(mattermostUserID string, value bool) error
 }
 
+// The user index is sharded by Mattermost user ID, so that connecting users don't contend for the same key,
+// and the jobs go through the index one shard at a time.
+const (
+	userIndexShards = 32
+	// legacyUserIndexKey is the key of the index before it was sharded.
+	legacyUserIndexKey = ""
+	// skippedStatusSyncUsersKey stores the users the status sync job didn't get to, to sync them first next time.
+	skippedStatusSyncUsersKey = "status_sync_skipped"
+)
+
 type UserIndex []*UserShort
 
 type UserShort struct {
@@ -74,6 +93,10 @@ type User struct {
 	ActiveEvents          []string          `json:"events"`
 	ChannelEvents         ChannelEventLink  `json:"linkedEvents,omitempty"`
 	IsCustomStatusSet     bool
+
+	// AutomaticStatus is the reason why the status was last set from the mailbox settings of the user, if any.
+	AutomaticStatus              string `json:"automaticStatus,omitempty"`
+	IsOutOfOfficeCustomStatusSet bool   `json:"isOutOfOfficeCustomStatusSet,omitempty"`
 }
 
 var DefaultSettings = Settings{
@@ -88,16 +111,134 @@ type Settings struct {
 	ReceiveReminders        bool
 	SetCustomStatus         bool
 
+	// CalendarIDs are the calendars used for status sync, reminders, daily summary and calendar views.
+	// When empty, only the default calendar is used.
+	CalendarIDs []string
+
+	// ReminderLeadTimes are the numbers of minutes before the start of the events at which reminders are sent,
+	// OutlookReminderLeadTime standing for the reminder set on the event. When empty, reminders are sent
+	// DefaultReminderLeadTime minutes before.
+	ReminderLeadTimes []int
+
+	// SetAwayOutsideWorkingHours sets the status to away outside the working hours of the mailbox settings.
+	SetAwayOutsideWorkingHours bool
+	// OutOfOfficeStatusOption is the status to set while automatic replies are on, NotSetStatusOption or
+	// empty for none.
+	OutOfOfficeStatusOption    string
+	SetOutOfOfficeCustomStatus bool
+
+	// CustomStatusRules pick the custom status set for the events matching them, the first matching rule
+	// winning. The default meeting custom status is used for the other events.
+	CustomStatusRules []*CustomStatusRule
+
 	// Legacy settings
 	UpdateStatus                      bool
 	ReceiveNotificationsDuringMeeting bool
 }
 
+const (
+	CustomStatusExpiryEventEnd = "end"
+	CustomStatusExpiryNone     = "none"
+)
+
+// CustomStatusRule sets a custom status for the events matching all its conditions. The text may contain
+// the {subject} and {end} placeholders, and Expiry is CustomStatusExpiryEventEnd, CustomStatusExpiryNone or
+// a duration from the start of the event like "30m".
+type CustomStatusRule struct {
+	Category       string `json:"category,omitempty"`
+	SubjectPattern string `json:"subject_pattern,omitempty"`
+	ShowAs         string `json:"show_as,omitempty"`
+	HasTeamsLink   bool   `json:"has_teams_link,omitempty"`
+	Emoji          string `json:"emoji"`
+	Text           string `json:"text"`
+	Expiry         string `json:"expiry,omitempty"`
+}
+
 type DailySummaryUserSettings struct {
 	PostTime     string `json:"post_time"` // Kitchen format, i.e. 8:30AM
 	Timezone     string `json:"tz"`        // Timezone in MSCal when PostTime is set/updated
 	LastPostTime string `json:"last_post_time"`
 	Enable       bool   `json:"enable"`
+
+	// Sections are the contents of the summary, DefaultDailySummarySections when empty.
+	Sections []string `json:"sections,omitempty"`
+	// Days are the lowercase weekdays the summary is posted on, every day when empty.
+	Days []string `json:"days,omitempty"`
+	// Skip are the days the summary is not posted on, among the DailySummarySkip options.
+	Skip []string `json:"skip,omitempty"`
+	// Interactive posts the events of the day with buttons to respond to them and join their meetings.
+	Interactive bool `json:"interactive,omitempty"`
+}
+
+const (
+	// DailySummarySectionToday lists the events of the day.
+	DailySummarySectionToday = "today"
+	// DailySummarySectionWeekPreview lists the events of the week ahead, on Mondays.
+	DailySummarySectionWeekPreview = "week_preview"
+	// DailySummarySectionMeetingHours, DailySummarySectionBackToBack and DailySummarySectionAwaitingResponse
+	// are the sections of the recap of the week, on Fridays.
+	DailySummarySectionMeetingHours     = "meeting_hours"
+	DailySummarySectionBackToBack       = "back_to_back"
+	DailySummarySectionAwaitingResponse = "awaiting_response"
+)
+
+var DefaultDailySummarySections = []string{DailySummarySectionToday}
+
+// DefaultDailySummaryDays are the days selected for new daily summary settings.
+var DefaultDailySummaryDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+
+// AllDailySummaryDays are the days the summary is posted on when no day is selected.
+var AllDailySummaryDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+const (
+	// DailySummarySkipOutOfOffice skips the days with an all-day out of office event, or the automatic replies on.
+	DailySummarySkipOutOfOffice = "out_of_office"
+	// DailySummarySkipEmptyDays skips the days without events, instead of posting that nothing is scheduled.
+	DailySummarySkipEmptyDays = "empty_days"
+)
+
+// HasSection returns whether the section is selected for the summary.
+func (dsum *DailySummaryUserSettings) HasSection(section string) bool {
+	sections := dsum.Sections
+	if len(sections) == 0 {
+		sections = DefaultDailySummarySections
+	}
+	for _, s := range sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// PostsOn returns whether the summary is posted on the weekday.
+func (dsum *DailySummaryUserSettings) PostsOn(day time.Weekday) bool {
+	if len(dsum.Days) == 0 {
+		return true
+	}
+	for _, d := range dsum.Days {
+		if d == strings.ToLower(day.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Skips returns whether the summary is not posted on the days matching the skip option.
+func (dsum *DailySummaryUserSettings) Skips(option string) bool {
+	for _, s := range dsum.Skip {
+		if s == option {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWeekRecap returns whether any section of the recap of the week is selected.
+func (dsum *DailySummaryUserSettings) HasWeekRecap() bool {
+	return dsum.HasSection(DailySummarySectionMeetingHours) ||
+		dsum.HasSection(DailySummarySectionBackToBack) ||
+		dsum.HasSection(DailySummarySectionAwaitingResponse)
 }
 
 type WelcomeFlowStatus struct {
@@ -105,6 +246,16 @@ type WelcomeFlowStatus struct {
 	Step    int
 }
 
+const (
+	DefaultReminderLeadTime = 10
+	OutlookReminderLeadTime = -1
+)
+
+const (
+	OutOfOfficeAutomaticStatus         = "out_of_office"
+	OutsideWorkingHoursAutomaticStatus = "outside_working_hours"
+)
+
 const (
 	AwayStatusOption   = "Away"
 	DNDStatusOption    = "Do Not Disturb"
@@ -143,17 +294,74 @@ func (s *pluginStore) LoadMattermostUserID(remoteUserID string) (string, error)
 	return string(data), nil
 }
 
+// LoadUserIndex returns the whole user index, or an error if any of its shards fails to load. The jobs go
+// through the index with ForEachUserIndexPage instead.
 func (s *pluginStore) LoadUserIndex() (UserIndex, error) {
 	users := UserIndex{}
-	err := kvstore.LoadJSON(s.userIndexKV, "", &users)
+	err := s.ForEachUserIndexPage(func(page UserIndex) error {
+		users = append(users, page...)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 	return users, nil
 }
 
+// ForEachUserIndexPage calls f with the users of each shard of the index, skipping the empty shards. It stops at
+// the first error returned by f. The shards failing to load don't keep f from being called with the next ones,
+// their errors are returned as UserIndexShardErrors once all the shards are gone through.
+func (s *pluginStore) ForEachUserIndexPage(f func(page UserIndex) error) error {
+	shardErrors := UserIndexShardErrors{}
+	for shard := 0; shard < userIndexShards; shard++ {
+		page, err := s.loadUserIndexShard(shard)
+		if err != nil {
+			shardErrors[shard] = err
+			continue
+		}
+		if len(page) == 0 {
+			continue
+		}
+
+		err = f(page)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(shardErrors) > 0 {
+		return shardErrors
+	}
+	return nil
+}
+
+// UserIndexShardErrors are the errors of the shards of the user index failing to load, by shard.
+type UserIndexShardErrors map[int]error
+
+func (e UserIndexShardErrors) Error() string {
+	shards := make([]int, 0, len(e))
+	for shard := range e {
+		shards = append(shards, shard)
+	}
+	sort.Ints(shards)
+
+	messages := []string{}
+	for _, shard := range shards {
+		messages = append(messages, fmt.Sprintf("shard %d: %s", shard, e[shard].Error()))
+	}
+	return "error loading user index " + strings.Join(messages, ", ")
+}
+
+func (e UserIndexShardErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
 func (s *pluginStore) LoadUserFromIndex(mattermostUserID string) (*UserShort, error) {
-	users, err := s.LoadUserIndex()
+	users, err := s.loadUserIndexShard(userIndexShard(mattermostUserID))
 	if err != nil {
 		return nil, err
 	}
@@ -167,6 +375,83 @@ func (s *pluginStore) LoadUserFromIndex(mattermostUserID string) (*UserShort, er
 	return nil, ErrNotFound
 }
 
+func (s *pluginStore) loadUserIndexShard(shard int) (UserIndex, error) {
+	users := UserIndex{}
+	err := kvstore.LoadJSON(s.userIndexKV, userIndexShardKey(shard), &users)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return users, nil
+}
+
+// MigrateUserIndex moves the users of the legacy index, stored under a single key, to the shards of the index.
+// The users already in a shard are kept, as they connected since the legacy index was last written.
+func (s *pluginStore) MigrateUserIndex() error {
+	var legacy UserIndex
+	err := kvstore.LoadJSON(s.userIndexKV, legacyUserIndexKey, &legacy)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error loading legacy user index")
+	}
+
+	byShard := map[int]UserIndex{}
+	for _, u := range legacy {
+		shard := userIndexShard(u.MattermostUserID)
+		byShard[shard] = append(byShard[shard], u)
+	}
+
+	for shard, users := range byShard {
+		err = s.modifyUserIndexShard(shard, func(userIndex UserIndex) (UserIndex, error) {
+			indexed := userIndex.ByMattermostID()
+			for _, u := range users {
+				if _, ok := indexed[u.MattermostUserID]; !ok {
+					userIndex = append(userIndex, u)
+				}
+			}
+			return userIndex, nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "error migrating user index shard %d", shard)
+		}
+	}
+
+	err = s.userIndexKV.Delete(legacyUserIndexKey)
+	if err != nil {
+		return errors.Wrap(err, "error deleting legacy user index")
+	}
+
+	s.Logger.Infof("store: migrated %d users to the sharded user index.", len(legacy))
+	return nil
+}
+
+func (s *pluginStore) LoadSkippedStatusSyncUsers() ([]string, error) {
+	mattermostUserIDs := []string{}
+	err := kvstore.LoadJSON(s.userIndexKV, skippedStatusSyncUsersKey, &mattermostUserIDs)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return mattermostUserIDs, nil
+}
+
+func (s *pluginStore) StoreSkippedStatusSyncUsers(mattermostUserIDs []string) error {
+	if len(mattermostUserIDs) == 0 {
+		return s.userIndexKV.Delete(skippedStatusSyncUsersKey)
+	}
+	return kvstore.StoreJSON(s.userIndexKV, skippedStatusSyncUsersKey, mattermostUserIDs)
+}
+
+func userIndexShard(mattermostUserID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(mattermostUserID))
+	return int(h.Sum32() % userIndexShards)
+}
+
+func userIndexShardKey(shard int) string {
+	return fmt.Sprintf("shard_%d", shard)
+}
+
 func (s *pluginStore) StoreUser(user *User) error {
 	err := kvstore.StoreJSON(s.userKV, user.MattermostUserID, user)
 	if err != nil {
@@ -195,27 +480,11 @@ func (s *pluginStore) DeleteUser(mattermostUserID string) error {
 		return err
 	}
 
-	var userIndex []*UserShort
-	err = kvstore.LoadJSON(s.userIndexKV, "", &userIndex)
-	if err != nil {
-		return err
-	}
-	filtered := []*UserShort{}
-	for _, u := range userIndex {
-		if u.MattermostUserID != mattermostUserID {
-			filtered = append(filtered, u)
-		}
-	}
-	err = kvstore.StoreJSON(s.userIndexKV, "", &filtered)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return s.DeleteUserFromIndex(mattermostUserID)
 }
 
-func (s *pluginStore) ModifyUserIndex(modify func(userIndex UserIndex) (UserIndex, error)) error {
-	return kvstore.AtomicModify(s.userIndexKV, "", func(initial []byte, storeErr error) ([]byte, error) {
+func (s *pluginStore) modifyUserIndexShard(shard int, modify func(userIndex UserIndex) (UserIndex, error)) error {
+	return kvstore.AtomicModify(s.userIndexKV, userIndexShardKey(shard), func(initial []byte, storeErr error) ([]byte, error) {
 		if storeErr != nil && storeErr != ErrNotFound {
 			return initial, storeErr
 		}
@@ -243,7 +512,7 @@ func (s *pluginStore) ModifyUserIndex(modify func(userIndex UserIndex) (UserInde
 }
 
 func (s *pluginStore) StoreUserInIndex(user *User) error {
-	return s.ModifyUserIndex(func(userIndex UserIndex) (UserIndex, error) {
+	return s.modifyUserIndexShard(userIndexShard(user.MattermostUserID), func(userIndex UserIndex) (UserIndex, error) {
 		newUser := &UserShort{
 			MattermostUserID:      user.MattermostUserID,
 			MattermostUsername:    user.MattermostUsername,
@@ -267,7 +536,7 @@ func (s *pluginStore) StoreUserInIndex(user *User) error {
 }
 
 func (s *pluginStore) DeleteUserFromIndex(mattermostUserID string) error {
-	return s.ModifyUserIndex(func(userIndex UserIndex) (UserIndex, error) {
+	return s.modifyUserIndexShard(userIndexShard(mattermostUserID), func(userIndex UserIndex) (UserIndex, error) {
 		for i, u := range userIndex {
 			if u.MattermostUserID == mattermostUserID {
 				return append(userIndex[:i], userIndex[i+1:]...), nil
@@ -321,6 +590,21 @@ func (s *pluginStore) StoreUserLinkedEvent(mattermostUserID, eventID, channelID
 	return kvstore.StoreJSON(s.userKV, mattermostUserID, u)
 }
 
+func (s *pluginStore) DeleteUserLinkedEvent(mattermostUserID, eventID, channelID string) error {
+	u, err := s.LoadUser(mattermostUserID)
+	if err != nil {
+		return err
+	}
+
+	if u.ChannelEvents[eventID] != channelID {
+		return nil
+	}
+
+	delete(u.ChannelEvents, eventID)
+
+	return kvstore.StoreJSON(s.userKV, mattermostUserID, u)
+}
+
 func (index UserIndex) ToDTO() (result []UserShortDTO) {
 	for _, u := range index {
 		result = append(result, u.ToDTO())
@@ -338,6 +622,26 @@ func (s *pluginStore) StoreUserCustomStatusUpdates(mattermostUserID string, valu
 	return kvstore.StoreJSON(s.userKV, mattermostUserID, u)
 }
 
+func (s *pluginStore) StoreUserAutomaticStatus(mattermostUserID, automaticStatus string) error {
+	u, err := s.LoadUser(mattermostUserID)
+	if err != nil {
+		return err
+	}
+
+	u.AutomaticStatus = automaticStatus
+	return kvstore.StoreJSON(s.userKV, mattermostUserID, u)
+}
+
+func (s *pluginStore) StoreUserOutOfOfficeCustomStatus(mattermostUserID string, value bool) error {
+	u, err := s.LoadUser(mattermostUserID)
+	if err != nil {
+		return err
+	}
+
+	u.IsOutOfOfficeCustomStatusSet = value
+	return kvstore.StoreJSON(s.userKV, mattermostUserID, u)
+}
+
 func (index UserIndex) ByMattermostID() map[string]*UserShort {
 	result := map[string]*UserShort{}
 
@@ -400,3 +704,21 @@ func (user *User) IsConfiguredForStatusUpdates() bool {
 func (user *User) IsConfiguredForCustomStatusUpdates() bool {
 	return user.Settings.SetCustomStatus
 }
+
+// IsConfiguredForPresenceUpdates returns whether the status or the custom status of the user follow their
+// working hours or their automatic replies.
+func (user *User) IsConfiguredForPresenceUpdates() bool {
+	return user.Settings.SetAwayOutsideWorkingHours || user.IsConfiguredForOutOfOfficeStatus() || user.Settings.SetOutOfOfficeCustomStatus
+}
+
+func (user *User) IsConfiguredForOutOfOfficeStatus() bool {
+	return user.Settings.OutOfOfficeStatusOption == AwayStatusOption || user.Settings.OutOfOfficeStatusOption == DNDStatusOption
+}
+
+// ReminderLeadTimeMinutes returns the lead times of the reminders, falling back to DefaultReminderLeadTime.
+func (settings Settings) ReminderLeadTimeMinutes() []int {
+	if len(settings.ReminderLeadTimes) == 0 {
+		return []int{DefaultReminderLeadTime}
+	}
+	return settings.ReminderLeadTimes
+}
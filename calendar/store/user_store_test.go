@@ -0,0 +1,151 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+func makeIndexedUser(mattermostUserID string) *User {
+	return &User{
+		MattermostUserID: mattermostUserID,
+		Remote:           &remote.User{ID: mattermostUserID + "_remote", Mail: mattermostUserID + "@example.com"},
+	}
+}
+
+func loadUserIndexPages(t *testing.T, s *pluginStore) []UserIndex {
+	pages := []UserIndex{}
+	err := s.ForEachUserIndexPage(func(page UserIndex) error {
+		pages = append(pages, page)
+		return nil
+	})
+	require.NoError(t, err)
+	return pages
+}
+
+func userIndexIDs(users UserIndex) []string {
+	ids := []string{}
+	for _, u := range users {
+		ids = append(ids, u.MattermostUserID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestMigrateUserIndex(t *testing.T) {
+	s := newTestStore(newTestKV())
+
+	legacy := UserIndex{}
+	for i := 0; i < 10; i++ {
+		legacy = append(legacy, &UserShort{MattermostUserID: fmt.Sprintf("user_%d", i), RemoteID: fmt.Sprintf("remote_%d", i)})
+	}
+	require.NoError(t, kvstore.StoreJSON(s.userIndexKV, legacyUserIndexKey, legacy))
+
+	// A user connected since the legacy index was last written keeps their entry
+	require.NoError(t, s.StoreUserInIndex(&User{MattermostUserID: "user_0", Remote: &remote.User{ID: "remote_0", Mail: "user_0@example.com"}}))
+
+	require.NoError(t, s.MigrateUserIndex())
+
+	users, err := s.LoadUserIndex()
+	require.NoError(t, err)
+	require.Equal(t, userIndexIDs(legacy), userIndexIDs(users))
+
+	u, err := s.LoadUserFromIndex("user_0")
+	require.NoError(t, err)
+	require.Equal(t, "user_0@example.com", u.Email)
+
+	var left UserIndex
+	err = kvstore.LoadJSON(s.userIndexKV, legacyUserIndexKey, &left)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	t.Run("running the migration again changes nothing", func(t *testing.T) {
+		require.NoError(t, s.MigrateUserIndex())
+
+		again, err := s.LoadUserIndex()
+		require.NoError(t, err)
+		require.Equal(t, userIndexIDs(users), userIndexIDs(again))
+	})
+}
+
+func TestUserIndexShards(t *testing.T) {
+	s := newTestStore(newTestKV())
+
+	added := UserIndex{}
+	for i := 0; i < 100; i++ {
+		user := makeIndexedUser(fmt.Sprintf("user_%d", i))
+		require.NoError(t, s.StoreUserInIndex(user))
+		added = append(added, &UserShort{MattermostUserID: user.MattermostUserID})
+	}
+
+	t.Run("the users are added to their shard", func(t *testing.T) {
+		for _, u := range added {
+			shard, err := s.loadUserIndexShard(userIndexShard(u.MattermostUserID))
+			require.NoError(t, err)
+			require.Contains(t, userIndexIDs(shard), u.MattermostUserID)
+		}
+	})
+
+	t.Run("the pages go through all the shards", func(t *testing.T) {
+		pages := loadUserIndexPages(t, s)
+		require.Greater(t, len(pages), 1)
+
+		all := UserIndex{}
+		for _, page := range pages {
+			require.NotEmpty(t, page)
+			shard := userIndexShard(page[0].MattermostUserID)
+			for _, u := range page {
+				require.Equal(t, shard, userIndexShard(u.MattermostUserID))
+			}
+			all = append(all, page...)
+		}
+		require.Equal(t, userIndexIDs(added), userIndexIDs(all))
+	})
+
+	t.Run("the users are deleted from their shard", func(t *testing.T) {
+		require.NoError(t, s.DeleteUserFromIndex("user_42"))
+
+		shard, err := s.loadUserIndexShard(userIndexShard("user_42"))
+		require.NoError(t, err)
+		require.NotContains(t, userIndexIDs(shard), "user_42")
+
+		_, err = s.LoadUserFromIndex("user_42")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		users, err := s.LoadUserIndex()
+		require.NoError(t, err)
+		require.Len(t, users, len(added)-1)
+	})
+
+	t.Run("a shard failing to load is returned after the other ones", func(t *testing.T) {
+		broken := userIndexShard("user_1")
+		require.NoError(t, s.userIndexKV.Store(userIndexShardKey(broken), []byte("{")))
+
+		count := 0
+		err := s.ForEachUserIndexPage(func(page UserIndex) error {
+			for _, u := range page {
+				require.NotEqual(t, broken, userIndexShard(u.MattermostUserID))
+			}
+			count += len(page)
+			return nil
+		})
+		shardErrors := UserIndexShardErrors{}
+		require.ErrorAs(t, err, &shardErrors)
+		require.Len(t, shardErrors, 1)
+		require.Contains(t, shardErrors, broken)
+		require.Greater(t, count, 0)
+
+		_, err = s.LoadUserIndex()
+		require.Error(t, err)
+
+		_, err = s.SearchInUserIndex("user", 10)
+		require.Error(t, err)
+	})
+}
@@ -21,6 +21,9 @@ type Poster interface {
 	// DMWithMessageAndAttachments posts a Direct Message that contains Slack attachments and a message.
 	DMWithMessageAndAttachments(mattermostUserID, message string, attachments ...*model.SlackAttachment) (string, error)
 
+	// DMWithFile uploads a file and posts it in a Direct Message along with a message.
+	DMWithFile(mattermostUserID, message, fileName string, data []byte) (string, error)
+
 	// Ephemeral sends an ephemeral message to a user
 	Ephemeral(mattermostUserID, channelID, format string, args ...interface{})
 
@@ -63,6 +66,25 @@ func (bot *bot) DMWithMessageAndAttachments(mattermostUserID, message string, at
 	return bot.dm(mattermostUserID, &post)
 }
 
+// DMWithFile uploads a file and posts it in a Direct Message along with a message.
+func (bot *bot) DMWithFile(mattermostUserID, message, fileName string, data []byte) (string, error) {
+	channel, err := bot.pluginAPI.GetDirectChannel(mattermostUserID, bot.mattermostUserID)
+	if err != nil {
+		bot.pluginAPI.LogInfo("Couldn't get bot's DM channel", "user_id", mattermostUserID)
+		return "", err
+	}
+
+	fileInfo, err := bot.pluginAPI.UploadFile(data, channel.Id, fileName)
+	if err != nil {
+		return "", err
+	}
+
+	return bot.dm(mattermostUserID, &model.Post{
+		Message: message,
+		FileIds: model.StringArray{fileInfo.Id},
+	})
+}
+
 func (bot *bot) dm(mattermostUserID string, post *model.Post) (string, error) {
 	channel, err := bot.pluginAPI.GetDirectChannel(mattermostUserID, bot.mattermostUserID)
 	if err != nil {
@@ -119,6 +119,10 @@ func (a *API) CanLinkEventToChannel(channelID, userID string) bool {
 	return a.api.HasPermissionToChannel(userID, channelID, model.PermissionCreatePost)
 }
 
+func (a *API) CanReadChannel(channelID, userID string) bool {
+	return a.api.HasPermissionToChannel(userID, channelID, model.PermissionReadChannel)
+}
+
 func (a *API) CleanKVStore() error {
 	appErr := a.api.KVDeleteAll()
 	if appErr != nil {
@@ -144,6 +148,37 @@ func (a *API) GetPost(postID string) (*model.Post, error) {
 	return p, nil
 }
 
+func (a *API) GetChannel(channelID string) (*model.Channel, error) {
+	ch, appErr := a.api.GetChannel(channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return ch, nil
+}
+
 func (a *API) PublishWebsocketEvent(mattermostUserID, event string, payload map[string]any) {
 	a.api.PublishWebSocketEvent(event, payload, &model.WebsocketBroadcast{UserId: mattermostUserID})
 }
+
+func (a *API) OpenInteractiveDialog(dialog model.OpenDialogRequest) error {
+	if appErr := a.api.OpenInteractiveDialog(dialog); appErr != nil {
+		return appErr
+	}
+	return nil
+}
+
+func (a *API) GetFileInfo(fileID string) (*model.FileInfo, error) {
+	fileInfo, appErr := a.api.GetFileInfo(fileID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return fileInfo, nil
+}
+
+func (a *API) GetFile(fileID string) ([]byte, error) {
+	data, appErr := a.api.GetFile(fileID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return data, nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package fake
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+const defaultCalendarID = "calendar"
+
+// storedEvent is an event in the calendar of a user. Like in Exchange, the organizer and each attendee have their
+// own copy of an event, sharing its ID and its iCalendar UID.
+type storedEvent struct {
+	*remote.Event
+	CalendarID string
+}
+
+// sentMessage is a mail sent by a user, kept to be looked at in tests.
+type sentMessage struct {
+	From    string
+	Message *remote.Message
+}
+
+// calendarStore holds the users of the fake provider, created on the first use of their email address, with their
+// calendars, events and mailbox settings, and the subscriptions to their events.
+type calendarStore struct {
+	sync.Mutex
+
+	users         map[string]*remote.User
+	mailboxes     map[string]*remote.MailboxSettings
+	calendars     map[string][]*remote.Calendar
+	events        map[string]map[string]*storedEvent
+	subscriptions map[string]*subscription
+	sentMail      []*sentMessage
+}
+
+func newCalendarStore() *calendarStore {
+	return &calendarStore{
+		users:         map[string]*remote.User{},
+		mailboxes:     map[string]*remote.MailboxSettings{},
+		calendars:     map[string][]*remote.Calendar{},
+		events:        map[string]map[string]*storedEvent{},
+		subscriptions: map[string]*subscription{},
+	}
+}
+
+// userID returns the ID of the user with the email address. The IDs don't change when the plugin restarts, so
+// that the connected users keep their fake account.
+func userID(mail string) string {
+	return strings.ToLower(strings.TrimSpace(mail))
+}
+
+// user returns the user with the email address, or the ID, creating it if needed.
+func (s *calendarStore) user(mail string) *remote.User {
+	s.Lock()
+	defer s.Unlock()
+	return s.userLocked(mail)
+}
+
+func (s *calendarStore) userLocked(mail string) *remote.User {
+	id := userID(mail)
+	if u, ok := s.users[id]; ok {
+		return u
+	}
+
+	name := id
+	if i := strings.Index(name, "@"); i > 0 {
+		name = name[:i]
+	}
+	u := &remote.User{
+		ID:                id,
+		DisplayName:       name,
+		UserPrincipalName: id,
+		Mail:              id,
+	}
+	s.users[id] = u
+
+	s.mailboxes[id] = &remote.MailboxSettings{
+		TimeZone: "UTC",
+		WorkingHours: remote.WorkingHours{
+			StartTime:  "09:00:00.0000000",
+			EndTime:    "17:00:00.0000000",
+			DaysOfWeek: []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+		},
+		AutomaticRepliesSetting: remote.AutomaticRepliesSetting{
+			Status: remote.AutomaticRepliesStatusDisabled,
+		},
+	}
+	s.mailboxes[id].WorkingHours.TimeZone.Name = "UTC"
+
+	s.calendars[id] = []*remote.Calendar{{
+		ID:                defaultCalendarID,
+		Name:              "Calendar",
+		IsDefaultCalendar: true,
+		Owner:             u,
+	}}
+	s.events[id] = map[string]*storedEvent{}
+	return u
+}
+
+// eventsBetween returns copies of the events of the calendar overlapping the time range, sorted by start time.
+// The default calendar is used when calendarID is empty.
+func (s *calendarStore) eventsBetween(remoteUserID, calendarID string, start, end time.Time) ([]*remote.Event, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.eventsBetweenLocked(remoteUserID, calendarID, start, end)
+}
+
+func (s *calendarStore) eventsBetweenLocked(remoteUserID, calendarID string, start, end time.Time) ([]*remote.Event, error) {
+	if calendarID == "" {
+		calendarID = defaultCalendarID
+	}
+	u := s.userLocked(remoteUserID)
+	if s.calendarLocked(u.ID, calendarID) == nil {
+		return nil, errors.Errorf("calendar %s not found", calendarID)
+	}
+
+	events := []*remote.Event{}
+	for _, e := range s.events[u.ID] {
+		if e.CalendarID != calendarID {
+			continue
+		}
+		if !overlaps(e.Event, start, end) {
+			continue
+		}
+		events = append(events, copyEvent(e.Event))
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Start.Time().Before(events[j].Start.Time())
+	})
+	return events, nil
+}
+
+func (s *calendarStore) calendarLocked(remoteUserID, calendarID string) *remote.Calendar {
+	for _, c := range s.calendars[userID(remoteUserID)] {
+		if c.ID == calendarID {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *calendarStore) eventLocked(remoteUserID, eventID string) (*storedEvent, error) {
+	e, ok := s.events[userID(remoteUserID)][eventID]
+	if !ok {
+		return nil, errors.Errorf("event %s not found", eventID)
+	}
+	return e, nil
+}
+
+func overlaps(e *remote.Event, start, end time.Time) bool {
+	if e.Start == nil || e.End == nil {
+		return false
+	}
+	return e.Start.Time().Before(end) && e.End.Time().After(start)
+}
+
+// copyEvent returns a deep copy of the event, so that the events handed out don't change the stored ones.
+func copyEvent(e *remote.Event) *remote.Event {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil
+	}
+	event := &remote.Event{}
+	if err = json.Unmarshal(data, event); err != nil {
+		return nil
+	}
+	return event
+}
+
+func newID() string {
+	return model.NewId()
+}
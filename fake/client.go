@@ -0,0 +1,197 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package fake
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+type client struct {
+	remote   *impl
+	calendar *calendarStore
+
+	// mail is the email address of the user the client was made for, empty for the superuser client.
+	mail string
+	bot.Logger
+}
+
+// change is a change to an event in the calendar of a user, that the subscriptions to the events of the user
+// are notified of.
+type change struct {
+	owner      string
+	eventID    string
+	changeType string
+}
+
+// update runs f with the calendars locked, then notifies the subscriptions of the changes it made.
+func (c *client) update(f func() ([]change, error)) error {
+	c.calendar.Lock()
+	changes, err := f()
+	deliveries := c.calendar.webhooksLocked(changes)
+	c.calendar.Unlock()
+
+	c.remote.send(deliveries)
+	return err
+}
+
+func (c *client) GetMe() (*remote.User, error) {
+	if c.mail == "" {
+		return nil, errors.New("fake GetMe: the superuser client has no user")
+	}
+	u := *c.calendar.user(c.mail)
+	return &u, nil
+}
+
+func (c *client) GetCalendars(remoteUserID string) ([]*remote.Calendar, error) {
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	u := c.calendar.userLocked(remoteUserID)
+	calendars := []*remote.Calendar{}
+	for _, cal := range c.calendar.calendars[u.ID] {
+		copied := *cal
+		calendars = append(calendars, &copied)
+	}
+	return calendars, nil
+}
+
+func (c *client) GetDefaultCalendarView(remoteUserID string, start, end time.Time) ([]*remote.Event, error) {
+	events, err := c.calendar.eventsBetween(remoteUserID, defaultCalendarID, start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "fake GetDefaultCalendarView")
+	}
+	return normalizeEvents(events), nil
+}
+
+func (c *client) GetEventsBetweenDates(remoteUserID string, start, end time.Time) ([]*remote.Event, error) {
+	events, err := c.calendar.eventsBetween(remoteUserID, defaultCalendarID, start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "fake GetEventsBetweenDates")
+	}
+	return normalizeEvents(events), nil
+}
+
+// GetSharedCalendarView returns the events of the user with the address, as any email address is a user of the
+// fake provider.
+func (c *client) GetSharedCalendarView(calendarAddress string, start, end time.Time) ([]*remote.Event, error) {
+	events, err := c.calendar.eventsBetween(calendarAddress, defaultCalendarID, start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "fake GetSharedCalendarView")
+	}
+	return normalizeEvents(events), nil
+}
+
+func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarParams) ([]*remote.ViewCalendarResponse, error) {
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	result := []*remote.ViewCalendarResponse{}
+	for _, params := range allParams {
+		res := &remote.ViewCalendarResponse{
+			RemoteUserID: params.RemoteUserID,
+			CalendarID:   params.CalendarID,
+		}
+		events, err := c.calendar.eventsBetweenLocked(params.RemoteUserID, params.CalendarID, params.StartTime, params.EndTime)
+		if err != nil {
+			res.Error = &remote.APIError{
+				Code:    "ErrorItemNotFound",
+				Message: err.Error(),
+			}
+		} else {
+			res.Events = normalizeEvents(events)
+		}
+		result = append(result, res)
+	}
+	return result, nil
+}
+
+func (c *client) GetMailboxSettings(remoteUserID string) (*remote.MailboxSettings, error) {
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	u := c.calendar.userLocked(remoteUserID)
+	settings := *c.calendar.mailboxes[u.ID]
+	return &settings, nil
+}
+
+func (c *client) SendMail(remoteUserID string, message *remote.Message) error {
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	u := c.calendar.userLocked(remoteUserID)
+	c.calendar.sentMail = append(c.calendar.sentMail, &sentMessage{
+		From:    u.ID,
+		Message: message,
+	})
+
+	c.Logger.With(bot.LogContext{
+		"from":    u.ID,
+		"subject": message.Subject,
+	}).Debugf("fake: sent mail.")
+	return nil
+}
+
+func (c *client) CreateCalendar(remoteUserID string, in *remote.Calendar) (*remote.Calendar, error) {
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	u := c.calendar.userLocked(remoteUserID)
+	cal := &remote.Calendar{
+		ID:    newID(),
+		Name:  in.Name,
+		Owner: u,
+	}
+	c.calendar.calendars[u.ID] = append(c.calendar.calendars[u.ID], cal)
+
+	copied := *cal
+	return &copied, nil
+}
+
+func (c *client) DeleteCalendar(remoteUserID, calendarID string) error {
+	if calendarID == defaultCalendarID {
+		return errors.New("fake DeleteCalendar: the default calendar can't be deleted")
+	}
+
+	return c.update(func() ([]change, error) {
+		u := c.calendar.userLocked(remoteUserID)
+		if c.calendar.calendarLocked(u.ID, calendarID) == nil {
+			return nil, errors.Errorf("fake DeleteCalendar: calendar %s not found", calendarID)
+		}
+
+		calendars := []*remote.Calendar{}
+		for _, cal := range c.calendar.calendars[u.ID] {
+			if cal.ID != calendarID {
+				calendars = append(calendars, cal)
+			}
+		}
+		c.calendar.calendars[u.ID] = calendars
+
+		changes := []change{}
+		for _, e := range c.calendar.events[u.ID] {
+			if e.CalendarID == calendarID {
+				changes = append(changes, c.calendar.deleteEventLocked(u.ID, e)...)
+			}
+		}
+		return changes, nil
+	})
+}
+
+func (c *client) GetSuperuserToken() (string, error) {
+	return superuserToken, nil
+}
+
+// CallJSON and CallFormPost are the raw calls to the Graph API, which the fake provider doesn't have.
+func (c *client) CallJSON(_, _ string, _, _ interface{}) ([]byte, error) {
+	return nil, remote.ErrNotImplemented
+}
+
+func (c *client) CallFormPost(_, _ string, _ url.Values, _ interface{}) ([]byte, error) {
+	return nil, remote.ErrNotImplemented
+}
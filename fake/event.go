@@ -0,0 +1,446 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package fake
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+// The events are stored with the response statuses of Graph, and converted like msgraph does for the calendar
+// views, so that the engine sees the same values from both providers.
+const (
+	responseAccepted  = "accepted"
+	responseTentative = "tentativelyAccepted"
+	responseDeclined  = "declined"
+	responseNone      = "none"
+	responseOrganizer = "organizer"
+)
+
+var responseStatusConversion = map[string]string{
+	responseAccepted:  remote.EventResponseStatusAccepted,
+	responseTentative: remote.EventResponseStatusTentative,
+	responseDeclined:  remote.EventResponseStatusDeclined,
+	responseNone:      remote.EventResponseStatusNotAnswered,
+	responseOrganizer: remote.EventResponseStatusNotAnswered,
+}
+
+// showAsForResponse is how an event shows in the calendar of an attendee after they respond.
+var showAsForResponse = map[string]string{
+	responseAccepted:  remote.ScheduleStatusBusy,
+	responseTentative: remote.ScheduleStatusTentative,
+	responseDeclined:  remote.ScheduleStatusFree,
+}
+
+func normalizeEvents(events []*remote.Event) []*remote.Event {
+	for _, e := range events {
+		if e.ResponseStatus != nil {
+			e.ResponseStatus.Response = responseStatusConversion[e.ResponseStatus.Response]
+		}
+	}
+	return events
+}
+
+func responseTime() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func (c *client) GetEvent(remoteUserID, eventID string) (*remote.Event, error) {
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	e, err := c.calendar.eventLocked(remoteUserID, eventID)
+	if err != nil {
+		return nil, errors.Wrap(err, "fake GetEvent")
+	}
+	return copyEvent(e.Event), nil
+}
+
+// CreateEvent adds the event to the default calendar of the organizer, and invites the attendees, adding the
+// event to their calendar.
+func (c *client) CreateEvent(remoteUserID string, in *remote.Event) (*remote.Event, error) {
+	if in == nil || in.Start == nil || in.End == nil {
+		return nil, errors.New("fake CreateEvent: the event must have a start and an end")
+	}
+
+	var out *remote.Event
+	err := c.update(func() ([]change, error) {
+		organizer := c.calendar.userLocked(remoteUserID)
+
+		e := copyEvent(in)
+		e.ID = newID()
+		e.ICalUID = newID()
+		e.IsOrganizer = true
+		e.IsCancelled = false
+		e.ResponseRequested = true
+		e.Organizer = &remote.Attendee{
+			EmailAddress: &remote.EmailAddress{
+				Address: organizer.Mail,
+				Name:    organizer.DisplayName,
+			},
+		}
+		e.ResponseStatus = &remote.EventResponseStatus{
+			Response: responseOrganizer,
+			Time:     responseTime(),
+		}
+		if e.ShowAs == "" {
+			e.ShowAs = remote.ScheduleStatusBusy
+		}
+		if e.Type == "" {
+			e.Type = "singleInstance"
+		}
+		for _, a := range e.Attendees {
+			a.Status = &remote.EventResponseStatus{Response: responseNone}
+			if a.Type == "" {
+				a.Type = "required"
+			}
+		}
+		c.calendar.events[organizer.ID][e.ID] = &storedEvent{
+			Event:      e,
+			CalendarID: defaultCalendarID,
+		}
+
+		changes := []change{{organizer.ID, e.ID, remote.ChangeTypeCreated}}
+		changes = append(changes, c.calendar.syncAttendeesLocked(e, false)...)
+		out = copyEvent(e)
+		return changes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateEvent changes the event like a PATCH of Graph: only the fields set in the event are changed. The changes
+// of the organizer are sent to the attendees, and the attendees respond again when the time changes.
+func (c *client) UpdateEvent(remoteUserID, eventID string, in *remote.Event) (*remote.Event, error) {
+	var out *remote.Event
+	err := c.update(func() ([]change, error) {
+		stored, err := c.calendar.eventLocked(remoteUserID, eventID)
+		if err != nil {
+			return nil, err
+		}
+		in = copyEvent(in)
+
+		changes := []change{{userID(remoteUserID), eventID, remote.ChangeTypeUpdated}}
+		if !stored.IsOrganizer {
+			patchPersonalFields(stored.Event, in)
+			out = copyEvent(stored.Event)
+			return changes, nil
+		}
+
+		previousAttendees := attendeeIDs(stored.Event)
+		timeChanged := patchEvent(stored.Event, in)
+		if timeChanged {
+			for _, a := range stored.Attendees {
+				a.Status = &remote.EventResponseStatus{Response: responseNone}
+				a.ProposedNewTime = nil
+			}
+		}
+
+		changes = append(changes, c.calendar.syncAttendeesLocked(stored.Event, timeChanged)...)
+		currentAttendees := attendeeIDs(stored.Event)
+		for id := range previousAttendees {
+			if !currentAttendees[id] {
+				changes = append(changes, c.calendar.cancelAttendeeEventLocked(id, eventID)...)
+			}
+		}
+		out = copyEvent(stored.Event)
+		return changes, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "fake UpdateEvent")
+	}
+	return out, nil
+}
+
+// CancelEvent deletes the event from the calendar of the organizer, and cancels it in the calendars of the
+// attendees.
+func (c *client) CancelEvent(remoteUserID, eventID, _ string) error {
+	err := c.update(func() ([]change, error) {
+		stored, err := c.calendar.eventLocked(remoteUserID, eventID)
+		if err != nil {
+			return nil, err
+		}
+		if !stored.IsOrganizer {
+			return nil, errors.New("only the organizer can cancel the event")
+		}
+		return c.calendar.deleteEventLocked(userID(remoteUserID), stored), nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "fake CancelEvent")
+	}
+	return nil
+}
+
+func (c *client) DeleteEvent(remoteUserID, eventID string) error {
+	err := c.update(func() ([]change, error) {
+		stored, err := c.calendar.eventLocked(remoteUserID, eventID)
+		if err != nil {
+			return nil, err
+		}
+		return c.calendar.deleteEventLocked(userID(remoteUserID), stored), nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "fake DeleteEvent")
+	}
+	return nil
+}
+
+func (c *client) AcceptEvent(remoteUserID, eventID string) error {
+	err := c.respond(remoteUserID, eventID, responseAccepted, nil)
+	if err != nil {
+		return errors.Wrap(err, "fake AcceptEvent")
+	}
+	return nil
+}
+
+func (c *client) DeclineEvent(remoteUserID, eventID string) error {
+	err := c.respond(remoteUserID, eventID, responseDeclined, nil)
+	if err != nil {
+		return errors.Wrap(err, "fake DeclineEvent")
+	}
+	return nil
+}
+
+func (c *client) TentativelyAcceptEvent(remoteUserID, eventID string) error {
+	err := c.respond(remoteUserID, eventID, responseTentative, nil)
+	if err != nil {
+		return errors.Wrap(err, "fake TentativelyAcceptEvent")
+	}
+	return nil
+}
+
+func (c *client) ProposeNewTime(remoteUserID, eventID, response string, proposedTime *remote.TimeSlot, _ string) error {
+	var graphResponse string
+	switch response {
+	case remote.EventResponseStatusDeclined:
+		graphResponse = responseDeclined
+	case remote.EventResponseStatusTentative:
+		graphResponse = responseTentative
+	default:
+		return errors.Errorf("fake ProposeNewTime: can't propose a new time with response %q", response)
+	}
+
+	err := c.respond(remoteUserID, eventID, graphResponse, proposedTime)
+	if err != nil {
+		return errors.Wrap(err, "fake ProposeNewTime")
+	}
+	return nil
+}
+
+// respond sets the response of the attendee in their calendar and in the calendar of the organizer.
+func (c *client) respond(remoteUserID, eventID, response string, proposedTime *remote.TimeSlot) error {
+	return c.update(func() ([]change, error) {
+		stored, err := c.calendar.eventLocked(remoteUserID, eventID)
+		if err != nil {
+			return nil, err
+		}
+		if stored.IsOrganizer {
+			return nil, errors.New("the organizer can't respond to the event")
+		}
+
+		attendeeID := userID(remoteUserID)
+		stored.ResponseStatus = &remote.EventResponseStatus{
+			Response: response,
+			Time:     responseTime(),
+		}
+		stored.ShowAs = showAsForResponse[response]
+		changes := []change{{attendeeID, eventID, remote.ChangeTypeUpdated}}
+
+		if stored.Organizer == nil || stored.Organizer.EmailAddress == nil {
+			return changes, nil
+		}
+		organizerID := userID(stored.Organizer.EmailAddress.Address)
+		organizerEvent, ok := c.calendar.events[organizerID][eventID]
+		if !ok {
+			return changes, nil
+		}
+		for _, a := range organizerEvent.Attendees {
+			if a.EmailAddress == nil || userID(a.EmailAddress.Address) != attendeeID {
+				continue
+			}
+			a.Status = &remote.EventResponseStatus{
+				Response: response,
+				Time:     stored.ResponseStatus.Time,
+			}
+			a.ProposedNewTime = proposedTime
+		}
+		return append(changes, change{organizerID, eventID, remote.ChangeTypeUpdated}), nil
+	})
+}
+
+// syncAttendeesLocked copies the event of the organizer to the calendars of the attendees, keeping the fields
+// that are their own, and their responses unless resetResponses is set.
+func (s *calendarStore) syncAttendeesLocked(organizerEvent *remote.Event, resetResponses bool) []change {
+	organizerID := ""
+	if organizerEvent.Organizer != nil && organizerEvent.Organizer.EmailAddress != nil {
+		organizerID = userID(organizerEvent.Organizer.EmailAddress.Address)
+	}
+
+	changes := []change{}
+	for id := range attendeeIDs(organizerEvent) {
+		if id == organizerID {
+			continue
+		}
+		attendee := s.userLocked(id)
+
+		e := copyEvent(organizerEvent)
+		e.IsOrganizer = false
+		e.ResponseStatus = &remote.EventResponseStatus{Response: responseNone}
+		e.ShowAs = remote.ScheduleStatusTentative
+		e.IsReminderOn = false
+		e.ReminderMinutesBeforeStart = 0
+		e.Categories = nil
+
+		changeType := remote.ChangeTypeCreated
+		calendarID := defaultCalendarID
+		if previous, ok := s.events[attendee.ID][e.ID]; ok {
+			changeType = remote.ChangeTypeUpdated
+			calendarID = previous.CalendarID
+			if !resetResponses {
+				e.ResponseStatus = previous.ResponseStatus
+				e.ShowAs = previous.ShowAs
+			}
+			e.IsReminderOn = previous.IsReminderOn
+			e.ReminderMinutesBeforeStart = previous.ReminderMinutesBeforeStart
+			e.Categories = previous.Categories
+		}
+
+		s.events[attendee.ID][e.ID] = &storedEvent{
+			Event:      e,
+			CalendarID: calendarID,
+		}
+		changes = append(changes, change{attendee.ID, e.ID, changeType})
+	}
+	return changes
+}
+
+// deleteEventLocked deletes the event from the calendar of the user. The event is cancelled for the attendees
+// when the user is the organizer.
+func (s *calendarStore) deleteEventLocked(owner string, e *storedEvent) []change {
+	delete(s.events[owner], e.ID)
+	changes := []change{{owner, e.ID, remote.ChangeTypeDeleted}}
+
+	if !e.IsOrganizer {
+		return changes
+	}
+	for id := range attendeeIDs(e.Event) {
+		if id != owner {
+			changes = append(changes, s.cancelAttendeeEventLocked(id, e.ID)...)
+		}
+	}
+	return changes
+}
+
+func (s *calendarStore) cancelAttendeeEventLocked(attendeeID, eventID string) []change {
+	e, ok := s.events[attendeeID][eventID]
+	if !ok || e.IsCancelled {
+		return nil
+	}
+
+	e.IsCancelled = true
+	e.ShowAs = remote.ScheduleStatusFree
+	e.Subject = "Canceled: " + e.Subject
+	return []change{{attendeeID, eventID, remote.ChangeTypeUpdated}}
+}
+
+func attendeeIDs(e *remote.Event) map[string]bool {
+	ids := map[string]bool{}
+	for _, a := range e.Attendees {
+		if a.EmailAddress != nil && strings.TrimSpace(a.EmailAddress.Address) != "" {
+			ids[userID(a.EmailAddress.Address)] = true
+		}
+	}
+	return ids
+}
+
+// patchEvent sets the fields of the event that are set in the patch, and tells whether the time of the event
+// changed. The attendees that stay keep their response.
+func patchEvent(e, patch *remote.Event) bool {
+	if patch.Subject != "" {
+		e.Subject = patch.Subject
+	}
+	if patch.Body != nil {
+		e.Body = patch.Body
+		e.BodyPreview = patch.Body.Content
+	}
+	if patch.Location != nil {
+		e.Location = patch.Location
+	}
+	if patch.Conference != nil {
+		e.Conference = patch.Conference
+	}
+	if patch.OnlineMeeting != nil {
+		e.OnlineMeeting = patch.OnlineMeeting
+	}
+	if patch.OnlineMeetingProvider != "" {
+		e.OnlineMeetingProvider = patch.OnlineMeetingProvider
+	}
+	if patch.Importance != "" {
+		e.Importance = patch.Importance
+	}
+	if patch.Sensitivity != "" {
+		e.Sensitivity = patch.Sensitivity
+	}
+	if patch.Recurrence != nil {
+		e.Recurrence = patch.Recurrence
+	}
+	if patch.IsAllDay {
+		e.IsAllDay = true
+	}
+	patchPersonalFields(e, patch)
+
+	if patch.Attendees != nil {
+		statuses := map[string]*remote.Attendee{}
+		for _, a := range e.Attendees {
+			if a.EmailAddress != nil {
+				statuses[userID(a.EmailAddress.Address)] = a
+			}
+		}
+		for _, a := range patch.Attendees {
+			a.Status = &remote.EventResponseStatus{Response: responseNone}
+			if a.Type == "" {
+				a.Type = "required"
+			}
+			if a.EmailAddress == nil {
+				continue
+			}
+			if previous, ok := statuses[userID(a.EmailAddress.Address)]; ok {
+				a.Status = previous.Status
+				a.ProposedNewTime = previous.ProposedNewTime
+			}
+		}
+		e.Attendees = patch.Attendees
+	}
+
+	timeChanged := false
+	if patch.Start != nil && (e.Start == nil || !patch.Start.Time().Equal(e.Start.Time())) {
+		e.Start = patch.Start
+		timeChanged = true
+	}
+	if patch.End != nil && (e.End == nil || !patch.End.Time().Equal(e.End.Time())) {
+		e.End = patch.End
+		timeChanged = true
+	}
+	return timeChanged
+}
+
+// patchPersonalFields sets the fields that each attendee has for themselves.
+func patchPersonalFields(e, patch *remote.Event) {
+	if patch.ShowAs != "" {
+		e.ShowAs = patch.ShowAs
+	}
+	if patch.Categories != nil {
+		e.Categories = patch.Categories
+	}
+	if patch.IsReminderOn || patch.ReminderMinutesBeforeStart != 0 {
+		e.IsReminderOn = patch.IsReminderOn
+		e.ReminderMinutesBeforeStart = patch.ReminderMinutesBeforeStart
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func nextNotification(t *testing.T, c remote.Client, notifications chan *remote.Notification) *remote.Notification {
+	select {
+	case n := <-notifications:
+		n, err := c.GetNotificationData(n)
+		require.NoError(t, err)
+		return n
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no notification received")
+		return nil
+	}
+}
+
+func TestEventLifecycle(t *testing.T) {
+	r, notifications := newTestRemote(t)
+	ctx := context.Background()
+	alice := r.MakeClient(ctx, &oauth2.Token{AccessToken: "alice@example.com"})
+	bob := r.MakeClient(ctx, &oauth2.Token{AccessToken: "bob@example.com"})
+
+	sub, err := bob.CreateMySubscription(r.conf.GetNotificationURL(), "bob@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "bob@example.com", sub.CreatorID)
+
+	start := time.Date(2030, time.January, 7, 10, 0, 0, 0, time.UTC)
+	created, err := alice.CreateEvent("alice@example.com", &remote.Event{
+		Subject: "Planning",
+		Start:   remote.NewDateTime(start, "UTC"),
+		End:     remote.NewDateTime(start.Add(time.Hour), "UTC"),
+		Attendees: []*remote.Attendee{{
+			EmailAddress: &remote.EmailAddress{Address: "Bob@example.com"},
+		}},
+	})
+	require.NoError(t, err)
+	require.True(t, created.IsOrganizer)
+	require.Equal(t, remote.ScheduleStatusBusy, created.ShowAs)
+
+	n := nextNotification(t, bob, notifications)
+	require.Equal(t, sub.ID, n.SubscriptionID)
+	require.Equal(t, sub.ClientState, n.ClientState)
+	require.Equal(t, remote.ChangeTypeCreated, n.ChangeType)
+	require.False(t, n.RecommendRenew)
+	require.Equal(t, created.ID, n.Event.ID)
+	require.Equal(t, "Planning", n.Event.Subject)
+	require.False(t, n.Event.IsOrganizer)
+	require.True(t, n.Event.ResponseRequested)
+	require.Equal(t, responseNone, n.Event.ResponseStatus.Response)
+
+	t.Run("the response of the attendee is sent to the organizer", func(t *testing.T) {
+		require.NoError(t, bob.AcceptEvent("bob@example.com", created.ID))
+		n := nextNotification(t, bob, notifications)
+		require.Equal(t, remote.ChangeTypeUpdated, n.ChangeType)
+
+		events, err := bob.GetDefaultCalendarView("bob@example.com", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, remote.EventResponseStatusAccepted, events[0].ResponseStatus.Response)
+		require.Equal(t, remote.ScheduleStatusBusy, events[0].ShowAs)
+
+		event, err := alice.GetEvent("alice@example.com", created.ID)
+		require.NoError(t, err)
+		require.Equal(t, responseAccepted, event.Attendees[0].Status.Response)
+	})
+
+	t.Run("the organizer can't respond", func(t *testing.T) {
+		require.Error(t, alice.DeclineEvent("alice@example.com", created.ID))
+	})
+
+	t.Run("a new time is proposed to the organizer", func(t *testing.T) {
+		proposed := &remote.TimeSlot{
+			Start: remote.NewDateTime(start.Add(2*time.Hour), "UTC"),
+			End:   remote.NewDateTime(start.Add(3*time.Hour), "UTC"),
+		}
+		require.Error(t, bob.ProposeNewTime("bob@example.com", created.ID, remote.EventResponseStatusAccepted, proposed, ""))
+		require.NoError(t, bob.ProposeNewTime("bob@example.com", created.ID, remote.EventResponseStatusTentative, proposed, "Later?"))
+		nextNotification(t, bob, notifications)
+
+		event, err := alice.GetEvent("alice@example.com", created.ID)
+		require.NoError(t, err)
+		require.Equal(t, responseTentative, event.Attendees[0].Status.Response)
+		require.Equal(t, proposed.Start.Time(), event.Attendees[0].ProposedNewTime.Start.Time())
+	})
+
+	t.Run("the attendees respond again when the time changes", func(t *testing.T) {
+		updated, err := alice.UpdateEvent("alice@example.com", created.ID, &remote.Event{
+			Start: remote.NewDateTime(start.Add(2*time.Hour), "UTC"),
+			End:   remote.NewDateTime(start.Add(3*time.Hour), "UTC"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Planning", updated.Subject)
+		require.Equal(t, responseNone, updated.Attendees[0].Status.Response)
+		require.Nil(t, updated.Attendees[0].ProposedNewTime)
+
+		n := nextNotification(t, bob, notifications)
+		require.Equal(t, remote.ChangeTypeUpdated, n.ChangeType)
+		require.Equal(t, responseNone, n.Event.ResponseStatus.Response)
+		require.Equal(t, start.Add(2*time.Hour), n.Event.Start.Time())
+	})
+
+	t.Run("the attendees can't update the event for everyone", func(t *testing.T) {
+		_, err := bob.UpdateEvent("bob@example.com", created.ID, &remote.Event{Subject: "Mine", ShowAs: remote.ScheduleStatusFree})
+		require.NoError(t, err)
+		nextNotification(t, bob, notifications)
+
+		event, err := bob.GetEvent("bob@example.com", created.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Planning", event.Subject)
+		require.Equal(t, remote.ScheduleStatusFree, event.ShowAs)
+	})
+
+	t.Run("the event is cancelled for the attendees", func(t *testing.T) {
+		require.Error(t, bob.CancelEvent("bob@example.com", created.ID, ""))
+		require.NoError(t, alice.CancelEvent("alice@example.com", created.ID, "Sorry"))
+
+		n := nextNotification(t, bob, notifications)
+		require.Equal(t, remote.ChangeTypeUpdated, n.ChangeType)
+		require.True(t, n.Event.IsCancelled)
+		require.Equal(t, "Canceled: Planning", n.Event.Subject)
+
+		_, err := alice.GetEvent("alice@example.com", created.ID)
+		require.Error(t, err)
+	})
+
+	t.Run("deleted events are identified by their ID", func(t *testing.T) {
+		require.NoError(t, bob.DeleteEvent("bob@example.com", created.ID))
+		n := nextNotification(t, bob, notifications)
+		require.Equal(t, remote.ChangeTypeDeleted, n.ChangeType)
+		require.Equal(t, &remote.Event{ID: created.ID}, n.Event)
+	})
+}
+
+func TestRenewSubscription(t *testing.T) {
+	r, notifications := newTestRemote(t)
+	alice := r.MakeClient(context.Background(), &oauth2.Token{AccessToken: "alice@example.com"})
+
+	// A subscription stored by the plugin before it restarted
+	stored := &remote.Subscription{
+		ID:          "subscription_id",
+		Resource:    "users/alice@example.com/events",
+		ClientState: "client_state",
+		CreatorID:   "alice@example.com",
+	}
+	renewed, err := alice.RenewSubscription(r.conf.GetNotificationURL(), "alice@example.com", stored)
+	require.NoError(t, err)
+	require.Equal(t, stored.ID, renewed.ID)
+	require.Equal(t, stored.ClientState, renewed.ClientState)
+
+	start := time.Date(2030, time.January, 7, 10, 0, 0, 0, time.UTC)
+	_, err = alice.CreateEvent("alice@example.com", &remote.Event{
+		Subject: "Focus",
+		Start:   remote.NewDateTime(start, "UTC"),
+		End:     remote.NewDateTime(start.Add(time.Hour), "UTC"),
+	})
+	require.NoError(t, err)
+
+	n := nextNotification(t, alice, notifications)
+	require.Equal(t, "subscription_id", n.SubscriptionID)
+	require.Equal(t, "client_state", n.ClientState)
+	require.Equal(t, "Focus", n.Event.Subject)
+
+	subs, err := alice.ListSubscriptions()
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+
+	require.NoError(t, alice.DeleteSubscription(renewed))
+	require.NoError(t, alice.DeleteSubscription(renewed))
+	subs, err = alice.ListSubscriptions()
+	require.NoError(t, err)
+	require.Empty(t, subs)
+}
+
+func TestDoBatchViewCalendarRequests(t *testing.T) {
+	r, _ := newTestRemote(t)
+	alice := r.MakeClient(context.Background(), &oauth2.Token{AccessToken: "alice@example.com"})
+
+	start := time.Date(2030, time.January, 7, 10, 0, 0, 0, time.UTC)
+	_, err := alice.CreateEvent("alice@example.com", &remote.Event{
+		Subject: "Planning",
+		Start:   remote.NewDateTime(start, "UTC"),
+		End:     remote.NewDateTime(start.Add(time.Hour), "UTC"),
+		Attendees: []*remote.Attendee{{
+			EmailAddress: &remote.EmailAddress{Address: "bob@example.com"},
+		}},
+	})
+	require.NoError(t, err)
+
+	views, err := alice.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{
+		{RemoteUserID: "alice@example.com", StartTime: start, EndTime: start.Add(time.Hour)},
+		{RemoteUserID: "bob@example.com", StartTime: start, EndTime: start.Add(time.Hour)},
+		{RemoteUserID: "bob@example.com", StartTime: start.Add(time.Hour), EndTime: start.Add(2 * time.Hour)},
+		{RemoteUserID: "bob@example.com", CalendarID: "unknown", StartTime: start, EndTime: start.Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	require.Len(t, views, 4)
+
+	require.Len(t, views[0].Events, 1)
+	require.Equal(t, remote.EventResponseStatusNotAnswered, views[0].Events[0].ResponseStatus.Response)
+	require.Len(t, views[1].Events, 1)
+	require.Equal(t, remote.EventResponseStatusNotAnswered, views[1].Events[0].ResponseStatus.Response)
+	require.Equal(t, remote.ScheduleStatusTentative, views[1].Events[0].ShowAs)
+	require.Empty(t, views[2].Events)
+	require.NotNil(t, views[3].Error)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+// Package fake is an in-memory calendar provider, to run the plugin without a calendar service, for example in a
+// local Mattermost for development, or in integration tests. The calendars are lost when the plugin restarts.
+package fake
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/httputils"
+)
+
+const Kind = "fake"
+
+const (
+	PathAuthorize = "/authorize"
+	PathLogin     = "/login"
+	PathToken     = "/token"
+
+	superuserToken = "superuser"
+)
+
+type impl struct {
+	conf       *config.Config
+	logger     bot.Logger
+	calendar   *calendarStore
+	httpClient *http.Client
+}
+
+// calendars is shared by the remotes created on configuration changes, so that the calendars stay while the
+// plugin runs.
+var calendars = newCalendarStore()
+
+func init() {
+	remote.Makers[Kind] = NewRemote
+}
+
+func NewRemote(conf *config.Config, logger bot.Logger) remote.Remote {
+	return newRemote(conf, logger, calendars)
+}
+
+func newRemote(conf *config.Config, logger bot.Logger, calendar *calendarStore) *impl {
+	return &impl{
+		conf:     conf,
+		logger:   logger,
+		calendar: calendar,
+		httpClient: &http.Client{
+			Timeout: webhookTimeout,
+		},
+	}
+}
+
+// MakeClient creates a client for the user the token was issued to. The access token is the email address of
+// the user.
+func (r *impl) MakeClient(_ context.Context, token *oauth2.Token) remote.Client {
+	c := &client{
+		remote:   r,
+		calendar: r.calendar,
+		Logger:   r.logger,
+	}
+	if token != nil && token.AccessToken != superuserToken {
+		c.mail = token.AccessToken
+	}
+	return c
+}
+
+func (r *impl) MakeSuperuserClient(ctx context.Context) (remote.Client, error) {
+	return r.MakeClient(ctx, &oauth2.Token{AccessToken: superuserToken}), nil
+}
+
+// NewOAuth2Config points to the login of the fake provider, served by the plugin under config.PathRemote.
+func (r *impl) NewOAuth2Config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:    Kind,
+		RedirectURL: r.conf.PluginURL + config.FullPathOAuth2Redirect,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   r.conf.PluginURL + config.PathRemote + PathAuthorize,
+			TokenURL:  r.conf.PluginURL + config.PathRemote + PathToken,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+}
+
+func (r *impl) CheckConfiguration(_ config.StoredConfig) error {
+	return nil
+}
+
+var loginTemplate = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Fake calendar</title></head>
+<body>
+<h1>Connect to the fake calendar</h1>
+<p>The events of the fake calendar are kept in memory until the plugin restarts.</p>
+<form method="get" action="{{.LoginURL}}">
+<input type="hidden" name="redirect_uri" value="{{.RedirectURI}}">
+<input type="hidden" name="state" value="{{.State}}">
+<label>Email address: <input type="email" name="email" required autofocus></label>
+<button type="submit">Connect</button>
+</form>
+</body>
+</html>
+`))
+
+// ServeHTTP serves the login of the fake provider. The users sign in with any email address, which becomes the
+// code, and then the access token.
+func (r *impl) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch strings.TrimSuffix(req.URL.Path, "/") {
+	case PathAuthorize:
+		w.Header().Set("Content-Type", "text/html")
+		err := loginTemplate.Execute(w, map[string]string{
+			"LoginURL":    r.conf.PluginURLPath + config.PathRemote + PathLogin,
+			"RedirectURI": req.FormValue("redirect_uri"),
+			"State":       req.FormValue("state"),
+		})
+		if err != nil {
+			r.logger.Warnf("fake: failed to render the login page. err=%v", err)
+		}
+
+	case PathLogin:
+		// Only the plugin can be redirected to
+		redirectURL, err := url.Parse(req.FormValue("redirect_uri"))
+		if err != nil || redirectURL.String() != r.conf.PluginURL+config.FullPathOAuth2Redirect {
+			http.Error(w, "invalid redirect URI", http.StatusBadRequest)
+			return
+		}
+		email := strings.TrimSpace(req.FormValue("email"))
+		if email == "" {
+			http.Error(w, "missing email address", http.StatusBadRequest)
+			return
+		}
+
+		q := redirectURL.Query()
+		q.Set("code", email)
+		q.Set("state", req.FormValue("state"))
+		redirectURL.RawQuery = q.Encode()
+		http.Redirect(w, req, redirectURL.String(), http.StatusFound)
+
+	case PathToken:
+		code := req.FormValue("code")
+		if req.FormValue("grant_type") == "refresh_token" {
+			code = req.FormValue("refresh_token")
+		}
+		if code == "" {
+			http.Error(w, "missing code", http.StatusBadRequest)
+			return
+		}
+		r.calendar.user(code)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = httputils.WriteJSONResponse(w, map[string]interface{}{
+			"access_token":  code,
+			"refresh_token": code,
+			"token_type":    "Bearer",
+			"expires_in":    365 * 24 * 60 * 60,
+		}, http.StatusOK)
+
+	default:
+		http.NotFound(w, req)
+	}
+}
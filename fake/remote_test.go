@@ -0,0 +1,116 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package fake
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const testPluginURLPath = "/plugins/com.mattermost.mscalendar"
+
+// newTestRemote serves the pages of the remote and the webhook endpoint of the plugin, and returns the
+// notifications received by the webhook endpoint.
+func newTestRemote(t *testing.T) (*impl, chan *remote.Notification) {
+	notifications := make(chan *remote.Notification, 100)
+
+	var r *impl
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, testPluginURLPath)
+		switch {
+		case path == config.FullPathEventNotification:
+			for _, n := range r.HandleWebhook(w, req) {
+				notifications <- n
+			}
+		case strings.HasPrefix(path, config.PathRemote):
+			req.URL.Path = strings.TrimPrefix(path, config.PathRemote)
+			r.ServeHTTP(w, req)
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	conf := &config.Config{
+		PluginURL:     server.URL + testPluginURLPath,
+		PluginURLPath: testPluginURLPath,
+	}
+	r = newRemote(conf, &bot.NilLogger{}, newCalendarStore())
+	return r, notifications
+}
+
+func TestOAuth2(t *testing.T) {
+	r, _ := newTestRemote(t)
+	oconf := r.NewOAuth2Config()
+	noRedirect := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := http.Get(oconf.AuthCodeURL("state_user_id"))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, string(body), `action="`+testPluginURLPath+config.PathRemote+PathLogin+`"`)
+	require.Contains(t, string(body), `value="state_user_id"`)
+
+	t.Run("the user is redirected to the plugin with the email address as code", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("email", "Alice@Example.com")
+		q.Set("state", "state_user_id")
+		q.Set("redirect_uri", oconf.RedirectURL)
+		resp, err := noRedirect.Get(r.conf.PluginURL + config.PathRemote + PathLogin + "?" + q.Encode())
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+
+		location, err := url.Parse(resp.Header.Get("Location"))
+		require.NoError(t, err)
+		require.Equal(t, oconf.RedirectURL, location.Scheme+"://"+location.Host+location.Path)
+		require.Equal(t, "state_user_id", location.Query().Get("state"))
+
+		token, err := oconf.Exchange(context.Background(), location.Query().Get("code"))
+		require.NoError(t, err)
+		require.Equal(t, "Alice@Example.com", token.AccessToken)
+		require.True(t, token.Expiry.After(time.Now().Add(24*time.Hour)))
+
+		me, err := r.MakeClient(context.Background(), token).GetMe()
+		require.NoError(t, err)
+		require.Equal(t, "alice@example.com", me.ID)
+		require.Equal(t, "alice@example.com", me.Mail)
+		require.Equal(t, "alice", me.DisplayName)
+	})
+
+	t.Run("the user can't be redirected out of the plugin", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("email", "alice@example.com")
+		q.Set("redirect_uri", "https://example.com/steal")
+		resp, err := noRedirect.Get(r.conf.PluginURL + config.PathRemote + PathLogin + "?" + q.Encode())
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("the superuser client has no user", func(t *testing.T) {
+		c, err := r.MakeSuperuserClient(context.Background())
+		require.NoError(t, err)
+		_, err = c.GetMe()
+		require.Error(t, err)
+	})
+}
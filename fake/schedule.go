@@ -0,0 +1,284 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package fake
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	defaultAvailabilityViewInterval = 30
+	defaultMaxCandidates            = 5
+	meetingTimeStep                 = 30 * time.Minute
+)
+
+var availabilityViews = map[string]byte{
+	remote.ScheduleStatusFree:             remote.AvailabilityViewFree,
+	remote.ScheduleStatusTentative:        remote.AvailabilityViewTentative,
+	remote.ScheduleStatusBusy:             remote.AvailabilityViewBusy,
+	remote.ScheduleStatusOof:              remote.AvailabilityViewOutOfOffice,
+	remote.ScheduleStatusWorkingElsewhere: remote.AvailabilityViewWorkingElsewhere,
+}
+
+// availabilityRanks orders the availabilities of overlapping events: a slot shows the least available one.
+var availabilityRanks = map[byte]int{
+	remote.AvailabilityViewFree:             0,
+	remote.AvailabilityViewWorkingElsewhere: 1,
+	remote.AvailabilityViewTentative:        2,
+	remote.AvailabilityViewBusy:             3,
+	remote.AvailabilityViewOutOfOffice:      4,
+}
+
+// blocksTime tells whether the event makes its attendee unavailable.
+func blocksTime(e *remote.Event) bool {
+	if e.IsCancelled || e.ShowAs == "" || e.ShowAs == remote.ScheduleStatusFree {
+		return false
+	}
+	return e.ResponseStatus == nil || e.ResponseStatus.Response != responseDeclined
+}
+
+// GetSchedule returns the availability of the users that used the fake provider. The others are not found, like
+// the addresses outside of the tenant in Graph.
+func (c *client) GetSchedule(requests []*remote.ScheduleUserInfo, startTime, endTime *remote.DateTime, availabilityViewInterval int) ([]*remote.ScheduleInformation, error) {
+	if startTime == nil || endTime == nil {
+		return nil, errors.New("fake GetSchedule: the start and end times are required")
+	}
+	if availabilityViewInterval <= 0 {
+		availabilityViewInterval = defaultAvailabilityViewInterval
+	}
+	interval := time.Duration(availabilityViewInterval) * time.Minute
+	start, end := startTime.Time(), endTime.Time()
+
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	result := []*remote.ScheduleInformation{}
+	for _, request := range requests {
+		mail := request.Mail
+		if mail == "" {
+			mail = request.RemoteUserID
+		}
+		info := &remote.ScheduleInformation{
+			ScheduleID: mail,
+		}
+		result = append(result, info)
+
+		if _, ok := c.calendar.users[userID(mail)]; !ok {
+			info.Error = &remote.ScheduleInformationError{
+				Message:      fmt.Sprintf("%s is not a user of the fake calendar", mail),
+				ResponseCode: "ErrorMailRecipientNotFound",
+			}
+			continue
+		}
+		events, err := c.calendar.eventsBetweenLocked(mail, defaultCalendarID, start, end)
+		if err != nil {
+			return nil, errors.Wrap(err, "fake GetSchedule")
+		}
+
+		view := []byte{}
+		for slotStart := start; slotStart.Before(end); slotStart = slotStart.Add(interval) {
+			slotEnd := slotStart.Add(interval)
+			availability := byte(remote.AvailabilityViewFree)
+			for _, e := range events {
+				if !blocksTime(e) || !overlaps(e, slotStart, slotEnd) {
+					continue
+				}
+				eventAvailability, ok := availabilityViews[e.ShowAs]
+				if ok && availabilityRanks[eventAvailability] > availabilityRanks[availability] {
+					availability = eventAvailability
+				}
+			}
+			view = append(view, availability)
+		}
+		info.AvailabilityView = remote.AvailabilityView(view)
+
+		for _, e := range events {
+			if !blocksTime(e) {
+				continue
+			}
+			item := &remote.ScheduleItem{
+				Start:     e.Start,
+				End:       e.End,
+				Status:    e.ShowAs,
+				Subject:   e.Subject,
+				IsPrivate: e.Sensitivity == "private",
+			}
+			if e.Location != nil {
+				item.Location = e.Location.DisplayName
+			}
+			info.ScheduleItems = append(info.ScheduleItems, item)
+		}
+	}
+	return result, nil
+}
+
+// FindMeetingTimes suggests the times in the constraint when the organizer and the attendees are all free, on
+// the half hour, and within the working hours of the organizer for the "work" activity domain.
+func (c *client) FindMeetingTimes(remoteUserID string, params *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error) {
+	var hours, minutes int
+	_, err := fmt.Sscanf(params.MeetingDuration, "PT%dH%dM", &hours, &minutes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fake FindMeetingTimes: invalid meeting duration %q", params.MeetingDuration)
+	}
+	duration := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	if duration <= 0 {
+		return nil, errors.New("fake FindMeetingTimes: the meeting duration must be positive")
+	}
+	maxCandidates := defaultMaxCandidates
+	if params.MaxCandidates != nil && *params.MaxCandidates > 0 {
+		maxCandidates = *params.MaxCandidates
+	}
+
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	organizer := c.calendar.userLocked(remoteUserID)
+	attendeeIDs := []string{organizer.ID}
+	for _, a := range params.Attendees {
+		if a.EmailAddress != nil && a.EmailAddress.Address != "" {
+			attendeeIDs = append(attendeeIDs, c.calendar.userLocked(a.EmailAddress.Address).ID)
+		}
+	}
+
+	var workTime *workingHours
+	if params.TimeConstraint == nil || params.TimeConstraint.ActivityDomain == "" || params.TimeConstraint.ActivityDomain == "work" {
+		workTime, err = newWorkingHours(c.calendar.mailboxes[organizer.ID])
+		if err != nil {
+			return nil, errors.Wrap(err, "fake FindMeetingTimes")
+		}
+	}
+
+	results := &remote.MeetingTimeSuggestionResults{}
+	var timeSlots []remote.TimeSlot
+	if params.TimeConstraint != nil {
+		timeSlots = params.TimeConstraint.TimeSlots
+	}
+	for _, slot := range timeSlots {
+		if slot.Start == nil || slot.End == nil {
+			continue
+		}
+		slotEnd := slot.End.Time()
+		for start := slot.Start.Time().Truncate(meetingTimeStep); !start.Add(duration).After(slotEnd); start = start.Add(meetingTimeStep) {
+			if len(results.MeetingTimeSuggestions) == maxCandidates {
+				return results, nil
+			}
+			end := start.Add(duration)
+			if start.Before(slot.Start.Time()) || (workTime != nil && !workTime.contains(start, end)) {
+				continue
+			}
+			if !c.calendar.allFreeLocked(attendeeIDs, start, end) {
+				continue
+			}
+
+			suggestion := &remote.MeetingTimeSuggestion{
+				MeetingTimeSlot: &remote.TimeSlot{
+					Start: remote.NewDateTime(start.UTC(), "UTC"),
+					End:   remote.NewDateTime(end.UTC(), "UTC"),
+				},
+				SuggestionReason:      "Suggested because it is one of the nearest times when all attendees are available.",
+				OrganizerAvailability: remote.ScheduleStatusFree,
+				Confidence:            100,
+				Order:                 int32(len(results.MeetingTimeSuggestions) + 1),
+			}
+			for i := range params.Attendees {
+				attendee := params.Attendees[i]
+				suggestion.AttendeeAvailability = append(suggestion.AttendeeAvailability, &remote.AttendeeAvailability{
+					Attendee:     &attendee,
+					Availability: remote.ScheduleStatusFree,
+				})
+			}
+			results.MeetingTimeSuggestions = append(results.MeetingTimeSuggestions, suggestion)
+		}
+	}
+
+	if len(results.MeetingTimeSuggestions) == 0 {
+		results.EmptySuggestionReason = "AttendeesUnavailable"
+	}
+	return results, nil
+}
+
+func (s *calendarStore) allFreeLocked(ids []string, start, end time.Time) bool {
+	for _, id := range ids {
+		events, err := s.eventsBetweenLocked(id, defaultCalendarID, start, end)
+		if err != nil {
+			return false
+		}
+		for _, e := range events {
+			if blocksTime(e) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type workingHours struct {
+	loc        *time.Location
+	start, end time.Duration
+	days       map[time.Weekday]bool
+}
+
+func newWorkingHours(mailbox *remote.MailboxSettings) (*workingHours, error) {
+	timeZone := mailbox.WorkingHours.TimeZone.Name
+	if timeZone == "" {
+		timeZone = mailbox.TimeZone
+	}
+	loc, err := time.LoadLocation(tz.Go(timeZone))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading working hours timezone %s", timeZone)
+	}
+	start, err := parseTimeOfDay(mailbox.WorkingHours.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseTimeOfDay(mailbox.WorkingHours.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	days := map[time.Weekday]bool{}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		for _, name := range mailbox.WorkingHours.DaysOfWeek {
+			if strings.EqualFold(name, d.String()) {
+				days[d] = true
+			}
+		}
+	}
+
+	return &workingHours{
+		loc:   loc,
+		start: start,
+		end:   end,
+		days:  days,
+	}, nil
+}
+
+// parseTimeOfDay parses the times of the working hours, like "09:00:00.0000000".
+func parseTimeOfDay(s string) (time.Duration, error) {
+	var hours, minutes int
+	_, err := fmt.Sscanf(s, "%d:%d", &hours, &minutes)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid working hours time %q", s)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+// contains tells whether the time range is within the working hours of a single day.
+func (wh *workingHours) contains(start, end time.Time) bool {
+	start = start.In(wh.loc)
+	end = end.In(wh.loc)
+	if !wh.days[start.Weekday()] {
+		return false
+	}
+
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, wh.loc)
+	return !start.Before(day.Add(wh.start)) && !end.After(day.Add(wh.end))
+}
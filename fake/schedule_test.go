@@ -0,0 +1,92 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestSchedule(t *testing.T) {
+	r, _ := newTestRemote(t)
+	alice := r.MakeClient(context.Background(), &oauth2.Token{AccessToken: "alice@example.com"})
+
+	// Monday
+	day := time.Date(2030, time.January, 7, 0, 0, 0, 0, time.UTC)
+	_, err := alice.CreateEvent("alice@example.com", &remote.Event{
+		Subject: "Planning",
+		Start:   remote.NewDateTime(day.Add(10*time.Hour), "UTC"),
+		End:     remote.NewDateTime(day.Add(11*time.Hour), "UTC"),
+		Attendees: []*remote.Attendee{{
+			EmailAddress: &remote.EmailAddress{Address: "bob@example.com"},
+		}},
+	})
+	require.NoError(t, err)
+
+	t.Run("availability", func(t *testing.T) {
+		infos, err := alice.GetSchedule([]*remote.ScheduleUserInfo{
+			{Mail: "alice@example.com"},
+			{Mail: "bob@example.com"},
+			{Mail: "carol@example.com"},
+		}, remote.NewDateTime(day.Add(9*time.Hour), "UTC"), remote.NewDateTime(day.Add(12*time.Hour), "UTC"), 30)
+		require.NoError(t, err)
+		require.Len(t, infos, 3)
+
+		require.Equal(t, remote.AvailabilityView("002200"), infos[0].AvailabilityView)
+		require.Len(t, infos[0].ScheduleItems, 1)
+		require.Equal(t, "Planning", infos[0].ScheduleItems[0].Subject)
+		require.Equal(t, remote.AvailabilityView("001100"), infos[1].AvailabilityView)
+		require.NotNil(t, infos[2].Error)
+		require.Equal(t, "carol@example.com", infos[2].ScheduleID)
+	})
+
+	t.Run("meeting times", func(t *testing.T) {
+		results, err := alice.FindMeetingTimes("alice@example.com", &remote.FindMeetingTimesParameters{
+			MeetingDuration: remote.FormatMeetingDuration(time.Hour),
+			MaxCandidates:   model.NewInt(3),
+			TimeConstraint: &remote.TimeConstraint{
+				ActivityDomain: "work",
+				TimeSlots: []remote.TimeSlot{{
+					Start: remote.NewDateTime(day.Add(7*time.Hour), "UTC"),
+					End:   remote.NewDateTime(day.Add(13*time.Hour), "UTC"),
+				}},
+			},
+			Attendees: []remote.Attendee{{
+				EmailAddress: &remote.EmailAddress{Address: "bob@example.com"},
+			}},
+		})
+		require.NoError(t, err)
+		require.Empty(t, results.EmptySuggestionReason)
+
+		starts := []time.Time{}
+		for _, suggestion := range results.MeetingTimeSuggestions {
+			starts = append(starts, suggestion.MeetingTimeSlot.Start.Time())
+			require.Len(t, suggestion.AttendeeAvailability, 1)
+		}
+		require.Equal(t, []time.Time{day.Add(9 * time.Hour), day.Add(11 * time.Hour), day.Add(11*time.Hour + 30*time.Minute)}, starts)
+	})
+
+	t.Run("no meeting times outside of the working hours", func(t *testing.T) {
+		results, err := alice.FindMeetingTimes("alice@example.com", &remote.FindMeetingTimesParameters{
+			MeetingDuration: remote.FormatMeetingDuration(30 * time.Minute),
+			TimeConstraint: &remote.TimeConstraint{
+				ActivityDomain: "work",
+				TimeSlots: []remote.TimeSlot{{
+					Start: remote.NewDateTime(day.Add(-24*time.Hour), "UTC"),
+					End:   remote.NewDateTime(day, "UTC"),
+				}},
+			},
+		})
+		require.NoError(t, err)
+		require.Empty(t, results.MeetingTimeSuggestions)
+		require.NotEmpty(t, results.EmptySuggestionReason)
+	})
+}
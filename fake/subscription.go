@@ -0,0 +1,292 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const (
+	subscribeTTL                      = 48 * time.Hour
+	renewSubscriptionBeforeExpiration = 12 * time.Hour
+
+	webhookTimeout = 30 * time.Second
+)
+
+// subscription is a subscription to the events of a user, the owner, created by the owner or, for shared
+// calendars, by another user.
+type subscription struct {
+	remote.Subscription
+	owner string
+}
+
+// webhook is the notification sent for a subscription, in the format of Graph.
+type webhook struct {
+	ChangeType                     string `json:"changeType"`
+	ClientState                    string `json:"clientState,omitempty"`
+	Resource                       string `json:"resource,omitempty"`
+	SubscriptionExpirationDateTime string `json:"subscriptionExpirationDateTime,omitempty"`
+	SubscriptionID                 string `json:"subscriptionId"`
+	ResourceData                   struct {
+		ID string `json:"id,omitempty"`
+	} `json:"resourceData"`
+}
+
+type delivery struct {
+	url  string
+	body []byte
+}
+
+func eventsResource(owner string) string {
+	return "users/" + owner + "/events"
+}
+
+// parseEventResource returns the owner and the ID of the event of a webhook resource.
+func parseEventResource(resource string) (string, string, error) {
+	parts := strings.Split(resource, "/")
+	if len(parts) != 4 || parts[0] != "users" || parts[2] != "events" {
+		return "", "", errors.Errorf("invalid resource %q", resource)
+	}
+	return parts[1], parts[3], nil
+}
+
+func (c *client) CreateMySubscription(notificationURL, remoteUserID string) (*remote.Subscription, error) {
+	return c.createSubscription(notificationURL, remoteUserID, remoteUserID), nil
+}
+
+func (c *client) CreateSharedCalendarSubscription(notificationURL, calendarAddress string) (*remote.Subscription, error) {
+	if c.mail == "" {
+		return nil, errors.New("fake CreateSharedCalendarSubscription: the superuser client has no user")
+	}
+	return c.createSubscription(notificationURL, calendarAddress, c.mail), nil
+}
+
+func (c *client) createSubscription(notificationURL, owner, creator string) *remote.Subscription {
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	u := c.calendar.userLocked(owner)
+	sub := &subscription{
+		Subscription: remote.Subscription{
+			ID:                 newID(),
+			Resource:           eventsResource(u.ID),
+			ChangeType:         "created,updated,deleted",
+			NotificationURL:    notificationURL,
+			ExpirationDateTime: time.Now().Add(subscribeTTL).Format(time.RFC3339),
+			ClientState:        newID(),
+			CreatorID:          userID(creator),
+		},
+		owner: u.ID,
+	}
+	c.calendar.subscriptions[sub.ID] = sub
+
+	c.Logger.With(bot.LogContext{
+		"subscriptionID":     sub.ID,
+		"resource":           sub.Resource,
+		"expirationDateTime": sub.ExpirationDateTime,
+	}).Debugf("fake: created subscription.")
+
+	out := sub.Subscription
+	return &out
+}
+
+// DeleteSubscription succeeds for unknown subscriptions, like the ones created before the plugin restarted.
+func (c *client) DeleteSubscription(sub *remote.Subscription) error {
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	delete(c.calendar.subscriptions, sub.ID)
+
+	c.Logger.With(bot.LogContext{
+		"subscriptionID": sub.ID,
+	}).Debugf("fake: deleted subscription.")
+	return nil
+}
+
+func (c *client) ListSubscriptions() ([]*remote.Subscription, error) {
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	subs := []*remote.Subscription{}
+	for _, sub := range c.calendar.subscriptions {
+		out := sub.Subscription
+		subs = append(subs, &out)
+	}
+	return subs, nil
+}
+
+// RenewSubscription extends the subscription. The subscriptions created before the plugin restarted are created
+// again, with their ID and client state, so that the users connected then get their notifications again.
+func (c *client) RenewSubscription(notificationURL, remoteUserID string, oldSub *remote.Subscription) (*remote.Subscription, error) {
+	c.calendar.Lock()
+	defer c.calendar.Unlock()
+
+	sub, ok := c.calendar.subscriptions[oldSub.ID]
+	if !ok {
+		owner := remoteUserID
+		if resource := strings.TrimSuffix(oldSub.Resource, "/events"); strings.HasPrefix(resource, "users/") {
+			owner = strings.TrimPrefix(resource, "users/")
+		}
+		u := c.calendar.userLocked(owner)
+
+		sub = &subscription{
+			Subscription: *oldSub,
+			owner:        u.ID,
+		}
+		sub.Resource = eventsResource(u.ID)
+		if sub.CreatorID == "" {
+			sub.CreatorID = userID(remoteUserID)
+		}
+		c.calendar.subscriptions[sub.ID] = sub
+	}
+	sub.NotificationURL = notificationURL
+	sub.ExpirationDateTime = time.Now().Add(subscribeTTL).Format(time.RFC3339)
+
+	c.Logger.With(bot.LogContext{
+		"subscriptionID":     sub.ID,
+		"expirationDateTime": sub.ExpirationDateTime,
+	}).Debugf("fake: renewed subscription.")
+
+	out := sub.Subscription
+	return &out, nil
+}
+
+// webhooksLocked returns the webhooks to send to the subscriptions for the changes. The expired subscriptions
+// aren't notified anymore, like in Graph.
+func (s *calendarStore) webhooksLocked(changes []change) []*delivery {
+	now := time.Now()
+	deliveries := []*delivery{}
+	for _, ch := range changes {
+		for _, sub := range s.subscriptions {
+			if sub.owner != ch.owner {
+				continue
+			}
+			expires, err := time.Parse(time.RFC3339, sub.ExpirationDateTime)
+			if err != nil || now.After(expires) {
+				continue
+			}
+
+			wh := &webhook{
+				ChangeType:                     ch.changeType,
+				ClientState:                    sub.ClientState,
+				Resource:                       eventsResource(ch.owner) + "/" + ch.eventID,
+				SubscriptionExpirationDateTime: sub.ExpirationDateTime,
+				SubscriptionID:                 sub.ID,
+			}
+			wh.ResourceData.ID = ch.eventID
+
+			body, err := json.Marshal(map[string]interface{}{
+				"value": []*webhook{wh},
+			})
+			if err != nil {
+				continue
+			}
+			deliveries = append(deliveries, &delivery{
+				url:  sub.NotificationURL,
+				body: body,
+			})
+		}
+	}
+	return deliveries
+}
+
+// send posts the webhooks in the background, in order.
+func (r *impl) send(deliveries []*delivery) {
+	if len(deliveries) == 0 {
+		return
+	}
+
+	go func() {
+		for _, d := range deliveries {
+			resp, err := r.httpClient.Post(d.url, "application/json", bytes.NewReader(d.body))
+			if err != nil {
+				r.logger.Warnf("fake: failed to send webhook. err=%v", err)
+				continue
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode >= http.StatusBadRequest {
+				r.logger.Warnf("fake: webhook was rejected with status %d.", resp.StatusCode)
+			}
+		}
+	}()
+}
+
+func (r *impl) HandleWebhook(w http.ResponseWriter, req *http.Request) []*remote.Notification {
+	rawData, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		r.logger.Infof("fake: failed to process webhook: `%v`.", err)
+		return nil
+	}
+
+	var v struct {
+		Value []*webhook `json:"value"`
+	}
+	err = json.Unmarshal(rawData, &v)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		r.logger.Infof("fake: failed to process webhook: `%v`.", err)
+		return nil
+	}
+
+	notifications := []*remote.Notification{}
+	for _, wh := range v.Value {
+		n := &remote.Notification{
+			SubscriptionID: wh.SubscriptionID,
+			ChangeType:     wh.ChangeType,
+			ClientState:    wh.ClientState,
+			IsBare:         true,
+			WebhookRawData: rawData,
+			Webhook:        wh,
+		}
+
+		expires, err := time.Parse(time.RFC3339, wh.SubscriptionExpirationDateTime)
+		if err == nil && time.Now().After(expires.Add(-renewSubscriptionBeforeExpiration)) {
+			n.RecommendRenew = true
+		}
+
+		notifications = append(notifications, n)
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	return notifications
+}
+
+func (c *client) GetNotificationData(orig *remote.Notification) (*remote.Notification, error) {
+	n := *orig
+	wh, ok := n.Webhook.(*webhook)
+	if !ok {
+		return nil, errors.New("fake GetNotificationData: unknown webhook")
+	}
+	n.ChangeType = wh.ChangeType
+	n.IsBare = false
+
+	if wh.ChangeType == remote.ChangeTypeDeleted {
+		// Deleted events can't be fetched, the notification only identifies them
+		n.Event = &remote.Event{ID: wh.ResourceData.ID}
+		return &n, nil
+	}
+
+	owner, eventID, err := parseEventResource(wh.Resource)
+	if err != nil {
+		return nil, errors.Wrap(err, "fake GetNotificationData")
+	}
+	event, err := c.GetEvent(owner, eventID)
+	if err != nil {
+		return nil, errors.Wrap(err, "fake GetNotificationData")
+	}
+	n.Event = event
+	return &n, nil
+}
@@ -4,7 +4,11 @@
 package msgraph
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"strings"
+	"time"
 )
 
 const maxNumRequestsPerBatch = 20
@@ -21,11 +25,99 @@ type fullBatchRequest struct {
 	Requests []*singleRequest `json:"requests"`
 }
 
+// rawBatchResponse keeps the responses of a batch as they are, to decode them in the type of the caller once
+// the throttled requests were sent again.
+type rawBatchResponse struct {
+	Responses []json.RawMessage `json:"responses"`
+}
+
+type singleResponseStatus struct {
+	Headers map[string]string `json:"headers"`
+	ID      string            `json:"id"`
+	Status  int               `json:"status"`
+}
+
+// batchRequest sends the batch, and sends again only the requests of the batch throttled by Graph, after the
+// wait it asks for.
 func (c *client) batchRequest(req fullBatchRequest, out interface{}) error {
-	u := "https://graph.microsoft.com/v1.0/$batch"
+	byID := map[string]*singleRequest{}
+	for _, r := range req.Requests {
+		byID[r.ID] = r
+	}
 
-	_, err := c.CallJSON(http.MethodPost, u, req, out)
-	return err
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	responses := map[string]json.RawMessage{}
+	pending := req.Requests
+	var waited time.Duration
+	for attempt := 0; len(pending) > 0; attempt++ {
+		batchRes := &rawBatchResponse{}
+		_, err := c.CallJSON(http.MethodPost, "/$batch", fullBatchRequest{Requests: pending}, batchRes)
+		if err != nil {
+			return err
+		}
+
+		pending = nil
+		throttled := map[string]json.RawMessage{}
+		var wait time.Duration
+		for _, raw := range batchRes.Responses {
+			status := singleResponseStatus{}
+			err = json.Unmarshal(raw, &status)
+			if err != nil {
+				return err
+			}
+
+			if isThrottled(status.Status) && attempt < maxRetries && byID[status.ID] != nil {
+				pending = append(pending, byID[status.ID])
+				throttled[status.ID] = raw
+				wait = max(wait, retryWait(headerValue(status.Headers, "Retry-After"), attempt))
+				continue
+			}
+			responses[status.ID] = raw
+		}
+
+		if len(pending) > 0 && !canRetryWithin(ctx, waited, wait) {
+			// The throttled requests are returned with their throttling error
+			for id, raw := range throttled {
+				responses[id] = raw
+			}
+			break
+		}
+		if len(pending) > 0 {
+			c.Debugf("msgraph: %d requests of the batch throttled, retrying in %v", len(pending), wait)
+			waited += wait
+			err = sleepContext(ctx, wait)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// The responses are kept in the order of the requests
+	result := rawBatchResponse{}
+	for _, r := range req.Requests {
+		if raw, ok := responses[r.ID]; ok {
+			result.Responses = append(result.Responses, raw)
+		}
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// headerValue returns the value of a header of a batch response, whatever the case of its name.
+func headerValue(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
 }
 
 func prepareBatchRequests(requests []*singleRequest) []fullBatchRequest {
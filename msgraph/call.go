@@ -88,7 +88,8 @@ func (c *client) call(method, path, contentType string, inBody io.Reader, out in
 		}
 		return responseData, nil
 
-	case http.StatusNoContent:
+	case http.StatusAccepted, http.StatusNoContent:
+		// Graph accepts some actions, like cancelling an event, without a body
 		return nil, nil
 	}
 
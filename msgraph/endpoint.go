@@ -0,0 +1,122 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+)
+
+// The endpoints of the global Azure cloud. National clouds, like US Government or China 21Vianet, and Graph
+// stand-ins for testing, are configured with GraphBaseURL and OAuth2AuthorityHost.
+const (
+	DefaultGraphBaseURL        = "https://graph.microsoft.com"
+	DefaultOAuth2AuthorityHost = "https://login.microsoftonline.com"
+
+	defaultGraphHost = "graph.microsoft.com"
+)
+
+// graphBaseURL returns the configured Graph endpoint, without the API version.
+func graphBaseURL(conf *config.Config) string {
+	return normalizeEndpointURL(conf.GraphBaseURL, DefaultGraphBaseURL)
+}
+
+// authorityURL returns the configured login endpoint.
+func authorityURL(conf *config.Config) string {
+	return normalizeEndpointURL(conf.OAuth2AuthorityHost, DefaultOAuth2AuthorityHost)
+}
+
+func normalizeEndpointURL(configured, defaultURL string) string {
+	u := strings.TrimRight(strings.TrimSpace(configured), "/")
+	if u == "" {
+		return defaultURL
+	}
+	if !strings.Contains(u, "://") {
+		u = "https://" + u
+	}
+	return u
+}
+
+func authorityEndpoint(conf *config.Config) oauth2.Endpoint {
+	tenant := conf.OAuth2Authority
+	if tenant == "" {
+		tenant = "common"
+	}
+	u := authorityURL(conf) + "/" + url.PathEscape(tenant)
+	return oauth2.Endpoint{
+		AuthURL:  u + "/oauth2/v2.0/authorize",
+		TokenURL: u + "/oauth2/v2.0/token",
+	}
+}
+
+// graphScope returns the permission scope for the configured Graph endpoint. Without the resource, Azure
+// grants the permission for the Graph endpoint of the global cloud.
+func graphScope(conf *config.Config, permission string) string {
+	base := graphBaseURL(conf)
+	if base == DefaultGraphBaseURL {
+		return permission
+	}
+	return base + "/" + permission
+}
+
+func validateEndpointURL(configured string) error {
+	if strings.TrimSpace(configured) == "" {
+		return nil
+	}
+	u, err := url.Parse(normalizeEndpointURL(configured, ""))
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return fmt.Errorf("%q is not a valid URL", configured)
+	}
+	return nil
+}
+
+// endpointTransport sends the requests for the Graph endpoint of the global cloud to the configured one, as
+// the msgraph library always builds its requests for the global cloud.
+type endpointTransport struct {
+	base   http.RoundTripper
+	target *url.URL
+}
+
+// newEndpointTransport returns the base transport as is when Graph isn't configured to another endpoint.
+func newEndpointTransport(base http.RoundTripper, conf *config.Config) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	graphURL := graphBaseURL(conf)
+	if graphURL == DefaultGraphBaseURL {
+		return base
+	}
+	target, err := url.Parse(graphURL)
+	if err != nil {
+		return base
+	}
+
+	return &endpointTransport{
+		base:   base,
+		target: target,
+	}
+}
+
+func (t *endpointTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != defaultGraphHost {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Host = t.target.Host
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	req.URL.Path = t.target.Path + req.URL.Path
+	if req.URL.RawPath != "" {
+		req.URL.RawPath = t.target.EscapedPath() + req.URL.RawPath
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func TestNewOAuth2Config(t *testing.T) {
+	for name, tc := range map[string]struct {
+		stored           config.StoredConfig
+		expectedAuthURL  string
+		expectedTokenURL string
+		expectedScope    string
+	}{
+		"global cloud": {
+			stored:           config.StoredConfig{OAuth2Authority: "tenant_id"},
+			expectedAuthURL:  "https://login.microsoftonline.com/tenant_id/oauth2/v2.0/authorize",
+			expectedTokenURL: "https://login.microsoftonline.com/tenant_id/oauth2/v2.0/token",
+			expectedScope:    "User.Read",
+		},
+		"US Government": {
+			stored: config.StoredConfig{
+				OAuth2Authority:     "tenant_id",
+				GraphBaseURL:        "https://graph.microsoft.us/",
+				OAuth2AuthorityHost: "login.microsoftonline.us",
+			},
+			expectedAuthURL:  "https://login.microsoftonline.us/tenant_id/oauth2/v2.0/authorize",
+			expectedTokenURL: "https://login.microsoftonline.us/tenant_id/oauth2/v2.0/token",
+			expectedScope:    "https://graph.microsoft.us/User.Read",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := NewRemote(&config.Config{StoredConfig: tc.stored}, &bot.NilLogger{}).(*impl)
+			conf := r.NewOAuth2Config()
+			require.Equal(t, tc.expectedAuthURL, conf.Endpoint.AuthURL)
+			require.Equal(t, tc.expectedTokenURL, conf.Endpoint.TokenURL)
+			require.Equal(t, "offline_access", conf.Scopes[0])
+			require.Equal(t, tc.expectedScope, conf.Scopes[1])
+		})
+	}
+}
+
+func TestCheckConfigurationEndpoints(t *testing.T) {
+	r := NewRemote(&config.Config{}, &bot.NilLogger{})
+	stored := config.StoredConfig{
+		OAuth2Authority:    "tenant_id",
+		OAuth2ClientID:     "client_id",
+		OAuth2ClientSecret: "client_secret",
+	}
+	require.NoError(t, r.CheckConfiguration(stored))
+
+	stored.GraphBaseURL = "http://localhost:8065/graph"
+	stored.OAuth2AuthorityHost = "login.chinacloudapi.cn"
+	require.NoError(t, r.CheckConfiguration(stored))
+
+	stored.GraphBaseURL = "ftp://graph.microsoft.us"
+	require.Error(t, r.CheckConfiguration(stored))
+}
+
+func TestRemoteWithGraphStandIn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login/tenant_id/oauth2/v2.0/token":
+			require.NoError(t, r.ParseForm())
+			require.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			require.Equal(t, "http://"+r.Host+"/graph/.default", r.PostForm.Get("scope"))
+			_ = json.NewEncoder(w).Encode(AuthResponse{TokenType: "Bearer", AccessToken: "superuser_token"})
+
+		case "/graph/v1.0/me":
+			require.Equal(t, "Bearer superuser_token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"id":"remote_id","displayName":"User","userPrincipalName":"user@example.com","mail":"user@example.com"}`))
+
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	conf := &config.Config{
+		StoredConfig: config.StoredConfig{
+			OAuth2Authority:     "tenant_id",
+			OAuth2ClientID:      "client_id",
+			OAuth2ClientSecret:  "client_secret",
+			GraphBaseURL:        server.URL + "/graph",
+			OAuth2AuthorityHost: server.URL + "/login",
+		},
+	}
+
+	c, err := NewRemote(conf, &bot.NilLogger{}).MakeSuperuserClient(context.Background())
+	require.NoError(t, err)
+
+	user, err := c.GetMe()
+	require.NoError(t, err)
+	require.Equal(t, "remote_id", user.ID)
+}
@@ -77,6 +77,75 @@ func (c *client) TentativelyAcceptEvent(remoteUserID, eventID string) error {
 	return nil
 }
 
+type proposeNewTimeRequest struct {
+	Comment         string           `json:"comment,omitempty"`
+	SendResponse    bool             `json:"sendResponse"`
+	ProposedNewTime *remote.TimeSlot `json:"proposedNewTime"`
+}
+
+// ProposeNewTime declines or tentatively accepts the event, proposing another time to the organizer.
+func (c *client) ProposeNewTime(remoteUserID, eventID, response string, proposedTime *remote.TimeSlot, comment string) error {
+	var action string
+	switch response {
+	case remote.EventResponseStatusDeclined:
+		action = "/decline"
+	case remote.EventResponseStatusTentative:
+		action = "/tentativelyAccept"
+	default:
+		return errors.Errorf("msgraph ProposeNewTime: can't propose a new time with response %q", response)
+	}
+
+	in := &proposeNewTimeRequest{
+		Comment:         comment,
+		SendResponse:    true,
+		ProposedNewTime: proposedTime,
+	}
+	err := c.postEventAction(remoteUserID, eventID, action, in)
+	if err != nil {
+		return errors.Wrap(err, "msgraph ProposeNewTime")
+	}
+	return nil
+}
+
+func (c *client) UpdateEvent(remoteUserID, eventID string, in *remote.Event) (*remote.Event, error) {
+	var out = remote.Event{}
+	err := c.rbuilder.Users().ID(remoteUserID).Events().ID(eventID).Request().JSONRequest(
+		c.ctx, http.MethodPatch, "", &in, &out)
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph UpdateEvent")
+	}
+	return &out, nil
+}
+
+// postEventAction posts an action on the event, like cancel, that Graph answers with 202 Accepted and no body.
+func (c *client) postEventAction(remoteUserID, eventID, action string, in interface{}) error {
+	_, err := c.CallJSON(http.MethodPost, c.rbuilder.Users().ID(remoteUserID).Events().ID(eventID).URL()+action, in, nil)
+	return err
+}
+
+type cancelEventRequest struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+func (c *client) CancelEvent(remoteUserID, eventID, comment string) error {
+	in := &cancelEventRequest{
+		Comment: comment,
+	}
+	err := c.postEventAction(remoteUserID, eventID, "/cancel", in)
+	if err != nil {
+		return errors.Wrap(err, "msgraph CancelEvent")
+	}
+	return nil
+}
+
+func (c *client) DeleteEvent(remoteUserID, eventID string) error {
+	err := c.rbuilder.Users().ID(remoteUserID).Events().ID(eventID).Request().Delete(c.ctx)
+	if err != nil {
+		return errors.Wrap(err, "msgraph DeleteEvent")
+	}
+	return nil
+}
+
 func (c *client) GetEventsBetweenDates(remoteUserID string, start, end time.Time) ([]*remote.Event, error) {
 	paramStr := getQueryParamStringForCalendarView(start, end)
 	res := &calendarViewResponse{}
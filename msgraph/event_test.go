@@ -0,0 +1,71 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	msgraph "github.com/yaegashi/msgraph.go/v1.0"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCancelEvent(t *testing.T) {
+	for name, tc := range map[string]struct {
+		status        int
+		body          string
+		expectedError string
+	}{
+		"accepted": {status: http.StatusAccepted},
+		"no body":  {status: http.StatusNoContent},
+		"not found": {
+			status:        http.StatusNotFound,
+			body:          `{"error":{"code":"ErrorItemNotFound","message":"The specified object was not found in the store."}}`,
+			expectedError: "msgraph CancelEvent: 404 Not Found: " + `{"error":{"code":"ErrorItemNotFound","message":"The specified object was not found in the store."}}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			httpClient := &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					require.Equal(t, http.MethodPost, req.Method)
+					require.Equal(t, "/v1.0/users/user_id/events/event_id/cancel", req.URL.Path)
+
+					in := cancelEventRequest{}
+					require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+					require.Equal(t, "Sorry", in.Comment)
+
+					return &http.Response{
+						StatusCode: tc.status,
+						Status:     fmt.Sprintf("%d %s", tc.status, http.StatusText(tc.status)),
+						Body:       io.NopCloser(strings.NewReader(tc.body)),
+						Request:    req,
+					}, nil
+				}),
+			}
+			c := &client{
+				ctx:        context.Background(),
+				httpClient: httpClient,
+				rbuilder:   msgraph.NewClient(httpClient),
+			}
+
+			err := c.CancelEvent("user_id", "event_id", "Sorry")
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package msgraph
 import (
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
@@ -35,10 +36,11 @@ func (c *client) GetDefaultCalendarView(remoteUserID string, start, end time.Tim
 
 func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarParams) ([]*remote.ViewCalendarResponse, error) {
 	requests := []*singleRequest{}
-	for _, params := range allParams {
+	for i, params := range allParams {
 		u := getCalendarViewURL(params)
 		req := &singleRequest{
-			ID:      params.RemoteUserID,
+			// Users can have several calendars, so the request index identifies the calendar view
+			ID:      strconv.Itoa(i),
 			URL:     u,
 			Method:  http.MethodGet,
 			Headers: map[string]string{},
@@ -61,8 +63,15 @@ func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarPar
 	result := []*remote.ViewCalendarResponse{}
 	for _, batchRes := range batchResponses {
 		for _, res := range batchRes.Responses {
+			i, err := strconv.Atoi(res.ID)
+			if err != nil || i < 0 || i >= len(allParams) {
+				c.Warnf("Unexpected calendar view response ID %q", res.ID)
+				continue
+			}
+
 			viewCalRes := &remote.ViewCalendarResponse{
-				RemoteUserID: res.ID,
+				RemoteUserID: allParams[i].RemoteUserID,
+				CalendarID:   allParams[i].CalendarID,
 				Events:       normalizeEvents(res.Body.Value),
 				Error:        res.Body.Error,
 			}
@@ -75,6 +84,9 @@ func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarPar
 
 func getCalendarViewURL(params *remote.ViewCalendarParams) string {
 	paramStr := getQueryParamStringForCalendarView(params.StartTime, params.EndTime)
+	if params.CalendarID != "" {
+		return "/Users/" + url.PathEscape(params.RemoteUserID) + "/calendars/" + url.PathEscape(params.CalendarID) + "/calendarView" + paramStr
+	}
 	return "/Users/" + url.PathEscape(params.RemoteUserID) + "/calendarView" + paramStr
 }
 
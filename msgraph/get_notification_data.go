@@ -5,6 +5,7 @@ package msgraph
 
 import (
 	"net/http"
+	"path"
 
 	"github.com/pkg/errors"
 
@@ -15,6 +16,18 @@ import (
 func (c *client) GetNotificationData(orig *remote.Notification) (*remote.Notification, error) {
 	n := *orig
 	wh := n.Webhook.(*webhook)
+	if wh.ChangeType == remote.ChangeTypeDeleted {
+		// Deleted events can't be fetched, the notification only identifies them
+		eventID := wh.ResourceData.ID
+		if eventID == "" {
+			eventID = path.Base(wh.Resource)
+		}
+		n.Event = &remote.Event{ID: eventID}
+		n.ChangeType = wh.ChangeType
+		n.IsBare = false
+		return &n, nil
+	}
+
 	switch wh.ResourceData.DataType {
 	case "#Microsoft.Graph.Event":
 		event := remote.Event{}
@@ -3,6 +3,7 @@ package msgraph
 import (
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/pkg/errors"
 
@@ -50,8 +51,11 @@ func (c *client) GetSchedule(requests []*remote.ScheduleUserInfo, startTime, end
 	}
 
 	allRequests := []*singleRequest{}
-	for _, req := range requests {
-		allRequests = append(allRequests, makeSingleRequestForGetSchedule(req, params))
+	for i, req := range requests {
+		singleReq := makeSingleRequestForGetSchedule(req, params)
+		// The same user can look up several schedules, so the IDs must not depend on the remote user ID only
+		singleReq.ID = strconv.Itoa(i)
+		allRequests = append(allRequests, singleReq)
 	}
 	batchRequests := prepareBatchRequests(allRequests)
 
@@ -17,12 +17,12 @@ type AuthResponse struct {
 }
 
 func (c *client) GetSuperuserToken() (string, error) {
-	u := "https://login.microsoftonline.com/" + url.PathEscape(c.conf.OAuth2Authority) + "/oauth2/v2.0/token"
+	u := authorityURL(c.conf) + "/" + url.PathEscape(c.conf.OAuth2Authority) + "/oauth2/v2.0/token"
 	res := AuthResponse{}
 
 	data := url.Values{}
 	data.Set("client_id", c.conf.OAuth2ClientID)
-	data.Set("scope", "https://graph.microsoft.com/.default")
+	data.Set("scope", graphBaseURL(c.conf)+"/.default")
 	data.Set("client_secret", c.conf.OAuth2ClientSecret)
 	data.Set("grant_type", "client_credentials")
 
@@ -25,6 +25,7 @@ type webhook struct {
 	SubscriptionID                 string `json:"subscriptionId"`
 	ResourceData                   struct {
 		DataType string `json:"@odata.type"`
+		ID       string `json:"id,omitempty"`
 	} `json:"resourceData"`
 }
 
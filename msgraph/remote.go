@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/microsoft"
 
 	msgraph "github.com/yaegashi/msgraph.go/v1.0"
 
@@ -40,6 +39,7 @@ func NewRemote(conf *config.Config, logger bot.Logger) remote.Remote {
 // MakeClient creates a new client for user-delegated permissions.
 func (r *impl) MakeClient(ctx context.Context, token *oauth2.Token) remote.Client {
 	httpClient := r.NewOAuth2Config().Client(ctx, token)
+	httpClient.Transport = newThrottledTransport(newEndpointTransport(httpClient.Transport, r.conf), tenantSemaphore(r.conf.OAuth2Authority), r.logger)
 	c := &client{
 		conf:       r.conf,
 		ctx:        ctx,
@@ -53,7 +53,8 @@ func (r *impl) MakeClient(ctx context.Context, token *oauth2.Token) remote.Clien
 // MakeSuperuserClient creates a new client used for app-only permissions.
 func (r *impl) MakeSuperuserClient(ctx context.Context) (remote.Client, error) {
 	httpClient := &http.Client{
-		Timeout: time.Second * 60,
+		Timeout:   time.Second * 60,
+		Transport: newThrottledTransport(newEndpointTransport(nil, r.conf), tenantSemaphore(r.conf.OAuth2Authority), r.logger),
 	}
 	c := &client{
 		conf:       r.conf,
@@ -81,12 +82,13 @@ func (r *impl) NewOAuth2Config() *oauth2.Config {
 		RedirectURL:  r.conf.PluginURL + config.FullPathOAuth2Redirect,
 		Scopes: []string{
 			"offline_access",
-			"User.Read",
-			"Calendars.ReadWrite",
-			"Calendars.ReadWrite.Shared",
-			"MailboxSettings.Read",
+			graphScope(r.conf, "User.Read"),
+			graphScope(r.conf, "Calendars.ReadWrite"),
+			graphScope(r.conf, "Calendars.ReadWrite.Shared"),
+			graphScope(r.conf, "MailboxSettings.Read"),
+			graphScope(r.conf, "Mail.Send"),
 		},
-		Endpoint: microsoft.AzureADEndpoint(r.conf.OAuth2Authority),
+		Endpoint: authorityEndpoint(r.conf),
 	}
 }
 
@@ -94,6 +96,12 @@ func (r *impl) CheckConfiguration(cfg config.StoredConfig) error {
 	if cfg.OAuth2ClientID == "" || cfg.OAuth2ClientSecret == "" || cfg.OAuth2Authority == "" {
 		return fmt.Errorf("OAuth2 credentials to be set in the config")
 	}
+	if err := validateEndpointURL(cfg.GraphBaseURL); err != nil {
+		return fmt.Errorf("invalid Graph base URL: %w", err)
+	}
+	if err := validateEndpointURL(cfg.OAuth2AuthorityHost); err != nil {
+		return fmt.Errorf("invalid login authority host: %w", err)
+	}
 
 	return nil
 }
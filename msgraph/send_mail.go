@@ -0,0 +1,31 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+type sendMailRequest struct {
+	Message         *remote.Message `json:"message"`
+	SaveToSentItems bool            `json:"saveToSentItems"`
+}
+
+// SendMail sends a message from the mailbox of the user, and saves it in their sent items.
+func (c *client) SendMail(remoteUserID string, message *remote.Message) error {
+	in := &sendMailRequest{
+		Message:         message,
+		SaveToSentItems: true,
+	}
+	// Graph answers with 202 Accepted and no body
+	_, err := c.CallJSON(http.MethodPost, c.rbuilder.Users().ID(remoteUserID).URL()+"/sendMail", in, nil)
+	if err != nil {
+		return errors.Wrap(err, "msgraph SendMail")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func (c *client) GetSharedCalendarView(calendarAddress string, start, end time.Time) ([]*remote.Event, error) {
+	path := c.sharedCalendarPath(calendarAddress) + "/calendarView" + getQueryParamStringForCalendarView(start, end)
+	res := &calendarViewResponse{}
+	_, err := c.CallJSON(http.MethodGet, path, nil, res)
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph GetSharedCalendarView")
+	}
+
+	return normalizeEvents(res.Value), nil
+}
+
+// sharedCalendarPath returns the path of the owner of the calendar: the group of a group mailbox, or the user of
+// a shared mailbox.
+func (c *client) sharedCalendarPath(calendarAddress string) string {
+	q := url.Values{}
+	q.Add("$filter", fmt.Sprintf("mail eq '%s'", strings.ReplaceAll(calendarAddress, "'", "''")))
+	q.Add("$select", "id")
+
+	var v struct {
+		Value []struct {
+			ID string `json:"id"`
+		} `json:"value"`
+	}
+	_, err := c.CallJSON(http.MethodGet, "/groups?"+q.Encode(), nil, &v)
+	if err != nil {
+		// Reading the groups needs more permissions than reading the shared mailboxes
+		c.Logger.With(bot.LogContext{
+			"calendarAddress": calendarAddress,
+			"err":             err.Error(),
+		}).Debugf("msgraph: failed to look up group mailbox.")
+	}
+	if err == nil && len(v.Value) > 0 {
+		return "/groups/" + url.PathEscape(v.Value[0].ID)
+	}
+
+	return "/users/" + url.PathEscape(calendarAddress)
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -24,8 +25,17 @@ func newRandomString() string {
 }
 
 func (c *client) CreateMySubscription(notificationURL, _ string) (*remote.Subscription, error) {
+	return c.createSubscription(notificationURL, "me/events")
+}
+
+func (c *client) CreateSharedCalendarSubscription(notificationURL, calendarAddress string) (*remote.Subscription, error) {
+	path := c.sharedCalendarPath(calendarAddress)
+	return c.createSubscription(notificationURL, strings.TrimPrefix(path, "/")+"/events")
+}
+
+func (c *client) createSubscription(notificationURL, resource string) (*remote.Subscription, error) {
 	sub := &remote.Subscription{
-		Resource:           "me/events",
+		Resource:           resource,
 		ChangeType:         "created,updated,deleted",
 		NotificationURL:    notificationURL,
 		ExpirationDateTime: time.Now().Add(subscribeTTL).Format(time.RFC3339),
@@ -33,7 +43,7 @@ func (c *client) CreateMySubscription(notificationURL, _ string) (*remote.Subscr
 	}
 	err := c.rbuilder.Subscriptions().Request().JSONRequest(c.ctx, http.MethodPost, "", sub, sub)
 	if err != nil {
-		return nil, errors.Wrap(err, "msgraph CreateMySubscription")
+		return nil, errors.Wrap(err, "msgraph CreateSubscription")
 	}
 
 	c.Logger.With(bot.LogContext{
@@ -0,0 +1,188 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const (
+	// maxRetries is the number of times a throttled request, or a throttled request of a batch, is sent again.
+	maxRetries = 3
+	// The wait before sending a throttled request again, when Graph doesn't tell with a Retry-After header,
+	// doubles with each retry.
+	retryBaseWait = time.Second
+	maxRetryWait  = 30 * time.Second
+	// The retries of a request stop when they would wait more than maxTotalRetryWait in all, or past the
+	// deadline of the request, so the throttling error is returned rather than a timeout.
+	maxTotalRetryWait = 45 * time.Second
+
+	// maxConcurrentRequestsPerTenant limits the requests in flight to Graph for a tenant, over all the clients.
+	maxConcurrentRequestsPerTenant = 16
+)
+
+var tenantSemaphores = struct {
+	sync.Mutex
+	byTenant map[string]chan struct{}
+}{
+	byTenant: map[string]chan struct{}{},
+}
+
+// tenantSemaphore returns the semaphore shared by the clients of the tenant.
+func tenantSemaphore(tenant string) chan struct{} {
+	tenantSemaphores.Lock()
+	defer tenantSemaphores.Unlock()
+
+	sem, ok := tenantSemaphores.byTenant[tenant]
+	if !ok {
+		sem = make(chan struct{}, maxConcurrentRequestsPerTenant)
+		tenantSemaphores.byTenant[tenant] = sem
+	}
+	return sem
+}
+
+// throttledTransport limits the requests in flight with a semaphore, and sends the requests throttled by Graph
+// again after the wait it asks for.
+type throttledTransport struct {
+	base   http.RoundTripper
+	sem    chan struct{}
+	logger bot.Logger
+}
+
+func newThrottledTransport(base http.RoundTripper, sem chan struct{}, logger bot.Logger) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &throttledTransport{
+		base:   base,
+		sem:    sem,
+		logger: logger,
+	}
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var waited time.Duration
+	for attempt := 0; ; attempt++ {
+		resp, err := t.roundTrip(req)
+		if err != nil || !isThrottled(resp.StatusCode) || attempt == maxRetries {
+			return resp, err
+		}
+		// The body can't be sent again
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait := retryWait(resp.Header.Get("Retry-After"), attempt)
+		if !canRetryWithin(req.Context(), waited, wait) {
+			return resp, nil
+		}
+		waited += wait
+		resp.Body.Close()
+		t.logger.Debugf("msgraph: request throttled with status %d, retrying in %v. method:%s, path:%s", resp.StatusCode, wait, req.Method, req.URL.Path)
+
+		err = sleepContext(req.Context(), wait)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// roundTrip sends the request once a slot of the semaphore is free. The slot is held until the body of the
+// response is closed, as the response is still being received until then.
+func (t *throttledTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	release := func() { <-t.sem }
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releasingBody frees the slot of the semaphore held by a response when its body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+func isThrottled(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// retryWait returns the wait asked for by the Retry-After header, in seconds or as a date, or an exponential
+// backoff when there is none.
+func retryWait(retryAfter string, attempt int) time.Duration {
+	wait := retryBaseWait << attempt
+	if seconds, err := strconv.Atoi(strings.TrimSpace(retryAfter)); err == nil && seconds >= 0 {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(retryAfter); err == nil {
+		wait = time.Until(date)
+	}
+
+	if wait < 0 {
+		return 0
+	}
+	if wait > maxRetryWait {
+		return maxRetryWait
+	}
+	return wait
+}
+
+// canRetryWithin returns whether a retry after wait, once waited was spent on the previous retries, keeps the
+// total wait under maxTotalRetryWait and ends before the deadline of ctx.
+func canRetryWithin(ctx context.Context, waited, wait time.Duration) bool {
+	if waited+wait > maxTotalRetryWait {
+		return false
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+		return false
+	}
+	return true
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	msgraph "github.com/yaegashi/msgraph.go/v1.0"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func newTestClient(t *testing.T, sem chan struct{}, handler http.HandlerFunc) *client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	conf := &config.Config{StoredConfig: config.StoredConfig{GraphBaseURL: server.URL}}
+	httpClient := &http.Client{
+		Transport: newThrottledTransport(newEndpointTransport(nil, conf), sem, &bot.NilLogger{}),
+	}
+	return &client{
+		conf:       conf,
+		ctx:        context.Background(),
+		httpClient: httpClient,
+		rbuilder:   msgraph.NewClient(httpClient),
+		Logger:     &bot.NilLogger{},
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	for name, tc := range map[string]struct {
+		retryAfter string
+		attempt    int
+		expected   time.Duration
+	}{
+		"seconds":               {retryAfter: "5", expected: 5 * time.Second},
+		"no header":             {retryAfter: "", attempt: 2, expected: 4 * time.Second},
+		"invalid header":        {retryAfter: "soon", expected: time.Second},
+		"longer than the max":   {retryAfter: "3600", expected: maxRetryWait},
+		"date in the past":      {retryAfter: "Wed, 21 Oct 2015 07:28:00 GMT", expected: 0},
+		"backoff after a while": {retryAfter: "", attempt: 10, expected: maxRetryWait},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, retryWait(tc.retryAfter, tc.attempt))
+		})
+	}
+}
+
+func TestCanRetryWithin(t *testing.T) {
+	soon, cancelSoon := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelSoon()
+
+	for name, tc := range map[string]struct {
+		ctx      context.Context
+		waited   time.Duration
+		wait     time.Duration
+		expected bool
+	}{
+		"no deadline":                  {ctx: context.Background(), wait: maxRetryWait, expected: true},
+		"over the total wait":          {ctx: context.Background(), waited: maxRetryWait, wait: maxRetryWait},
+		"before the deadline":          {ctx: soon, wait: time.Second, expected: true},
+		"past the deadline":            {ctx: soon, wait: 10 * time.Second},
+		"no wait at the total wait":    {ctx: context.Background(), waited: maxTotalRetryWait, expected: true},
+		"past the deadline once added": {ctx: soon, waited: time.Second, wait: 6 * time.Second},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, canRetryWithin(tc.ctx, tc.waited, tc.wait))
+		})
+	}
+}
+
+func TestThrottledTransport(t *testing.T) {
+	t.Run("throttled requests are sent again with their body", func(t *testing.T) {
+		var calls int32
+		c := newTestClient(t, make(chan struct{}, 1), func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"subject":"Review"}`, string(body))
+
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"event_id"}`))
+		})
+
+		out := &remote.Event{}
+		_, err := c.CallJSON(http.MethodPost, "/me/events", map[string]string{"subject": "Review"}, out)
+		require.NoError(t, err)
+		require.Equal(t, "event_id", out.ID)
+		require.EqualValues(t, 3, calls)
+	})
+
+	t.Run("the throttling error is returned after the retries", func(t *testing.T) {
+		var calls int32
+		c := newTestClient(t, make(chan struct{}, 1), func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"code":"ServiceUnavailable","message":"Try again later"}}`))
+		})
+
+		_, err := c.CallJSON(http.MethodGet, "/me", nil, nil)
+		require.Error(t, err)
+		require.EqualValues(t, maxRetries+1, calls)
+	})
+
+	t.Run("the throttling error is returned when the retry would end past the deadline", func(t *testing.T) {
+		var calls int32
+		c := newTestClient(t, make(chan struct{}, 1), func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set("Retry-After", "20")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"code":"TooManyRequests","message":"Too many requests"}}`))
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		c.ctx = ctx
+
+		start := time.Now()
+		_, err := c.CallJSON(http.MethodGet, "/me", nil, nil)
+		require.ErrorContains(t, err, "Too many requests")
+		require.EqualValues(t, 1, calls)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("the requests in flight are limited", func(t *testing.T) {
+		var inFlight, maxInFlight int32
+		c := newTestClient(t, make(chan struct{}, 2), func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := c.CallJSON(http.MethodGet, "/me", nil, nil)
+				require.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		require.LessOrEqual(t, maxInFlight, int32(2))
+	})
+
+	t.Run("the slot of a request is held until its body is closed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+		httpClient := &http.Client{
+			Transport: newThrottledTransport(nil, make(chan struct{}, maxConcurrentRequestsPerTenant), &bot.NilLogger{}),
+		}
+
+		bodies := []io.ReadCloser{}
+		for i := 0; i < maxConcurrentRequestsPerTenant; i++ {
+			resp, err := httpClient.Get(server.URL)
+			require.NoError(t, err)
+			bodies = append(bodies, resp.Body)
+		}
+
+		done := make(chan *http.Response)
+		go func() {
+			resp, err := httpClient.Get(server.URL)
+			require.NoError(t, err)
+			done <- resp
+		}()
+
+		select {
+		case <-done:
+			require.Fail(t, "the request was sent while all the slots were held")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		require.NoError(t, bodies[0].Close())
+		select {
+		case resp := <-done:
+			require.NoError(t, resp.Body.Close())
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the request was not sent once a body was closed")
+		}
+
+		for _, body := range bodies[1:] {
+			require.NoError(t, body.Close())
+		}
+	})
+}
+
+func TestBatchRequestRetriesThrottledRequests(t *testing.T) {
+	sent := [][]string{}
+	c := newTestClient(t, make(chan struct{}, 1), func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1.0/$batch", r.URL.Path)
+
+		req := fullBatchRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		ids := []string{}
+		for _, single := range req.Requests {
+			ids = append(ids, single.ID)
+		}
+		sent = append(sent, ids)
+
+		res := map[string]interface{}{}
+		responses := []map[string]interface{}{}
+		for _, id := range ids {
+			if id == "1" && len(sent) == 1 {
+				responses = append(responses, map[string]interface{}{
+					"id":      id,
+					"status":  http.StatusTooManyRequests,
+					"headers": map[string]string{"retry-after": "0"},
+					"body":    map[string]interface{}{"error": map[string]string{"code": "TooManyRequests"}},
+				})
+				continue
+			}
+			responses = append(responses, map[string]interface{}{
+				"id":     id,
+				"status": http.StatusOK,
+				"body": map[string]interface{}{"value": []*remote.Event{{
+					ID:             "event_" + id,
+					ResponseStatus: &remote.EventResponseStatus{Response: "accepted"},
+				}}},
+			})
+		}
+		res["responses"] = responses
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	})
+
+	views, err := c.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{
+		{RemoteUserID: "user1", StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)},
+		{RemoteUserID: "user2", StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)},
+		{RemoteUserID: "user3", StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"0", "1", "2"}, {"1"}}, sent)
+
+	require.Len(t, views, 3)
+	for i, view := range views {
+		require.Nil(t, view.Error)
+		require.Len(t, view.Events, 1)
+		require.Equal(t, []string{"user1", "user2", "user3"}[i], view.RemoteUserID)
+	}
+}
+
+func TestBatchRequestStopsRetryingPastTheDeadline(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, make(chan struct{}, 1), func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		req := fullBatchRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		responses := []map[string]interface{}{}
+		for _, single := range req.Requests {
+			responses = append(responses, map[string]interface{}{
+				"id":      single.ID,
+				"status":  http.StatusTooManyRequests,
+				"headers": map[string]string{"Retry-After": "20"},
+				"body":    map[string]interface{}{"error": map[string]string{"code": "TooManyRequests", "message": "Too many requests"}},
+			})
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"responses": responses}))
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c.ctx = ctx
+
+	views, err := c.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{
+		{RemoteUserID: "user1", StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, calls)
+	require.Len(t, views, 1)
+	require.NotNil(t, views[0].Error)
+}
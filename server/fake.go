@@ -0,0 +1,7 @@
+//go:build fake
+
+package main
+
+// The fake calendar provider is only built for development, with `make dist-fake`, as it connects any email
+// address without a password.
+import _ "github.com/mattermost/mattermost-plugin-mscalendar/fake"